@@ -0,0 +1,9 @@
+package api
+
+import _ "embed"
+
+//go:embed api-spec.json
+var Spec []byte
+
+//go:embed docs.html
+var Docs []byte
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -16,16 +17,33 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/metrics"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	"gopkg.in/yaml.v3"
 )
 
-var configPaths []string
+var (
+	configPaths []string
+	printConfig bool
+)
 
 func main() {
 	parseFlag()
 
+	if printConfig {
+		printAppConfig()
+
+		return
+	}
+
 	appConfig := config.NewAppConfig(configPaths)
 
+	tracerProvider := config.NewTracerProvider(appConfig)
+
 	pool := config.NewPgxPool(appConfig)
 	defer pool.Close()
 
@@ -34,16 +52,37 @@ func main() {
 
 	logger := setupLogger(appConfig, logFile)
 
+	stopAppConfigReloader := startAppConfigReloader(appConfig, logger)
+
 	router := httprouter.New()
 
-	routeConfig := InitializeController(pool, logger, router)
+	lifecycle := helper.NewLifecycle()
+
+	broker := stream.NewBrokerImpl(appConfig)
+
+	categoryCache := usecase.NewCategoryCache(appConfig, broker)
+
+	routeConfig := InitializeController(appConfig, pool, logger, router, lifecycle, broker, categoryCache)
 	routeConfig.Setup()
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", appConfig.Server.Host, appConfig.Server.Port),
-		Handler: setupMiddleware(appConfig, router, logger),
+		Handler: setupMiddleware(appConfig, router, logger, InitializeApiKeyUseCase(appConfig, pool), setupJwtVerifier(appConfig, logger)),
 	}
 
+	// Shutdown waits for the active requests and never for the hijacked
+	// WebSockets, the event streams and the WebSockets end at once and their
+	// clients reconnect to another instance
+	server.RegisterOnShutdown(broker.Close)
+
+	metricsServer := setupMetricsServer(appConfig, pool, logger)
+
+	stopWebhookDispatcher := startWebhookDispatcher(appConfig, pool, logger)
+
+	stopCategoryEventListener := startCategoryEventListener(appConfig, pool, logger, broker)
+
+	stopCategoryCache := startCategoryCache(appConfig, logger, categoryCache)
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
@@ -54,11 +93,35 @@ func main() {
 
 		logger.Warnf("received signal %s", signal)
 
+		// Readiness fails from now on, the load balancer stops sending new
+		// requests before the server stops accepting them
+		lifecycle.Drain()
+
+		logger.Warnf("draining the traffic for %s", appConfig.Server.ShutdownDelay*time.Second)
+
+		time.Sleep(appConfig.Server.ShutdownDelay * time.Second)
+
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		err := server.Shutdown(ctx)
 
+		if metricsServer != nil {
+			metricsServer.Shutdown(ctx)
+		}
+
+		// The outbox keeps the undelivered events, the next start sends them
+		stopWebhookDispatcher()
+
+		stopCategoryEventListener()
+
+		stopCategoryCache()
+
+		stopAppConfigReloader()
+
+		// The spans of the last requests are flushed before the process exits
+		tracerProvider.Shutdown(ctx)
+
 		logger.Warn("the server is shutting down...")
 
 		if err != nil {
@@ -88,6 +151,7 @@ func parseFlag() {
 		var configPathsFlag string
 
 		flag.StringVar(&configPathsFlag, "configPaths", "./", "Multiple config paths separated with commas")
+		flag.BoolVar(&printConfig, "print-config", false, "Print the effective config with the secrets redacted and exit")
 
 		flag.Parse()
 
@@ -95,6 +159,26 @@ func parseFlag() {
 	}
 }
 
+// printAppConfig prints the config after the environment overrides, the
+// validation errors go to stderr and fail the exit code, so a deploy checks
+// its config before it rolls out
+func printAppConfig() {
+	appConfig, err := config.LoadAppConfig(configPaths)
+	helper.LogStdPanicIfError(err)
+
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+
+	err = encoder.Encode(config.RedactedAppConfig(appConfig))
+	helper.LogStdPanicIfError(err)
+
+	if err := config.ValidateAppConfig(appConfig); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		os.Exit(1)
+	}
+}
+
 func createOrOpenLogFile(appConfig *config.AppConfig) *os.File {
 	logFile, err := os.OpenFile(
 		appConfig.Log.FilePath,
@@ -115,9 +199,189 @@ func setupLogger(appConfig *config.AppConfig, logFile *os.File) *logrus.Logger {
 	return logger
 }
 
-func setupMiddleware(appConfig *config.AppConfig, router *httprouter.Router, logger *logrus.Logger) middleware.HttpMiddleware {
-	authMiddleware := middleware.NewHttpAuthMiddleware(appConfig, router)
-	panicMiddleware := middleware.NewHttpPanicMiddleware(logger, authMiddleware)
+// setupMetricsServer serves /metrics on its own listener, so the metrics are
+// never exposed on the public API
+func setupMetricsServer(appConfig *config.AppConfig, pool db.PgxPool, logger *logrus.Logger) *http.Server {
+	if !appConfig.Metrics.Enabled {
+		return nil
+	}
+
+	metrics.Registry.MustRegister(metrics.NewPgxPoolCollector(pool))
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+
+	metricsServer := &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", appConfig.Metrics.Host, appConfig.Metrics.Port),
+		Handler: mux,
+	}
+
+	go func() {
+		logger.Infof("the metrics server is listening on %s", metricsServer.Addr)
+
+		err := metricsServer.ListenAndServe()
+
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.WithError(err).Error("the metrics server failed to listen and serve")
+		}
+	}()
+
+	return metricsServer
+}
+
+// startWebhookDispatcher runs the dispatcher in the background until the
+// returned func is called, the deliveries it cuts short are sent again once
+// their lease runs out
+func startWebhookDispatcher(appConfig *config.AppConfig, pool db.PgxPool, logger *logrus.Logger) func() {
+	if !appConfig.Webhook.Enabled {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	doneChan := make(chan bool)
+
+	dispatcher := InitializeWebhookDispatcher(appConfig, pool, logger)
+
+	go func() {
+		logger.Info("the webhook dispatcher is running")
+
+		dispatcher.Run(ctx)
+
+		doneChan <- true
+	}()
+
+	return func() {
+		cancel()
+		<-doneChan
+	}
+}
+
+// startCategoryEventListener publishes the category events of every instance
+// to the broker until the returned func is called
+func startCategoryEventListener(appConfig *config.AppConfig, pool db.PgxPool, logger *logrus.Logger, broker stream.Broker) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	doneChan := make(chan bool)
+
+	listener := InitializeCategoryEventListener(appConfig, pool, logger, broker)
+
+	go func() {
+		logger.Info("the category event listener is running")
+
+		listener.Run(ctx)
+
+		doneChan <- true
+	}()
+
+	return func() {
+		cancel()
+		<-doneChan
+	}
+}
+
+// startCategoryCache invalidates the category cache on the category events of
+// the broker until the returned func is called
+func startCategoryCache(appConfig *config.AppConfig, logger *logrus.Logger, categoryCache *usecase.CategoryCache) func() {
+	if !appConfig.CategoryCache.Enabled {
+		return func() {}
+	}
+
+	categoryCache.Start(context.Background())
+
+	logger.Info("the category cache is following the category events")
+
+	return categoryCache.Close
+}
+
+// startAppConfigReloader reloads the config on SIGHUP until the returned func
+// is called, an invalid config is logged and the current one stays
+func startAppConfigReloader(appConfig *config.AppConfig, logger *logrus.Logger) func() {
+	reloader := config.NewAppConfigReloaderImpl(configPaths, appConfig, logger)
+
+	reloader.Subscribe(func(snapshot *config.AppConfig) {
+		config.SetLogrusConfig(logger, snapshot)
+	})
+
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+
+	doneChan := make(chan bool)
+
+	go func() {
+		for {
+			select {
+			case <-hupChan:
+				logger.Info("reloading the config")
+
+				if err := reloader.Reload(); err != nil {
+					logger.WithError(err).Error("failed to reload the config, the current config stays")
+				}
+			case <-doneChan:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(hupChan)
+		close(doneChan)
+	}
+}
+
+// setupJwtVerifier loads the JWKS at the start, so a wrong JWKS shows up in
+// the logs at once. The server starts anyway, the first token loads it again
+func setupJwtVerifier(appConfig *config.AppConfig, logger *logrus.Logger) security.JwtVerifier {
+	if !slices.Contains(appConfig.Server.AuthModes, middleware.AuthModeJwt) {
+		return nil
+	}
+
+	jwkSet := security.NewJwkSetImpl(appConfig)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := jwkSet.Refresh(ctx); err != nil {
+		logger.WithError(err).Error("failed to load the JWKS")
+	}
+
+	return security.NewJwtVerifierImpl(appConfig, jwkSet)
+}
+
+func setupMiddleware(appConfig *config.AppConfig, router *httprouter.Router, logger *logrus.Logger, apiKeyUseCase usecase.ApiKeyUseCase, jwtVerifier security.JwtVerifier) middleware.HttpMiddleware {
+	var handler http.Handler = router
+
+	if appConfig.OpenApi.ValidateRequest {
+		handler = middleware.NewHttpOpenApiRequestMiddleware(handler)
+	}
+
+	handler = middleware.NewHttpRequestBodyMiddleware(appConfig, handler)
+
+	if appConfig.RateLimit.Enabled {
+		handler = middleware.NewHttpRateLimitMiddleware(appConfig, logger, config.NewRateLimiter(appConfig), handler)
+	}
+
+	authMiddleware := middleware.NewHttpAuthMiddleware(appConfig, apiKeyUseCase, jwtVerifier, handler)
+	corsMiddleware := middleware.NewHttpCorsMiddleware(appConfig, authMiddleware)
+	panicMiddleware := middleware.NewHttpPanicMiddleware(logger, corsMiddleware)
+
+	handler = panicMiddleware
+
+	if appConfig.OpenApi.ValidateResponse {
+		handler = middleware.NewHttpOpenApiResponseMiddleware(logger, handler)
+	}
+
+	if appConfig.Compression.Enabled {
+		handler = middleware.NewHttpCompressionMiddleware(appConfig, handler)
+	}
+
+	if appConfig.Log.Access.Enabled {
+		handler = middleware.NewHttpAccessLogMiddleware(appConfig, logger, handler)
+	}
+
+	if appConfig.Metrics.Enabled {
+		handler = middleware.NewHttpMetricsMiddleware(handler)
+	}
+
+	handler = middleware.NewHttpTracingMiddleware(handler)
 
-	return panicMiddleware
+	return middleware.NewHttpRequestIdMiddleware(security.NewIdGenImpl(), handler)
 }
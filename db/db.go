@@ -0,0 +1,29 @@
+package db
+
+import (
+	"embed"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// MigrationVersion is the version of the latest migration, the one the
+// database must be migrated to
+func MigrationVersion() int64 {
+	var latestVersion int64
+
+	fileNames, _ := fs.Glob(Migrations, "migrations/*.up.sql")
+
+	for _, fileName := range fileNames {
+		version, _, _ := strings.Cut(strings.TrimPrefix(fileName, "migrations/"), "_")
+
+		if parsedVersion, err := strconv.ParseInt(version, 10, 64); err == nil && parsedVersion > latestVersion {
+			latestVersion = parsedVersion
+		}
+	}
+
+	return latestVersion
+}
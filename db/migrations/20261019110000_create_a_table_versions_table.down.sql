@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS categories__bump_table_version ON categories;
+DROP FUNCTION IF EXISTS bump_table_version;
+DROP TABLE IF EXISTS table_versions;
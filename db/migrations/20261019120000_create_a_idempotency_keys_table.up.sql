@@ -0,0 +1,10 @@
+CREATE TABLE idempotency_keys(
+  key VARCHAR(300) NOT NULL,
+  request_hash VARCHAR(64) NOT NULL,
+  response_body JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (key)
+);
+
+CREATE INDEX idempotency_keys__expires_at__index ON idempotency_keys (expires_at);
@@ -0,0 +1,38 @@
+CREATE TABLE outbox_events(
+  id BIGSERIAL NOT NULL,
+  event_type VARCHAR(64) NOT NULL,
+  aggregate_id VARCHAR(36) NOT NULL,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  dispatched_at TIMESTAMPTZ,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX outbox_events__undispatched__index ON outbox_events (id) WHERE dispatched_at IS NULL;
+
+CREATE TABLE webhook_subscriptions(
+  id VARCHAR(36) NOT NULL,
+  url VARCHAR(2048) NOT NULL,
+  secret VARCHAR(64) NOT NULL,
+  event_types TEXT[] NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE webhook_deliveries(
+  id BIGSERIAL NOT NULL,
+  event_id BIGINT NOT NULL REFERENCES outbox_events (id) ON DELETE CASCADE,
+  subscription_id VARCHAR(36) NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+  status VARCHAR(16) NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_status_code INT,
+  last_error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  delivered_at TIMESTAMPTZ,
+  PRIMARY KEY (id),
+  UNIQUE (event_id, subscription_id)
+);
+
+CREATE INDEX webhook_deliveries__pending__index ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries__subscription_id__index ON webhook_deliveries (subscription_id, id);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS outbox_events__notify_category_event ON outbox_events;
+DROP FUNCTION IF EXISTS notify_category_event;
@@ -0,0 +1,18 @@
+-- Every instance of the server listens on the channel, so the category events
+-- reach the streams of all of them. NOTIFY is sent on commit only.
+CREATE FUNCTION notify_category_event() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM pg_notify('category_events', json_build_object(
+    'id', NEW.id,
+    'type', NEW.event_type,
+    'created_at', NEW.created_at,
+    'data', NEW.payload
+  )::text);
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER outbox_events__notify_category_event
+AFTER INSERT ON outbox_events
+FOR EACH ROW WHEN (NEW.event_type LIKE 'category.%') EXECUTE FUNCTION notify_category_event();
@@ -0,0 +1,47 @@
+CREATE OR REPLACE FUNCTION notify_category_event() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM pg_notify('category_events', json_build_object(
+    'id', NEW.id,
+    'type', NEW.event_type,
+    'created_at', NEW.created_at,
+    'data', NEW.payload
+  )::text);
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP POLICY IF EXISTS webhook_deliveries__tenant_isolation ON webhook_deliveries;
+DROP POLICY IF EXISTS webhook_subscriptions__tenant_isolation ON webhook_subscriptions;
+DROP POLICY IF EXISTS outbox_events__tenant_isolation ON outbox_events;
+DROP POLICY IF EXISTS idempotency_keys__tenant_isolation ON idempotency_keys;
+DROP POLICY IF EXISTS categories__tenant_isolation ON categories;
+
+ALTER TABLE webhook_deliveries DISABLE ROW LEVEL SECURITY;
+ALTER TABLE webhook_subscriptions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE outbox_events DISABLE ROW LEVEL SECURITY;
+ALTER TABLE idempotency_keys DISABLE ROW LEVEL SECURITY;
+ALTER TABLE categories DISABLE ROW LEVEL SECURITY;
+
+REVOKE ALL ON categories, idempotency_keys, outbox_events, table_versions, webhook_subscriptions, webhook_deliveries FROM app_tenant;
+REVOKE ALL ON SEQUENCE outbox_events_id_seq FROM app_tenant;
+
+-- The role is shared by the databases of the cluster, it is left in place
+
+ALTER TABLE api_keys DROP COLUMN tenant_id;
+ALTER TABLE webhook_subscriptions DROP COLUMN tenant_id;
+ALTER TABLE outbox_events DROP COLUMN tenant_id;
+
+-- Only the rows of the default tenant are kept, the ids of the other tenants
+-- may collide with them once the keys are global again
+DELETE FROM idempotency_keys WHERE tenant_id <> 'default';
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys DROP COLUMN tenant_id;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (key);
+
+DELETE FROM categories WHERE tenant_id <> 'default';
+ALTER TABLE categories DROP CONSTRAINT categories_pkey;
+ALTER TABLE categories DROP COLUMN tenant_id;
+ALTER TABLE categories ADD PRIMARY KEY (id);
+
+DROP TABLE tenants;
@@ -0,0 +1,102 @@
+CREATE TABLE tenants(
+  id VARCHAR(36) NOT NULL,
+  name VARCHAR(128) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (id),
+  UNIQUE (name)
+);
+
+-- The rows from before the tenants belong to the default tenant, and so do
+-- the rows written outside a tenant transaction
+INSERT INTO tenants (id, name) VALUES ('default', 'default');
+
+ALTER TABLE categories
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+ALTER TABLE categories
+  ALTER COLUMN tenant_id SET DEFAULT coalesce(nullif(current_setting('app.tenant_id', true), ''), 'default'),
+  DROP CONSTRAINT categories_pkey,
+  ADD PRIMARY KEY (tenant_id, id);
+
+ALTER TABLE idempotency_keys
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+ALTER TABLE idempotency_keys
+  ALTER COLUMN tenant_id SET DEFAULT coalesce(nullif(current_setting('app.tenant_id', true), ''), 'default'),
+  DROP CONSTRAINT idempotency_keys_pkey,
+  ADD PRIMARY KEY (tenant_id, key);
+
+ALTER TABLE outbox_events
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+ALTER TABLE outbox_events
+  ALTER COLUMN tenant_id SET DEFAULT coalesce(nullif(current_setting('app.tenant_id', true), ''), 'default');
+
+ALTER TABLE webhook_subscriptions
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+ALTER TABLE webhook_subscriptions
+  ALTER COLUMN tenant_id SET DEFAULT coalesce(nullif(current_setting('app.tenant_id', true), ''), 'default');
+
+ALTER TABLE api_keys
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+ALTER TABLE api_keys
+  ALTER COLUMN tenant_id DROP DEFAULT;
+
+-- The transactions of a tenant switch to this role, so the policies below
+-- hold even when the server connects as the owner of the tables or as a
+-- superuser. The owner bypasses them, the listener and the webhook dispatcher
+-- read the events of every tenant.
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'app_tenant') THEN
+    CREATE ROLE app_tenant NOLOGIN;
+  END IF;
+END
+$$;
+
+GRANT app_tenant TO CURRENT_USER;
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON categories, idempotency_keys TO app_tenant;
+GRANT SELECT, INSERT ON outbox_events TO app_tenant;
+GRANT USAGE ON SEQUENCE outbox_events_id_seq TO app_tenant;
+GRANT SELECT, INSERT, UPDATE ON table_versions TO app_tenant;
+GRANT SELECT, INSERT, DELETE ON webhook_subscriptions TO app_tenant;
+GRANT SELECT, UPDATE ON webhook_deliveries TO app_tenant;
+
+ALTER TABLE categories ENABLE ROW LEVEL SECURITY;
+ALTER TABLE idempotency_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE outbox_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE webhook_subscriptions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE webhook_deliveries ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY categories__tenant_isolation ON categories
+  USING (tenant_id = current_setting('app.tenant_id', true))
+  WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+CREATE POLICY idempotency_keys__tenant_isolation ON idempotency_keys
+  USING (tenant_id = current_setting('app.tenant_id', true))
+  WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+CREATE POLICY outbox_events__tenant_isolation ON outbox_events
+  USING (tenant_id = current_setting('app.tenant_id', true))
+  WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+CREATE POLICY webhook_subscriptions__tenant_isolation ON webhook_subscriptions
+  USING (tenant_id = current_setting('app.tenant_id', true))
+  WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- A delivery belongs to the tenant of its subscription, the subquery is
+-- filtered by the policy above
+CREATE POLICY webhook_deliveries__tenant_isolation ON webhook_deliveries
+  USING (subscription_id IN (SELECT id FROM webhook_subscriptions));
+
+CREATE OR REPLACE FUNCTION notify_category_event() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM pg_notify('category_events', json_build_object(
+    'id', NEW.id,
+    'tenant_id', NEW.tenant_id,
+    'type', NEW.event_type,
+    'created_at', NEW.created_at,
+    'data', NEW.payload
+  )::text);
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,10 @@
+ALTER TABLE api_keys ADD COLUMN signing_secret VARCHAR(64);
+
+CREATE TABLE request_nonces(
+  api_key_id VARCHAR(36) NOT NULL,
+  nonce VARCHAR(64) NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (api_key_id, nonce)
+);
+
+CREATE INDEX request_nonces__expires_at__index ON request_nonces (expires_at);
@@ -0,0 +1,37 @@
+DROP POLICY IF EXISTS table_versions__tenant_isolation ON table_versions;
+
+ALTER TABLE table_versions DISABLE ROW LEVEL SECURITY;
+
+DROP TRIGGER IF EXISTS categories__bump_table_version_on_truncate ON categories;
+DROP TRIGGER IF EXISTS categories__bump_table_version ON categories;
+
+CREATE OR REPLACE FUNCTION bump_table_version() RETURNS TRIGGER AS $$
+BEGIN
+  INSERT INTO table_versions (table_name, version, updated_at)
+  VALUES (TG_TABLE_NAME, 1, now())
+  ON CONFLICT (table_name) DO UPDATE
+  SET version = table_versions.version + 1, updated_at = now();
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The shared counter goes on from the highest one, so it never answers a
+-- version a client has already seen
+UPDATE table_versions v
+SET version = latest.version, updated_at = latest.updated_at
+FROM (
+  SELECT table_name, max(version) AS version, max(updated_at) AS updated_at
+  FROM table_versions
+  GROUP BY table_name
+) latest
+WHERE v.table_name = latest.table_name AND v.tenant_id = 'default';
+
+DELETE FROM table_versions WHERE tenant_id <> 'default';
+ALTER TABLE table_versions DROP CONSTRAINT table_versions_pkey;
+ALTER TABLE table_versions DROP COLUMN tenant_id;
+ALTER TABLE table_versions ADD PRIMARY KEY (table_name);
+
+CREATE TRIGGER categories__bump_table_version
+AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON categories
+FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
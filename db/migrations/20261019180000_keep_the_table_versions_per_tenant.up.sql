@@ -0,0 +1,60 @@
+-- Every tenant has its own counter, so the writes of a tenant never change the
+-- validators of the others
+ALTER TABLE table_versions
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT 'default' REFERENCES tenants (id);
+ALTER TABLE table_versions
+  ALTER COLUMN tenant_id SET DEFAULT coalesce(nullif(current_setting('app.tenant_id', true), ''), 'default'),
+  DROP CONSTRAINT table_versions_pkey,
+  ADD PRIMARY KEY (table_name, tenant_id);
+
+-- The row of a tenant is created by its first write, a tenant with rows
+-- already starts from the shared counter
+INSERT INTO table_versions (table_name, tenant_id, version, updated_at)
+SELECT DISTINCT 'categories', c.tenant_id, v.version, v.updated_at
+FROM categories c, table_versions v
+WHERE v.table_name = 'categories' AND c.tenant_id <> 'default';
+
+DROP TRIGGER categories__bump_table_version ON categories;
+
+-- A row level trigger knows the tenant of the row, a truncate has no rows so
+-- it bumps the counters of every tenant
+CREATE OR REPLACE FUNCTION bump_table_version() RETURNS TRIGGER AS $$
+DECLARE
+  row_tenant_id VARCHAR(36);
+BEGIN
+  IF TG_OP = 'TRUNCATE' THEN
+    UPDATE table_versions
+    SET version = version + 1, updated_at = now()
+    WHERE table_name = TG_TABLE_NAME;
+
+    RETURN NULL;
+  END IF;
+
+  IF TG_OP = 'DELETE' THEN
+    row_tenant_id := OLD.tenant_id;
+  ELSE
+    row_tenant_id := NEW.tenant_id;
+  END IF;
+
+  INSERT INTO table_versions (table_name, tenant_id, version, updated_at)
+  VALUES (TG_TABLE_NAME, row_tenant_id, 1, now())
+  ON CONFLICT (table_name, tenant_id) DO UPDATE
+  SET version = table_versions.version + 1, updated_at = now();
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER categories__bump_table_version
+AFTER INSERT OR UPDATE OR DELETE ON categories
+FOR EACH ROW EXECUTE FUNCTION bump_table_version();
+
+CREATE TRIGGER categories__bump_table_version_on_truncate
+AFTER TRUNCATE ON categories
+FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
+
+ALTER TABLE table_versions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY table_versions__tenant_isolation ON table_versions
+  USING (tenant_id = current_setting('app.tenant_id', true))
+  WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
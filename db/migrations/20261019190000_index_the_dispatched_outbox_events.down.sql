@@ -0,0 +1 @@
+DROP INDEX IF EXISTS outbox_events__dispatched_at__index;
@@ -0,0 +1 @@
+CREATE INDEX outbox_events__dispatched_at__index ON outbox_events (dispatched_at) WHERE dispatched_at IS NOT NULL;
@@ -0,0 +1,13 @@
+package cache
+
+// Cache is a bounded in-process cache. Every invalidation bumps its
+// generation, a value loaded before the latest invalidation may be stale and
+// Set drops it
+type Cache interface {
+	Get(key string) (any, bool)
+	Set(key string, value any, generation uint64)
+	Generation() uint64
+	Invalidate(keys ...string)
+	Purge()
+	Len() int
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+// lruCacheImpl evicts the least recently used entry once it holds size
+// entries, the expired entries are dropped when they are read
+type lruCacheImpl struct {
+	size       int
+	ttl        time.Duration
+	mutex      sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+	generation uint64
+}
+
+func NewLRUCacheImpl(size int, ttl time.Duration) Cache {
+	return &lruCacheImpl{
+		size:    size,
+		ttl:     ttl,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+func (c *lruCacheImpl) Get(key string) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(element.Value.(*entry).expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+
+	return element.Value.(*entry).value, true
+}
+
+func (c *lruCacheImpl) Set(key string, value any, generation uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if generation != c.generation || c.size <= 0 {
+		return
+	}
+
+	expiresAt := time.Now().Add(c.ttl)
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry).value = value
+		element.Value.(*entry).expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *lruCacheImpl) Generation() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.generation
+}
+
+func (c *lruCacheImpl) Invalidate(keys ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+}
+
+func (c *lruCacheImpl) Purge() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	c.entries = map[string]*list.Element{}
+	c.order.Init()
+}
+
+func (c *lruCacheImpl) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *lruCacheImpl) remove(element *list.Element) {
+	delete(c.entries, element.Value.(*entry).key)
+	c.order.Remove(element)
+}
@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/cache"
+)
+
+func TestLRUCacheGet(t *testing.T) {
+	// Arrange
+	lruCache := cache.NewLRUCacheImpl(2, time.Minute)
+
+	lruCache.Set("a", 1, lruCache.Generation())
+
+	// Action
+	// ---SUT (Subject Under Test)
+	value, hit := lruCache.Get("a")
+	_, missed := lruCache.Get("b")
+	// ---------------------------
+
+	// Assert
+	assert.True(t, hit)
+	assert.Equal(t, 1, value)
+	assert.False(t, missed)
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	// Arrange
+	lruCache := cache.NewLRUCacheImpl(2, time.Minute)
+
+	lruCache.Set("a", 1, lruCache.Generation())
+	lruCache.Set("b", 2, lruCache.Generation())
+
+	lruCache.Get("a")
+
+	// Action
+	// ---SUT (Subject Under Test)
+	lruCache.Set("c", 3, lruCache.Generation())
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, 2, lruCache.Len())
+
+	_, hit := lruCache.Get("b")
+	assert.False(t, hit)
+
+	_, hit = lruCache.Get("a")
+	assert.True(t, hit)
+
+	_, hit = lruCache.Get("c")
+	assert.True(t, hit)
+}
+
+func TestLRUCacheExpires(t *testing.T) {
+	// Arrange
+	lruCache := cache.NewLRUCacheImpl(2, 50*time.Millisecond)
+
+	lruCache.Set("a", 1, lruCache.Generation())
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	_, hit := lruCache.Get("a")
+	// ---------------------------
+
+	// Assert
+	assert.False(t, hit)
+	assert.Equal(t, 0, lruCache.Len())
+}
+
+func TestLRUCacheInvalidate(t *testing.T) {
+	t.Run("Removes The Keys", func(t *testing.T) {
+		// Arrange
+		lruCache := cache.NewLRUCacheImpl(3, time.Minute)
+
+		lruCache.Set("a", 1, lruCache.Generation())
+		lruCache.Set("b", 2, lruCache.Generation())
+		lruCache.Set("c", 3, lruCache.Generation())
+
+		// Action
+		// ---SUT (Subject Under Test)
+		lruCache.Invalidate("a", "b", "d")
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, 1, lruCache.Len())
+
+		_, hit := lruCache.Get("c")
+		assert.True(t, hit)
+	})
+
+	t.Run("Drops A Value Loaded Before It", func(t *testing.T) {
+		// Arrange
+		lruCache := cache.NewLRUCacheImpl(2, time.Minute)
+
+		generation := lruCache.Generation()
+
+		lruCache.Invalidate("a")
+
+		// Action
+		// ---SUT (Subject Under Test)
+		lruCache.Set("a", 1, generation)
+		// ---------------------------
+
+		// Assert
+		_, hit := lruCache.Get("a")
+		assert.False(t, hit)
+	})
+}
+
+func TestLRUCachePurge(t *testing.T) {
+	// Arrange
+	lruCache := cache.NewLRUCacheImpl(2, time.Minute)
+
+	lruCache.Set("a", 1, lruCache.Generation())
+	lruCache.Set("b", 2, lruCache.Generation())
+
+	generation := lruCache.Generation()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	lruCache.Purge()
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, 0, lruCache.Len())
+	assert.NotEqual(t, generation, lruCache.Generation())
+}
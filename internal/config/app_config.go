@@ -1,7 +1,13 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/spf13/viper"
@@ -9,72 +15,411 @@ import (
 )
 
 type (
+	JwtScopeMapping struct {
+		Claim  string
+		Scopes []string
+	}
+
+	Jwt struct {
+		JwksFile      string
+		JwksUrl       string
+		JwksRefresh   time.Duration `validate:"min=0"`
+		Issuer        string
+		Audience      string
+		ClockSkew     time.Duration `validate:"min=0"`
+		ScopeClaim    string
+		ScopeMappings []JwtScopeMapping
+		TenantClaim   string
+	}
+
 	Server struct {
-		Host   string
-		Port   int
-		ApiKey string
+		Host           string
+		Port           int      `validate:"required,min=1,max=65535"`
+		ApiKey         string   `validate:"required" secret:"true"`
+		AuthModes      []string `validate:"dive,oneof=apikey jwt"`
+		Jwt            Jwt
+		PublicTenant   string
+		TrustedProxies []string      `validate:"dive,ip|cidr"`
+		ShutdownDelay  time.Duration `validate:"min=0"`
 	}
 
 	Database struct {
-		Username        string
-		Password        string
-		Host            string
-		Port            int
-		DBName          string
-		MinConns        int
-		MaxConns        int
-		MaxConnLifeTime time.Duration
-		MaxConnIdleTime time.Duration
+		Dsn                   string `secret:"true"`
+		Username              string `validate:"required_without=Dsn"`
+		Password              string `secret:"true"`
+		Host                  string `validate:"required_without=Dsn"`
+		Port                  int    `validate:"required_without=Dsn,min=0,max=65535"`
+		DBName                string `validate:"required_without=Dsn"`
+		SslMode               string `validate:"omitempty,oneof=disable allow prefer require verify-ca verify-full"`
+		SslRootCert           string
+		SslCert               string `validate:"required_with=SslKey"`
+		SslKey                string `validate:"required_with=SslCert"`
+		SslPassword           string `secret:"true"`
+		SearchPath            string
+		ApplicationName       string
+		StatementTimeout      time.Duration `validate:"min=0"`
+		ConnectTimeout        time.Duration `validate:"min=0"`
+		ConnectRetries        int           `validate:"min=0"`
+		ConnectBackoffBase    time.Duration `validate:"min=0"`
+		ConnectBackoffMax     time.Duration `validate:"omitempty,gtefield=ConnectBackoffBase"`
+		MinConns              int           `validate:"min=0"`
+		MaxConns              int           `validate:"required,min=1,gtefield=MinConns"`
+		MaxConnLifeTime       time.Duration `validate:"min=0"`
+		MaxConnLifeTimeJitter time.Duration `validate:"min=0"`
+		MaxConnIdleTime       time.Duration `validate:"min=0"`
+		HealthCheckPeriod     time.Duration `validate:"min=0"`
+	}
+
+	AccessLog struct {
+		Enabled       bool
+		SampleRate    float64 `validate:"min=0,max=1"`
+		ExcludeRoutes []string
 	}
 
 	Log struct {
-		Level     int
-		Formatter string
-		Output    string
-		FilePath  string
+		Level     int    `validate:"min=0,max=6"`
+		Formatter string `validate:"oneof=text json"`
+		Output    string `validate:"oneof=console file"`
+		FilePath  string `validate:"required_if=Output file"`
+		Access    AccessLog
+	}
+
+	Metrics struct {
+		Enabled bool
+		Host    string
+		Port    int `validate:"required_if=Enabled true,min=0,max=65535"`
+	}
+
+	Tracing struct {
+		Exporter    string `validate:"omitempty,oneof=otlp stdout none"`
+		Endpoint    string
+		ServiceName string
+		SampleRatio float64 `validate:"min=0,max=1"`
+	}
+
+	RateLimitRedis struct {
+		Addr     string
+		Password string `secret:"true"`
+		DB       int
+	}
+
+	RateLimitGroup struct {
+		Name     string `validate:"required"`
+		Prefix   string
+		Requests int           `validate:"min=1"`
+		Period   time.Duration `validate:"min=1"`
+	}
+
+	RateLimit struct {
+		Enabled bool
+		Backend string `validate:"omitempty,oneof=memory redis"`
+		Redis   RateLimitRedis
+		Groups  []RateLimitGroup `validate:"dive"`
+	}
+
+	Cors struct {
+		AllowedOrigins   []string
+		AllowedMethods   []string
+		AllowedHeaders   []string
+		ExposedHeaders   []string
+		AllowCredentials bool
+		MaxAge           int `validate:"min=0"`
+	}
+
+	Request struct {
+		MaxBodySize           int64 `validate:"min=0"`
+		DisallowUnknownFields bool
+	}
+
+	Compression struct {
+		Enabled bool
+		MinSize int `validate:"min=0"`
+	}
+
+	Idempotency struct {
+		TTL time.Duration `validate:"min=0"`
+	}
+
+	Signing struct {
+		ClockSkew time.Duration `validate:"min=0"`
+		Headers   []string
+	}
+
+	Webhook struct {
+		Enabled      bool
+		PollInterval time.Duration `validate:"required_if=Enabled true,min=0"`
+		BatchSize    int           `validate:"required_if=Enabled true,min=0"`
+		Timeout      time.Duration `validate:"required_if=Enabled true,min=0"`
+		MaxAttempts  int           `validate:"required_if=Enabled true,min=0"`
+		BackoffBase  time.Duration `validate:"min=0"`
+		BackoffMax   time.Duration `validate:"omitempty,gtefield=BackoffBase"`
+		Retention    time.Duration `validate:"min=0"`
+	}
+
+	Stream struct {
+		LogSize      int           `validate:"min=0"`
+		BufferSize   int           `validate:"min=1"`
+		Heartbeat    time.Duration `validate:"min=1"`
+		ReconnectMax time.Duration `validate:"min=1"`
+	}
+
+	Websocket struct {
+		AuthTimeout      time.Duration `validate:"min=1"`
+		PingInterval     time.Duration `validate:"min=1"`
+		PongTimeout      time.Duration `validate:"min=1"`
+		WriteTimeout     time.Duration `validate:"min=1"`
+		SendBuffer       int           `validate:"min=1"`
+		MaxSubscriptions int           `validate:"min=1"`
+		MaxMessageSize   int64         `validate:"min=1"`
+	}
+
+	CategoryCache struct {
+		Enabled bool
+		Size    int           `validate:"required_if=Enabled true,min=0"`
+		TTL     time.Duration `validate:"min=0"`
+	}
+
+	HttpCache struct {
+		CacheControl string
+	}
+
+	OpenApi struct {
+		ValidateRequest  bool
+		ValidateResponse bool
+	}
+
+	Graphql struct {
+		MaxDepth      int `validate:"min=1"`
+		MaxComplexity int `validate:"min=1"`
 	}
 
 	Test struct {
-		Timeout time.Duration
+		Timeout time.Duration `validate:"required,min=1"`
 	}
 
 	AppConfig struct {
-		Server   *Server
-		Database *Database
-		Log      *Log
-		Test     *Test
+		Server        *Server
+		Database      *Database
+		Log           *Log
+		Metrics       *Metrics
+		Tracing       *Tracing
+		RateLimit     *RateLimit
+		Cors          *Cors
+		Request       *Request
+		Compression   *Compression
+		Idempotency   *Idempotency
+		Signing       *Signing
+		Webhook       *Webhook
+		Stream        *Stream
+		Websocket     *Websocket
+		CategoryCache *CategoryCache
+		HttpCache     *HttpCache
+		OpenApi       *OpenApi
+		Graphql       *Graphql
+		Test          *Test
+
+		// reloaded is shared by the loaded config and its reloaded snapshots
+		reloaded *atomic.Pointer[AppConfig]
 	}
 )
 
 type AppConfigPaths []string
 
+// appConfigEnvPrefix prefixes the environment variables which override the
+// keys of config.yaml, e.g. APP_SERVER_PORT overrides server.port and
+// APP_DATABASE_PASSWORD_FILE reads database.password from a secret file
+const appConfigEnvPrefix = "APP"
+
+// appConfigDefaults are the values of the keys config.yaml and the
+// environment leave out, so an older config.yaml keeps working as the keys
+// are added
+var appConfigDefaults = map[string]any{
+	"database.connectretries":    5,
+	"webhook.retention":          168,
+	"stream.logsize":             1000,
+	"stream.buffersize":          64,
+	"stream.heartbeat":           15,
+	"stream.reconnectmax":        30,
+	"websocket.authtimeout":      5,
+	"websocket.pinginterval":     20,
+	"websocket.pongtimeout":      10,
+	"websocket.writetimeout":     10,
+	"websocket.sendbuffer":       64,
+	"websocket.maxsubscriptions": 32,
+	"websocket.maxmessagesize":   4096,
+	"graphql.maxdepth":           8,
+	"graphql.maxcomplexity":      500,
+}
+
 var (
 	syncOnce  sync.Once
-	appConfig = &AppConfig{
-		Server:   new(Server),
-		Database: new(Database),
-		Log:      new(Log),
-		Test:     new(Test),
-	}
+	appConfig *AppConfig
 )
 
-func NewAppConfig(configPaths AppConfigPaths) *AppConfig {
-	syncOnce.Do(func() {
-		vp := viper.New()
+func newAppConfig() *AppConfig {
+	return &AppConfig{
+		Server:        new(Server),
+		Database:      new(Database),
+		Log:           new(Log),
+		Metrics:       new(Metrics),
+		Tracing:       new(Tracing),
+		RateLimit:     new(RateLimit),
+		Cors:          new(Cors),
+		Request:       new(Request),
+		Compression:   new(Compression),
+		Idempotency:   new(Idempotency),
+		Signing:       new(Signing),
+		Webhook:       new(Webhook),
+		Stream:        new(Stream),
+		Websocket:     new(Websocket),
+		CategoryCache: new(CategoryCache),
+		HttpCache:     new(HttpCache),
+		OpenApi:       new(OpenApi),
+		Graphql:       new(Graphql),
+		Test:          new(Test),
+		reloaded:      new(atomic.Pointer[AppConfig]),
+	}
+}
 
-		vp.SetConfigName("config")
-		vp.SetConfigType("yaml")
+// Current answers the latest snapshot the AppConfigReloader has applied, or
+// the config itself before the first reload. The hot reloadable settings are
+// read through it on every use, the other settings are read once at the start.
+func (c *AppConfig) Current() *AppConfig {
+	if c.reloaded == nil {
+		return c
+	}
 
-		for _, configPath := range configPaths {
-			vp.AddConfigPath(configPath)
-		}
+	if reloaded := c.reloaded.Load(); reloaded != nil {
+		return reloaded
+	}
 
-		err := vp.ReadInConfig()
+	return c
+}
+
+// NewAppConfig loads the config once and panics when it is invalid, so a
+// broken config never boots the server
+func NewAppConfig(configPaths AppConfigPaths) *AppConfig {
+	syncOnce.Do(func() {
+		loadedAppConfig, err := LoadAppConfig(configPaths)
 		helper.LogStdPanicIfError(err)
 
-		err = vp.Unmarshal(&appConfig)
+		err = ValidateAppConfig(loadedAppConfig)
 		helper.LogStdPanicIfError(err)
+
+		appConfig = loadedAppConfig
 	})
 
 	return appConfig
 }
+
+// LoadAppConfig reads config.yaml from the first path which has it and
+// applies the APP_ environment variables and the APP_*_FILE secret files over
+// it, without validating the result. The file is optional, so a container is
+// configured by the environment alone.
+func LoadAppConfig(configPaths AppConfigPaths) (*AppConfig, error) {
+	vp := viper.New()
+
+	vp.SetConfigName("config")
+	vp.SetConfigType("yaml")
+
+	for _, configPath := range configPaths {
+		vp.AddConfigPath(configPath)
+	}
+
+	for key, value := range appConfigDefaults {
+		vp.SetDefault(key, value)
+	}
+
+	vp.SetEnvPrefix(appConfigEnvPrefix)
+	vp.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	if err := vp.ReadInConfig(); err != nil {
+		var errNotFound viper.ConfigFileNotFoundError
+
+		if !errors.As(err, &errNotFound) {
+			return nil, err
+		}
+	}
+
+	// The keys are bound one by one, viper only looks up the environment of
+	// the keys it knows and config.yaml may leave some of them out
+	for _, key := range appConfigKeys(reflect.TypeOf(AppConfig{}), "") {
+		vp.BindEnv(key)
+
+		if err := setFromSecretFile(vp, key); err != nil {
+			return nil, err
+		}
+	}
+
+	loadedAppConfig := newAppConfig()
+
+	if err := vp.Unmarshal(loadedAppConfig); err != nil {
+		return nil, err
+	}
+
+	return loadedAppConfig, nil
+}
+
+// appConfigKeys lists the keys of the config fields, the lists of sections
+// like ratelimit.groups are left out as an environment variable can not
+// carry them
+func appConfigKeys(structType reflect.Type, prefix string) []string {
+	keys := []string{}
+
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		fieldType := field.Type
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		key := prefix + strings.ToLower(field.Name)
+
+		switch {
+		case fieldType.Kind() == reflect.Struct:
+			keys = append(keys, appConfigKeys(fieldType, key+".")...)
+		case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Struct:
+			continue
+		default:
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func appConfigEnvName(key string) string {
+	return appConfigEnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// setFromSecretFile sets the key from the file of its _FILE variable, e.g. a
+// mounted Docker or Kubernetes secret, the trailing newline of the file is
+// not part of the value
+func setFromSecretFile(vp *viper.Viper, key string) error {
+	envName := appConfigEnvName(key)
+
+	filePath, ok := os.LookupEnv(envName + "_FILE")
+
+	if !ok {
+		return nil
+	}
+
+	if _, ok := os.LookupEnv(envName); ok {
+		return fmt.Errorf("both %s and %s_FILE are set", envName, envName)
+	}
+
+	content, err := os.ReadFile(filePath)
+
+	if err != nil {
+		return fmt.Errorf("%s_FILE: %w", envName, err)
+	}
+
+	vp.Set(key, strings.TrimRight(string(content), "\r\n"))
+
+	return nil
+}
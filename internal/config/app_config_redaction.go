@@ -0,0 +1,58 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+const redactedValue = "[REDACTED]"
+
+// RedactedAppConfig maps the config by its config.yaml keys with the secret
+// fields redacted, the durations stay in the units config.yaml writes them
+func RedactedAppConfig(appConfig *AppConfig) map[string]any {
+	return redactedStruct(reflect.ValueOf(appConfig).Elem())
+}
+
+func redactedStruct(structValue reflect.Value) map[string]any {
+	settings := map[string]any{}
+
+	for i := range structValue.NumField() {
+		field := structValue.Type().Field(i)
+		fieldValue := structValue.Field(i)
+		key := strings.ToLower(field.Name)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				continue
+			}
+
+			fieldValue = fieldValue.Elem()
+		}
+
+		switch {
+		case field.Tag.Get("secret") == "true" && !fieldValue.IsZero():
+			settings[key] = redactedValue
+		case fieldValue.Kind() == reflect.Struct:
+			settings[key] = redactedStruct(fieldValue)
+		case fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() == reflect.Struct:
+			items := make([]map[string]any, 0, fieldValue.Len())
+
+			for j := range fieldValue.Len() {
+				items = append(items, redactedStruct(fieldValue.Index(j)))
+			}
+
+			settings[key] = items
+		case fieldValue.Type() == reflect.TypeOf(time.Duration(0)):
+			settings[key] = fieldValue.Int()
+		default:
+			settings[key] = fieldValue.Interface()
+		}
+	}
+
+	return settings
+}
@@ -0,0 +1,10 @@
+package config
+
+// AppConfigSubscriber is called with every snapshot the reloader applies, for
+// the settings which are pushed rather than read through AppConfig.Current
+type AppConfigSubscriber func(appConfig *AppConfig)
+
+type AppConfigReloader interface {
+	Reload() error
+	Subscribe(subscriber AppConfigSubscriber)
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+type appConfigReloaderImpl struct {
+	ConfigPaths AppConfigPaths
+	AppConfig   *AppConfig
+	Logger      *logrus.Logger
+	mutex       sync.Mutex
+	subscribers []AppConfigSubscriber
+}
+
+func NewAppConfigReloaderImpl(configPaths AppConfigPaths, appConfig *AppConfig, logger *logrus.Logger) AppConfigReloader {
+	return &appConfigReloaderImpl{
+		ConfigPaths: configPaths,
+		AppConfig:   appConfig,
+		Logger:      logger,
+	}
+}
+
+// Reload loads and validates the config again, an invalid config is refused
+// as a whole and the current snapshot stays. Only the reloadable keys are
+// applied, the changes of the other keys are logged as a warning and wait for
+// a restart, e.g. server.port or database.maxconns.
+func (r *appConfigReloaderImpl) Reload() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	loadedAppConfig, err := LoadAppConfig(r.ConfigPaths)
+
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateAppConfig(loadedAppConfig); err != nil {
+		return err
+	}
+
+	currentAppConfig := r.AppConfig.Current()
+
+	snapshot := reloadedAppConfig(currentAppConfig, loadedAppConfig)
+
+	if restartKeys := changedAppConfigKeys(snapshot, loadedAppConfig); len(restartKeys) > 0 {
+		r.Logger.WithField("keys", restartKeys).Warn("the config changes of these keys need a restart, they are not applied")
+	}
+
+	r.AppConfig.reloaded.Store(snapshot)
+
+	for _, subscriber := range r.subscribers {
+		subscriber(snapshot)
+	}
+
+	r.Logger.WithField("keys", changedAppConfigKeys(currentAppConfig, snapshot)).Info("the config has been reloaded")
+
+	return nil
+}
+
+func (r *appConfigReloaderImpl) Subscribe(subscriber AppConfigSubscriber) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.subscribers = append(r.subscribers, subscriber)
+}
+
+// reloadedAppConfig copies the current snapshot with the reloadable settings
+// of the loaded config, which are server.apikey, log.level, log.formatter,
+// ratelimit.groups and the cors section. The sections are copied, so a
+// snapshot never changes once it is applied.
+func reloadedAppConfig(currentAppConfig *AppConfig, loadedAppConfig *AppConfig) *AppConfig {
+	snapshot := *currentAppConfig
+
+	server := *currentAppConfig.Server
+	server.ApiKey = loadedAppConfig.Server.ApiKey
+	snapshot.Server = &server
+
+	log := *currentAppConfig.Log
+	log.Level = loadedAppConfig.Log.Level
+	log.Formatter = loadedAppConfig.Log.Formatter
+	snapshot.Log = &log
+
+	rateLimit := *currentAppConfig.RateLimit
+	rateLimit.Groups = loadedAppConfig.RateLimit.Groups
+	snapshot.RateLimit = &rateLimit
+
+	snapshot.Cors = loadedAppConfig.Cors
+
+	return &snapshot
+}
+
+// changedAppConfigKeys lists the keys whose values differ, the lists of
+// sections like ratelimit.groups are compared as a whole
+func changedAppConfigKeys(appConfig *AppConfig, otherAppConfig *AppConfig) []string {
+	values := map[string]any{}
+	otherValues := map[string]any{}
+
+	appConfigValues(reflect.ValueOf(appConfig).Elem(), "", values)
+	appConfigValues(reflect.ValueOf(otherAppConfig).Elem(), "", otherValues)
+
+	keys := []string{}
+
+	for key, value := range values {
+		if !reflect.DeepEqual(value, otherValues[key]) {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+func appConfigValues(structValue reflect.Value, prefix string, values map[string]any) {
+	for i := range structValue.NumField() {
+		field := structValue.Type().Field(i)
+		fieldValue := structValue.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Pointer {
+			fieldValue = fieldValue.Elem()
+		}
+
+		key := prefix + strings.ToLower(field.Name)
+
+		if fieldValue.Kind() == reflect.Struct {
+			appConfigValues(fieldValue, key+".", values)
+			continue
+		}
+
+		values[key] = fieldValue.Interface()
+	}
+}
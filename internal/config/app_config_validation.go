@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateAppConfig checks the validate tags of the config and reports every
+// invalid key at once by its config.yaml name, e.g. "server.port is required"
+func ValidateAppConfig(appConfig *AppConfig) error {
+	err := NewValidator().Struct(appConfig)
+
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	messages := make([]string, 0, len(validationErrors))
+
+	for _, fieldError := range validationErrors {
+		messages = append(messages, "  "+appConfigFieldErrorMessage(fieldError))
+	}
+
+	return fmt.Errorf("the config is invalid:\n%s", strings.Join(messages, "\n"))
+}
+
+func appConfigFieldErrorMessage(fieldError validator.FieldError) string {
+	// The namespace starts with the AppConfig type, the rest is the key
+	_, key, _ := strings.Cut(fieldError.Namespace(), ".")
+	key = strings.ToLower(key)
+
+	switch fieldError.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", key)
+	case "required_without":
+		return fmt.Sprintf("%s is required without %s", key, siblingAppConfigKey(key, fieldError.Param()))
+	case "required_if":
+		// The param is the field and the value it is compared with
+		field, value, _ := strings.Cut(fieldError.Param(), " ")
+
+		return fmt.Sprintf("%s is required when %s is %s", key, siblingAppConfigKey(key, field), value)
+	case "required_with":
+		return fmt.Sprintf("%s is required with %s", key, siblingAppConfigKey(key, fieldError.Param()))
+	case "min":
+		return fmt.Sprintf("%s must be at least %s", key, fieldError.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s", key, fieldError.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s, got %q", key, strings.Join(strings.Fields(fieldError.Param()), ", "), fieldError.Value())
+	case "gtefield":
+		return fmt.Sprintf("%s must be at least %s", key, siblingAppConfigKey(key, fieldError.Param()))
+	case "ip|cidr":
+		return fmt.Sprintf("%s must be an IP or a CIDR, got %q", key, fieldError.Value())
+	default:
+		return fmt.Sprintf("%s fails the %s rule", key, fieldError.Tag())
+	}
+}
+
+// siblingAppConfigKey names the field of the same section a rule refers to
+func siblingAppConfigKey(key string, fieldName string) string {
+	return key[:strings.LastIndex(key, ".")+1] + strings.ToLower(fieldName)
+}
@@ -7,11 +7,19 @@ import (
 func NewLogrus(appConfig *AppConfig) *logrus.Logger {
 	logger := logrus.New()
 
+	SetLogrusConfig(logger, appConfig)
+
+	return logger
+}
+
+// SetLogrusConfig applies the level and the formatter of the config, it is
+// subscribed to the reloads so the logger follows them
+func SetLogrusConfig(logger *logrus.Logger, appConfig *AppConfig) {
 	logger.SetLevel(logrus.Level(appConfig.Log.Level))
 
 	if appConfig.Log.Formatter == "json" {
 		logger.SetFormatter(new(logrus.JSONFormatter))
+	} else {
+		logger.SetFormatter(new(logrus.TextFormatter))
 	}
-
-	return logger
 }
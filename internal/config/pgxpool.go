@@ -2,30 +2,234 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
 )
 
+const (
+	// defaultDatabaseConnectTimeout bounds a connect attempt when
+	// database.connecttimeout is not set
+	defaultDatabaseConnectTimeout = 10 * time.Second
+
+	// defaultDatabaseConnectBackoffBase and defaultDatabaseConnectBackoffMax
+	// space the retries when database.connectbackoffbase and
+	// database.connectbackoffmax are not set, so they never retry at once
+	defaultDatabaseConnectBackoffBase = time.Second
+	defaultDatabaseConnectBackoffMax  = 10 * time.Second
+)
+
 func NewPgxPool(appConfig *AppConfig) db.PgxPool {
-	connString := fmt.Sprintf("postgres://%s:%s@%s:%d/%s", appConfig.Database.Username, appConfig.Database.Password, appConfig.Database.Host, appConfig.Database.Port, appConfig.Database.DBName)
+	pgxPoolCfg, err := NewPgxPoolConfig(appConfig)
+	helper.LogStdPanicIfError(err)
 
-	pgxPoolCfg, err := pgxpool.ParseConfig(connString)
+	pgxPool, err := pgxpool.NewWithConfig(context.Background(), pgxPoolCfg)
+	helper.LogStdPanicIfError(err)
+
+	err = pingWithRetries(appConfig, pgxPool.Ping)
 	helper.LogStdPanicIfError(err)
 
+	return db.NewRequestIdPgxPool(pgxPool)
+}
+
+// NewPgxPoolConfig parses the connection of the config with the pool settings
+// and the query tracer, without connecting
+func NewPgxPoolConfig(appConfig *AppConfig) (*pgxpool.Config, error) {
+	connString, err := databaseConnString(appConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pgxPoolCfg, err := pgxpool.ParseConfig(connString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	setDatabaseConnConfig(pgxPoolCfg.ConnConfig, appConfig)
+
 	pgxPoolCfg.MinConns = int32(appConfig.Database.MinConns)
 	pgxPoolCfg.MaxConns = int32(appConfig.Database.MaxConns)
 	pgxPoolCfg.MaxConnLifetime = appConfig.Database.MaxConnLifeTime * time.Minute
+	pgxPoolCfg.MaxConnLifetimeJitter = appConfig.Database.MaxConnLifeTimeJitter * time.Second
 	pgxPoolCfg.MaxConnIdleTime = appConfig.Database.MaxConnIdleTime * time.Minute
+	pgxPoolCfg.ConnConfig.Tracer = db.NewOtelQueryTracer()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	// pgxpool checks the idle connections every minute when it is not set
+	if appConfig.Database.HealthCheckPeriod > 0 {
+		pgxPoolCfg.HealthCheckPeriod = appConfig.Database.HealthCheckPeriod * time.Second
+	}
 
-	pgxPool, err := pgxpool.NewWithConfig(ctx, pgxPoolCfg)
+	return pgxPoolCfg, nil
+}
+
+func NewPgxConnector(appConfig *AppConfig) db.PgxConnector {
+	connString, err := databaseConnString(appConfig)
+	helper.LogStdPanicIfError(err)
+
+	connConfig, err := pgx.ParseConfig(connString)
 	helper.LogStdPanicIfError(err)
 
-	return pgxPool
+	setDatabaseConnConfig(connConfig, appConfig)
+
+	return func(ctx context.Context) (db.PgxListenConn, error) {
+		conn, err := pgx.ConnectConfig(ctx, connConfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return conn, nil
+	}
+}
+
+// databaseConnString answers the URL of the fields, whose parts are escaped so
+// a password may carry any character, or database.dsn when it is set. The TLS
+// fields are passed as the libpq parameters over either of them, so pgx builds
+// the TLS config and loads the client certificate itself.
+func databaseConnString(appConfig *AppConfig) (string, error) {
+	database := appConfig.Database
+	sslParams := databaseSslParams(database)
+
+	if database.Dsn != "" {
+		return withDsnParams(database.Dsn, sslParams)
+	}
+
+	connUrl := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(database.Username, database.Password),
+		Host:     net.JoinHostPort(database.Host, strconv.Itoa(database.Port)),
+		Path:     "/" + database.DBName,
+		RawQuery: sslParams.Encode(),
+	}
+
+	return connUrl.String(), nil
+}
+
+func databaseSslParams(database *Database) url.Values {
+	params := url.Values{}
+
+	for name, value := range map[string]string{
+		"sslmode":     database.SslMode,
+		"sslrootcert": database.SslRootCert,
+		"sslcert":     database.SslCert,
+		"sslkey":      database.SslKey,
+		"sslpassword": database.SslPassword,
+	} {
+		if value != "" {
+			params.Set(name, value)
+		}
+	}
+
+	return params
+}
+
+// withDsnParams sets the params over the ones of a postgres:// URL, or appends
+// them to a key=value DSN, where the last value of a key wins
+func withDsnParams(dsn string, params url.Values) (string, error) {
+	if len(params) == 0 {
+		return dsn, nil
+	}
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		dsnUrl, err := url.Parse(dsn)
+
+		if err != nil {
+			return "", errors.New("database.dsn is not a valid URL")
+		}
+
+		query := dsnUrl.Query()
+
+		for name := range params {
+			query.Set(name, params.Get(name))
+		}
+
+		dsnUrl.RawQuery = query.Encode()
+
+		return dsnUrl.String(), nil
+	}
+
+	quoter := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		dsn += fmt.Sprintf(" %s='%s'", name, quoter.Replace(params.Get(name)))
+	}
+
+	return dsn, nil
+}
+
+// setDatabaseConnConfig applies the session settings over the DSN as well,
+// they are sent with the startup message of every connection
+func setDatabaseConnConfig(connConfig *pgx.ConnConfig, appConfig *AppConfig) {
+	database := appConfig.Database
+
+	if database.SearchPath != "" {
+		connConfig.RuntimeParams["search_path"] = database.SearchPath
+	}
+
+	if database.ApplicationName != "" {
+		connConfig.RuntimeParams["application_name"] = database.ApplicationName
+	}
+
+	if database.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt((database.StatementTimeout * time.Second).Milliseconds(), 10)
+	}
+
+	if database.ConnectTimeout > 0 {
+		connConfig.ConnectTimeout = database.ConnectTimeout * time.Second
+	}
+}
+
+// pingWithRetries pings until the database answers, so the server waits for a
+// database which starts along with it. The wait doubles after every failed
+// attempt from database.connectbackoffbase up to database.connectbackoffmax,
+// 1 and 10 seconds when they are not set.
+func pingWithRetries(appConfig *AppConfig, ping func(ctx context.Context) error) error {
+	connectTimeout := defaultDatabaseConnectTimeout
+
+	if appConfig.Database.ConnectTimeout > 0 {
+		connectTimeout = appConfig.Database.ConnectTimeout * time.Second
+	}
+
+	wait := defaultDatabaseConnectBackoffBase
+
+	if appConfig.Database.ConnectBackoffBase > 0 {
+		wait = appConfig.Database.ConnectBackoffBase * time.Second
+	}
+
+	// The default longest wait is never below the configured first one
+	maxWait := max(defaultDatabaseConnectBackoffMax, wait)
+
+	if appConfig.Database.ConnectBackoffMax > 0 {
+		maxWait = appConfig.Database.ConnectBackoffMax * time.Second
+	}
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+		err := ping(ctx)
+		cancel()
+
+		if err == nil || attempt >= appConfig.Database.ConnectRetries {
+			return err
+		}
+
+		log.Printf("failed to connect to the database, retrying in %s: %v", wait, err)
+
+		time.Sleep(wait)
+
+		wait = min(2*wait, maxWait)
+	}
 }
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"github.com/redis/go-redis/v9"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/ratelimit"
+)
+
+func NewRateLimiter(appConfig *AppConfig) ratelimit.Limiter {
+	if appConfig.RateLimit.Backend == "redis" {
+		return ratelimit.NewRedisLimiterImpl(redis.NewClient(&redis.Options{
+			Addr:     appConfig.RateLimit.Redis.Addr,
+			Password: appConfig.RateLimit.Redis.Password,
+			DB:       appConfig.RateLimit.Redis.DB,
+		}))
+	}
+
+	return ratelimit.NewMemoryLimiterImpl()
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logrus_test "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+)
+
+func replaceConfigYaml(t *testing.T, configPaths config.AppConfigPaths, replacer *strings.Replacer) {
+	err := os.WriteFile(filepath.Join(configPaths[0], "config.yaml"), []byte(replacer.Replace(validConfigYaml)), 0644)
+	assert.NoError(t, err)
+}
+
+func setupAppConfigReloader(t *testing.T) (config.AppConfigPaths, *config.AppConfig, config.AppConfigReloader, *logrus_test.Hook) {
+	configPaths := writeConfigYaml(t, validConfigYaml)
+
+	appConfig, err := config.LoadAppConfig(configPaths)
+	assert.NoError(t, err)
+
+	hookLogger, hook := logrus_test.NewNullLogger()
+
+	return configPaths, appConfig, config.NewAppConfigReloaderImpl(configPaths, appConfig, hookLogger), hook
+}
+
+func TestAppConfigReload(t *testing.T) {
+	// Arrange
+	configPaths, appConfig, reloader, hook := setupAppConfigReloader(t)
+
+	var subscribedAppConfig *config.AppConfig
+
+	reloader.Subscribe(func(snapshot *config.AppConfig) {
+		subscribedAppConfig = snapshot
+	})
+
+	replaceConfigYaml(t, configPaths, strings.NewReplacer(
+		"apikey: secret", "apikey: rotated",
+		"level: 6", "level: 4",
+		"formatter: text", "formatter: json",
+		"requests: 100", "requests: 10",
+		"port: 3000", "port: 8080",
+		"maxconns: 60", "maxconns: 80",
+	))
+
+	// Action
+	// ---SUT (Subject Under Test)
+	err := reloader.Reload()
+	// ---------------------------
+
+	// Assert
+	assert.NoError(t, err)
+
+	snapshot := appConfig.Current()
+
+	assert.Same(t, snapshot, subscribedAppConfig)
+	assert.Same(t, snapshot, snapshot.Current())
+
+	assert.Equal(t, "rotated", snapshot.Server.ApiKey)
+	assert.Equal(t, 4, snapshot.Log.Level)
+	assert.Equal(t, "json", snapshot.Log.Formatter)
+	assert.Equal(t, 10, snapshot.RateLimit.Groups[0].Requests)
+
+	// The keys which need a restart keep their values
+	assert.Equal(t, 3000, snapshot.Server.Port)
+	assert.Equal(t, 60, snapshot.Database.MaxConns)
+
+	// The loaded config itself never changes
+	assert.Equal(t, "secret", appConfig.Server.ApiKey)
+	assert.Equal(t, 100, appConfig.RateLimit.Groups[0].Requests)
+
+	warnings := []*logrus.Entry{}
+
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel {
+			warnings = append(warnings, entry)
+		}
+	}
+
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, []string{"database.maxconns", "server.port"}, warnings[0].Data["keys"])
+	}
+}
+
+func TestAppConfigReloadFailed(t *testing.T) {
+	// Arrange
+	configPaths, appConfig, reloader, _ := setupAppConfigReloader(t)
+
+	subscribed := false
+
+	reloader.Subscribe(func(snapshot *config.AppConfig) {
+		subscribed = true
+	})
+
+	replaceConfigYaml(t, configPaths, strings.NewReplacer(
+		"apikey: secret", "apikey: rotated",
+		"formatter: text", "formatter: xml",
+	))
+
+	// Action
+	// ---SUT (Subject Under Test)
+	err := reloader.Reload()
+	// ---------------------------
+
+	// Assert
+	assert.EqualError(t, err, `the config is invalid:
+  log.formatter must be one of text, json, got "xml"`)
+
+	assert.Same(t, appConfig, appConfig.Current())
+	assert.Equal(t, "secret", appConfig.Current().Server.ApiKey)
+	assert.False(t, subscribed)
+}
+
+func TestAppConfigCurrentWithoutReloader(t *testing.T) {
+	// Arrange
+	appConfig := &config.AppConfig{Server: &config.Server{Port: 3000}}
+
+	// Action & Assert
+	assert.Same(t, appConfig, appConfig.Current())
+}
@@ -0,0 +1,340 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+)
+
+const validConfigYaml = `server:
+  port: 3000
+  apikey: secret
+  authmodes: [apikey]
+  trustedproxies: [10.0.0.0/8]
+database:
+  username: postgres
+  password: postgres
+  host: localhost
+  port: 5432
+  dbname: pzn_golang_restful_api
+  minconns: 15
+  maxconns: 60
+log:
+  level: 6
+  formatter: text
+  output: console
+  filepath: ./app.log
+  access:
+    samplerate: 1
+ratelimit:
+  redis:
+    password: redis-secret
+  groups:
+    - name: categories
+      prefix: /api/v2/categories
+      requests: 100
+      period: 60
+test:
+  timeout: 30
+`
+
+func writeConfigYaml(t *testing.T, content string) config.AppConfigPaths {
+	configPath := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(configPath, "config.yaml"), []byte(content), 0644)
+	assert.NoError(t, err)
+
+	return config.AppConfigPaths{configPath}
+}
+
+func TestLoadAppConfig(t *testing.T) {
+	// Arrange
+	configPaths := writeConfigYaml(t, validConfigYaml)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	appConfig, err := config.LoadAppConfig(configPaths)
+	// ---------------------------
+
+	// Assert
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3000, appConfig.Server.Port)
+		assert.Equal(t, "localhost", appConfig.Database.Host)
+		assert.Len(t, appConfig.RateLimit.Groups, 1)
+		assert.Equal(t, 5, appConfig.Database.ConnectRetries)
+		assert.Equal(t, time.Duration(168), appConfig.Webhook.Retention)
+		assert.Equal(t, &config.Stream{LogSize: 1000, BufferSize: 64, Heartbeat: 15, ReconnectMax: 30}, appConfig.Stream)
+		assert.Equal(t, time.Duration(20), appConfig.Websocket.PingInterval)
+		assert.Equal(t, &config.Graphql{MaxDepth: 8, MaxComplexity: 500}, appConfig.Graphql)
+		assert.NoError(t, config.ValidateAppConfig(appConfig))
+	}
+}
+
+func TestLoadAppConfigWithEnvironment(t *testing.T) {
+	t.Run("Environment Variables", func(t *testing.T) {
+		// Arrange
+		configPaths := writeConfigYaml(t, validConfigYaml)
+
+		t.Setenv("APP_SERVER_PORT", "8080")
+		t.Setenv("APP_SERVER_AUTHMODES", "apikey,jwt")
+		t.Setenv("APP_SERVER_JWT_ISSUER", "https://sso.example.com")
+		t.Setenv("APP_COMPRESSION_ENABLED", "false")
+
+		// Action
+		// ---SUT (Subject Under Test)
+		appConfig, err := config.LoadAppConfig(configPaths)
+		// ---------------------------
+
+		// Assert
+		if assert.NoError(t, err) {
+			assert.Equal(t, 8080, appConfig.Server.Port)
+			assert.Equal(t, []string{"apikey", "jwt"}, appConfig.Server.AuthModes)
+			assert.Equal(t, "https://sso.example.com", appConfig.Server.Jwt.Issuer)
+			assert.False(t, appConfig.Compression.Enabled)
+		}
+	})
+
+	t.Run("Secret Files", func(t *testing.T) {
+		// Arrange
+		configPaths := writeConfigYaml(t, validConfigYaml)
+
+		secretFilePath := filepath.Join(t.TempDir(), "database_password")
+
+		err := os.WriteFile(secretFilePath, []byte("file-secret\n"), 0600)
+		assert.NoError(t, err)
+
+		t.Setenv("APP_DATABASE_PASSWORD_FILE", secretFilePath)
+
+		// Action
+		// ---SUT (Subject Under Test)
+		appConfig, err := config.LoadAppConfig(configPaths)
+		// ---------------------------
+
+		// Assert
+		if assert.NoError(t, err) {
+			assert.Equal(t, "file-secret", appConfig.Database.Password)
+		}
+	})
+
+	t.Run("Without Config File", func(t *testing.T) {
+		// Arrange
+		t.Setenv("APP_SERVER_PORT", "8080")
+
+		// Action
+		// ---SUT (Subject Under Test)
+		appConfig, err := config.LoadAppConfig(config.AppConfigPaths{t.TempDir()})
+		// ---------------------------
+
+		// Assert
+		if assert.NoError(t, err) {
+			assert.Equal(t, 8080, appConfig.Server.Port)
+			assert.NotNil(t, appConfig.Webhook)
+		}
+	})
+}
+
+func TestLoadAppConfigFailed(t *testing.T) {
+	t.Run("Both Variable And Secret File", func(t *testing.T) {
+		// Arrange
+		configPaths := writeConfigYaml(t, validConfigYaml)
+
+		t.Setenv("APP_DATABASE_PASSWORD", "env-secret")
+		t.Setenv("APP_DATABASE_PASSWORD_FILE", filepath.Join(t.TempDir(), "database_password"))
+
+		// Action
+		// ---SUT (Subject Under Test)
+		_, err := config.LoadAppConfig(configPaths)
+		// ---------------------------
+
+		// Assert
+		assert.EqualError(t, err, "both APP_DATABASE_PASSWORD and APP_DATABASE_PASSWORD_FILE are set")
+	})
+
+	t.Run("Missing Secret File", func(t *testing.T) {
+		// Arrange
+		configPaths := writeConfigYaml(t, validConfigYaml)
+
+		t.Setenv("APP_DATABASE_PASSWORD_FILE", filepath.Join(t.TempDir(), "database_password"))
+
+		// Action
+		// ---SUT (Subject Under Test)
+		_, err := config.LoadAppConfig(configPaths)
+		// ---------------------------
+
+		// Assert
+		assert.ErrorContains(t, err, "APP_DATABASE_PASSWORD_FILE: open")
+	})
+}
+
+func TestValidateAppConfig(t *testing.T) {
+	// Arrange
+	configPaths := writeConfigYaml(t, strings.Replace(validConfigYaml, "  apikey: secret\n", "", 1))
+
+	t.Setenv("APP_SERVER_PORT", "0")
+	t.Setenv("APP_SERVER_AUTHMODES", "apikey,basic")
+	t.Setenv("APP_SERVER_TRUSTEDPROXIES", "proxy")
+	t.Setenv("APP_DATABASE_MAXCONNS", "10")
+	t.Setenv("APP_LOG_FORMATTER", "xml")
+	t.Setenv("APP_LOG_ACCESS_SAMPLERATE", "2")
+
+	appConfig, err := config.LoadAppConfig(configPaths)
+	assert.NoError(t, err)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	err = config.ValidateAppConfig(appConfig)
+	// ---------------------------
+
+	// Assert
+	assert.EqualError(t, err, `the config is invalid:
+  server.port is required
+  server.apikey is required
+  server.authmodes[1] must be one of apikey, jwt, got "basic"
+  server.trustedproxies[0] must be an IP or a CIDR, got "proxy"
+  database.maxconns must be at least database.minconns
+  log.formatter must be one of text, json, got "xml"
+  log.access.samplerate must be at most 1`)
+}
+
+func TestRedactedAppConfig(t *testing.T) {
+	// Arrange
+	configPaths := writeConfigYaml(t, strings.Replace(validConfigYaml, "  apikey: secret\n", "", 1))
+
+	appConfig, err := config.LoadAppConfig(configPaths)
+	assert.NoError(t, err)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	settings := config.RedactedAppConfig(appConfig)
+	// ---------------------------
+
+	// Assert
+	server := settings["server"].(map[string]any)
+	database := settings["database"].(map[string]any)
+	rateLimit := settings["ratelimit"].(map[string]any)
+
+	assert.Equal(t, "", server["apikey"])
+	assert.Equal(t, "[REDACTED]", database["password"])
+	assert.Equal(t, "[REDACTED]", rateLimit["redis"].(map[string]any)["password"])
+	assert.Equal(t, "postgres", database["username"])
+	assert.Equal(t, int64(30), settings["test"].(map[string]any)["timeout"])
+	assert.Equal(t, "categories", rateLimit["groups"].([]map[string]any)[0]["name"])
+}
+
+func TestValidateWebhookConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook *config.Webhook
+		message string
+	}{
+		{name: "Disabled Without Settings", webhook: &config.Webhook{}},
+		{name: "Enabled With Settings", webhook: &config.Webhook{Enabled: true, PollInterval: 2, BatchSize: 50, Timeout: 10, MaxAttempts: 8, BackoffBase: 10, BackoffMax: 3600}},
+		{name: "Enabled Without Settings", webhook: &config.Webhook{Enabled: true}, message: `the config is invalid:
+  webhook.pollinterval is required when webhook.enabled is true
+  webhook.batchsize is required when webhook.enabled is true
+  webhook.timeout is required when webhook.enabled is true
+  webhook.maxattempts is required when webhook.enabled is true`},
+		{name: "Negative Poll Interval", webhook: &config.Webhook{Enabled: true, PollInterval: -1, BatchSize: 50, Timeout: 10, MaxAttempts: 8}, message: `the config is invalid:
+  webhook.pollinterval must be at least 0`},
+		{name: "Backoff Max Below Base", webhook: &config.Webhook{BackoffBase: 10, BackoffMax: 5}, message: `the config is invalid:
+  webhook.backoffmax must be at least webhook.backoffbase`},
+		{name: "Negative Retention", webhook: &config.Webhook{Retention: -1}, message: `the config is invalid:
+  webhook.retention must be at least 0`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			appConfig, err := config.LoadAppConfig(writeConfigYaml(t, validConfigYaml))
+			assert.NoError(t, err)
+
+			appConfig.Webhook = test.webhook
+
+			// Action
+			// ---SUT (Subject Under Test)
+			err = config.ValidateAppConfig(appConfig)
+			// ---------------------------
+
+			// Assert
+			if test.message == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.message)
+			}
+		})
+	}
+}
+
+func TestValidateAppConfigSections(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(appConfig *config.AppConfig)
+		message string
+	}{
+		{name: "Defaults", change: func(appConfig *config.AppConfig) {}},
+		{name: "Console Log Without File Path", change: func(appConfig *config.AppConfig) { appConfig.Log.FilePath = "" }},
+		{name: "File Log Without File Path", change: func(appConfig *config.AppConfig) {
+			appConfig.Log.Output = "file"
+			appConfig.Log.FilePath = ""
+		}, message: `the config is invalid:
+  log.filepath is required when log.output is file`},
+		{name: "Unknown Tracing Exporter", change: func(appConfig *config.AppConfig) {
+			appConfig.Tracing = &config.Tracing{Exporter: "jaeger", SampleRatio: 2}
+		}, message: `the config is invalid:
+  tracing.exporter must be one of otlp, stdout, none, got "jaeger"
+  tracing.sampleratio must be at most 1`},
+		{name: "Unknown Rate Limit Backend", change: func(appConfig *config.AppConfig) { appConfig.RateLimit.Backend = "memcached" }, message: `the config is invalid:
+  ratelimit.backend must be one of memory, redis, got "memcached"`},
+		{name: "Category Cache Without Size", change: func(appConfig *config.AppConfig) { appConfig.CategoryCache.Enabled = true }, message: `the config is invalid:
+  categorycache.size is required when categorycache.enabled is true`},
+		{name: "Webhook Without Backoff Max", change: func(appConfig *config.AppConfig) { appConfig.Webhook.BackoffBase = 10 }},
+		{name: "Zero Stream", change: func(appConfig *config.AppConfig) { appConfig.Stream = &config.Stream{} }, message: `the config is invalid:
+  stream.buffersize must be at least 1
+  stream.heartbeat must be at least 1
+  stream.reconnectmax must be at least 1`},
+		{name: "Zero Websocket", change: func(appConfig *config.AppConfig) { appConfig.Websocket = &config.Websocket{} }, message: `the config is invalid:
+  websocket.authtimeout must be at least 1
+  websocket.pinginterval must be at least 1
+  websocket.pongtimeout must be at least 1
+  websocket.writetimeout must be at least 1
+  websocket.sendbuffer must be at least 1
+  websocket.maxsubscriptions must be at least 1
+  websocket.maxmessagesize must be at least 1`},
+		{name: "Zero Rate Limit Group", change: func(appConfig *config.AppConfig) {
+			appConfig.RateLimit.Groups = append(appConfig.RateLimit.Groups, config.RateLimitGroup{})
+		}, message: `the config is invalid:
+  ratelimit.groups[1].name is required
+  ratelimit.groups[1].requests must be at least 1
+  ratelimit.groups[1].period must be at least 1`},
+		{name: "Zero Graphql", change: func(appConfig *config.AppConfig) { appConfig.Graphql = &config.Graphql{} }, message: `the config is invalid:
+  graphql.maxdepth must be at least 1
+  graphql.maxcomplexity must be at least 1`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			appConfig, err := config.LoadAppConfig(writeConfigYaml(t, validConfigYaml))
+			assert.NoError(t, err)
+
+			test.change(appConfig)
+
+			// Action
+			// ---SUT (Subject Under Test)
+			err = config.ValidateAppConfig(appConfig)
+			// ---------------------------
+
+			// Assert
+			if test.message == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.message)
+			}
+		})
+	}
+}
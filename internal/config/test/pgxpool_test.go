@@ -0,0 +1,238 @@
+package config
+
+import (
+	"bytes"
+	"log"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+)
+
+func setupDatabaseTestConfig() *config.AppConfig {
+	return &config.AppConfig{
+		Database: &config.Database{
+			Username: "app user",
+			Password: "p@ss:w/rd#1?",
+			Host:     "db.example.com",
+			Port:     5433,
+			DBName:   "pzn_golang_restful_api",
+			MinConns: 1,
+			MaxConns: 4,
+		},
+	}
+}
+
+func TestNewPgxPoolConfig(t *testing.T) {
+	t.Run("Escaped Fields", func(t *testing.T) {
+		// Arrange
+		appConfig := setupDatabaseTestConfig()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		pgxPoolCfg, err := config.NewPgxPoolConfig(appConfig)
+		// ---------------------------
+
+		// Assert
+		if assert.NoError(t, err) {
+			assert.Equal(t, "app user", pgxPoolCfg.ConnConfig.User)
+			assert.Equal(t, "p@ss:w/rd#1?", pgxPoolCfg.ConnConfig.Password)
+			assert.Equal(t, "db.example.com", pgxPoolCfg.ConnConfig.Host)
+			assert.Equal(t, uint16(5433), pgxPoolCfg.ConnConfig.Port)
+			assert.Equal(t, "pzn_golang_restful_api", pgxPoolCfg.ConnConfig.Database)
+			assert.Equal(t, int32(4), pgxPoolCfg.MaxConns)
+			assert.Equal(t, time.Minute, pgxPoolCfg.HealthCheckPeriod)
+		}
+	})
+
+	t.Run("Session And Pool Settings", func(t *testing.T) {
+		// Arrange
+		appConfig := setupDatabaseTestConfig()
+		appConfig.Database.SslMode = "disable"
+		appConfig.Database.SearchPath = "app,public"
+		appConfig.Database.ApplicationName = "pzn-golang-restful-api"
+		appConfig.Database.StatementTimeout = 30
+		appConfig.Database.ConnectTimeout = 5
+		appConfig.Database.MaxConnLifeTimeJitter = 60
+		appConfig.Database.HealthCheckPeriod = 15
+
+		// Action
+		// ---SUT (Subject Under Test)
+		pgxPoolCfg, err := config.NewPgxPoolConfig(appConfig)
+		// ---------------------------
+
+		// Assert
+		if assert.NoError(t, err) {
+			assert.Nil(t, pgxPoolCfg.ConnConfig.TLSConfig)
+			assert.Equal(t, "app,public", pgxPoolCfg.ConnConfig.RuntimeParams["search_path"])
+			assert.Equal(t, "pzn-golang-restful-api", pgxPoolCfg.ConnConfig.RuntimeParams["application_name"])
+			assert.Equal(t, "30000", pgxPoolCfg.ConnConfig.RuntimeParams["statement_timeout"])
+			assert.Equal(t, 5*time.Second, pgxPoolCfg.ConnConfig.ConnectTimeout)
+			assert.Equal(t, time.Minute, pgxPoolCfg.MaxConnLifetimeJitter)
+			assert.Equal(t, 15*time.Second, pgxPoolCfg.HealthCheckPeriod)
+		}
+	})
+
+	t.Run("Required TLS", func(t *testing.T) {
+		// Arrange
+		appConfig := setupDatabaseTestConfig()
+		appConfig.Database.SslMode = "require"
+
+		// Action
+		// ---SUT (Subject Under Test)
+		pgxPoolCfg, err := config.NewPgxPoolConfig(appConfig)
+		// ---------------------------
+
+		// Assert
+		if assert.NoError(t, err) {
+			assert.NotNil(t, pgxPoolCfg.ConnConfig.TLSConfig)
+			assert.Empty(t, pgxPoolCfg.ConnConfig.Fallbacks)
+		}
+	})
+
+	t.Run("DSN", func(t *testing.T) {
+		// Arrange
+		appConfig := setupDatabaseTestConfig()
+		appConfig.Database.Dsn = "host=replica.example.com port=6432 user=reader dbname=catalog sslmode=disable"
+		appConfig.Database.ApplicationName = "pzn-golang-restful-api"
+
+		// Action
+		// ---SUT (Subject Under Test)
+		pgxPoolCfg, err := config.NewPgxPoolConfig(appConfig)
+		// ---------------------------
+
+		// Assert
+		if assert.NoError(t, err) {
+			assert.Equal(t, "replica.example.com", pgxPoolCfg.ConnConfig.Host)
+			assert.Equal(t, uint16(6432), pgxPoolCfg.ConnConfig.Port)
+			assert.Equal(t, "reader", pgxPoolCfg.ConnConfig.User)
+			assert.Equal(t, "catalog", pgxPoolCfg.ConnConfig.Database)
+			assert.Equal(t, "pzn-golang-restful-api", pgxPoolCfg.ConnConfig.RuntimeParams["application_name"])
+		}
+	})
+
+	dsnTests := []struct {
+		name string
+		dsn  string
+	}{
+		{name: "TLS Over Key Value DSN", dsn: "host=replica.example.com user=reader dbname=catalog sslmode=disable"},
+		{name: "TLS Over URL DSN", dsn: "postgres://reader@replica.example.com/catalog?sslmode=disable"},
+	}
+
+	for _, test := range dsnTests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			appConfig := setupDatabaseTestConfig()
+			appConfig.Database.Dsn = test.dsn
+			appConfig.Database.SslMode = "require"
+
+			// Action
+			// ---SUT (Subject Under Test)
+			pgxPoolCfg, err := config.NewPgxPoolConfig(appConfig)
+			// ---------------------------
+
+			// Assert
+			if assert.NoError(t, err) {
+				assert.Equal(t, "replica.example.com", pgxPoolCfg.ConnConfig.Host)
+				assert.NotNil(t, pgxPoolCfg.ConnConfig.TLSConfig)
+				assert.Empty(t, pgxPoolCfg.ConnConfig.Fallbacks)
+			}
+		})
+	}
+}
+
+func TestNewPgxPoolConfigFailed(t *testing.T) {
+	// Arrange
+	appConfig := setupDatabaseTestConfig()
+	appConfig.Database.SslMode = "verify-full"
+	appConfig.Database.SslCert = filepath.Join(t.TempDir(), "client.crt")
+	appConfig.Database.SslKey = filepath.Join(t.TempDir(), "client.key")
+
+	// Action
+	// ---SUT (Subject Under Test)
+	_, err := config.NewPgxPoolConfig(appConfig)
+	// ---------------------------
+
+	// Assert
+	assert.ErrorContains(t, err, "client.key")
+}
+
+func TestNewPgxPoolRetries(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	// The port is closed again, so every attempt is refused at once
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	appConfig := setupDatabaseTestConfig()
+	appConfig.Database.Host = "127.0.0.1"
+	appConfig.Database.Port = port
+	appConfig.Database.SslMode = "disable"
+	appConfig.Database.ConnectRetries = 1
+
+	logOutput := new(bytes.Buffer)
+	logWriter := log.Writer()
+
+	log.SetOutput(logOutput)
+	defer log.SetOutput(logWriter)
+
+	// Action & Assert
+	assert.Panics(t, func() {
+		// ---SUT (Subject Under Test)
+		config.NewPgxPool(appConfig)
+		// ---------------------------
+	})
+
+	// Without database.connectbackoffbase the retry still waits a second
+	assert.Equal(t, 1, strings.Count(logOutput.String(), "failed to connect to the database, retrying in 1s"))
+}
+
+func TestValidateDatabaseConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		database *config.Database
+		message  string
+	}{
+		{name: "DSN Without Fields", database: &config.Database{Dsn: "postgres://localhost/app", MaxConns: 1}},
+		{name: "Without DSN And Fields", database: &config.Database{MaxConns: 1}, message: `the config is invalid:
+  database.username is required without database.dsn
+  database.host is required without database.dsn
+  database.port is required without database.dsn
+  database.dbname is required without database.dsn`},
+		{name: "Client Certificate Without Key", database: &config.Database{Dsn: "postgres://localhost/app", SslMode: "verify-full", SslCert: "client.crt", MaxConns: 1}, message: `the config is invalid:
+  database.sslkey is required with database.sslcert`},
+		{name: "Unknown SSL Mode", database: &config.Database{Dsn: "postgres://localhost/app", SslMode: "strict", MaxConns: 1}, message: `the config is invalid:
+  database.sslmode must be one of disable, allow, prefer, require, verify-ca, verify-full, got "strict"`},
+		{name: "Backoff Max Unset", database: &config.Database{Dsn: "postgres://localhost/app", ConnectBackoffBase: 20, MaxConns: 1}},
+		{name: "Backoff Max Below Base", database: &config.Database{Dsn: "postgres://localhost/app", ConnectBackoffBase: 10, ConnectBackoffMax: 5, MaxConns: 1}, message: `the config is invalid:
+  database.connectbackoffmax must be at least database.connectbackoffbase`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			appConfig, err := config.LoadAppConfig(writeConfigYaml(t, validConfigYaml))
+			assert.NoError(t, err)
+
+			appConfig.Database = test.database
+
+			// Action
+			// ---SUT (Subject Under Test)
+			err = config.ValidateAppConfig(appConfig)
+			// ---------------------------
+
+			// Assert
+			if test.message == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.message)
+			}
+		})
+	}
+}
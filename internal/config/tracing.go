@@ -0,0 +1,50 @@
+package config
+
+import (
+	"context"
+	"os"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// NewTracerProvider registers the tracer provider and the W3C propagators
+// globally, the middleware, the usecases and pgx read them from otel
+func NewTracerProvider(appConfig *AppConfig) *sdktrace.TracerProvider {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(appConfig.Tracing.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(appConfig.Tracing.SampleRatio))),
+	}
+
+	switch appConfig.Tracing.Exporter {
+	case "otlp":
+		exporterOptions := []otlptracehttp.Option{}
+
+		if appConfig.Tracing.Endpoint != "" {
+			exporterOptions = append(exporterOptions, otlptracehttp.WithEndpointURL(appConfig.Tracing.Endpoint))
+		}
+
+		exporter, err := otlptracehttp.New(context.Background(), exporterOptions...)
+		helper.LogStdPanicIfError(err)
+
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case "stdout":
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		helper.LogStdPanicIfError(err)
+
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(options...)
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tracerProvider
+}
@@ -0,0 +1,82 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+)
+
+type categoryLoaderKey struct{}
+
+// categoryLoader collects the category ids requested while a GraphQL level is
+// resolved and loads all of them with a single FindByIds call once the first
+// thunk is dethunked, so aliased lookups do not open one transaction each.
+type categoryLoader struct {
+	UseCase usecase.CategoryUseCase
+	Context context.Context
+
+	mutex   sync.Mutex
+	pending []string
+	loaded  map[string]*model.CategoryResponse
+}
+
+func newCategoryLoader(ctx context.Context, useCase usecase.CategoryUseCase) *categoryLoader {
+	return &categoryLoader{
+		UseCase: useCase,
+		Context: ctx,
+		loaded:  map[string]*model.CategoryResponse{},
+	}
+}
+
+func categoryLoaderFromContext(ctx context.Context) *categoryLoader {
+	return ctx.Value(categoryLoaderKey{}).(*categoryLoader)
+}
+
+func (l *categoryLoader) Load(categoryId string) func() *model.CategoryResponse {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, ok := l.loaded[categoryId]; !ok && !l.isPending(categoryId) {
+		l.pending = append(l.pending, categoryId)
+	}
+
+	return func() *model.CategoryResponse {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		if _, ok := l.loaded[categoryId]; !ok {
+			l.dispatch()
+		}
+
+		return l.loaded[categoryId]
+	}
+}
+
+func (l *categoryLoader) isPending(categoryId string) bool {
+	for _, pendingId := range l.pending {
+		if pendingId == categoryId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *categoryLoader) dispatch() {
+	categoryIds := l.pending
+	l.pending = nil
+
+	slices.Sort(categoryIds)
+
+	// Missing ids are remembered as nil so they are not queried again
+	for _, categoryId := range categoryIds {
+		l.loaded[categoryId] = nil
+	}
+
+	for _, category := range l.UseCase.FindByIds(l.Context, categoryIds) {
+		l.loaded[category.Id] = &category
+	}
+}
@@ -0,0 +1,207 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+
+	go_graphql "github.com/graphql-go/graphql"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+const categoryCursorPrefix = "category:"
+
+func (c *graphqlControllerImpl) newSchema() (go_graphql.Schema, error) {
+	categoryType := go_graphql.NewObject(go_graphql.ObjectConfig{
+		Name: "Category",
+		Fields: go_graphql.Fields{
+			"id":   &go_graphql.Field{Type: go_graphql.NewNonNull(go_graphql.ID)},
+			"name": &go_graphql.Field{Type: go_graphql.NewNonNull(go_graphql.String)},
+		},
+	})
+
+	categoryEdgeType := go_graphql.NewObject(go_graphql.ObjectConfig{
+		Name: "CategoryEdge",
+		Fields: go_graphql.Fields{
+			"cursor": &go_graphql.Field{Type: go_graphql.NewNonNull(go_graphql.String)},
+			"node":   &go_graphql.Field{Type: go_graphql.NewNonNull(categoryType)},
+		},
+	})
+
+	pageInfoType := go_graphql.NewObject(go_graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: go_graphql.Fields{
+			"hasNextPage": &go_graphql.Field{Type: go_graphql.NewNonNull(go_graphql.Boolean)},
+			"endCursor":   &go_graphql.Field{Type: go_graphql.String},
+		},
+	})
+
+	categoryConnectionType := go_graphql.NewObject(go_graphql.ObjectConfig{
+		Name: "CategoryConnection",
+		Fields: go_graphql.Fields{
+			"edges":      &go_graphql.Field{Type: go_graphql.NewNonNull(go_graphql.NewList(go_graphql.NewNonNull(categoryEdgeType)))},
+			"pageInfo":   &go_graphql.Field{Type: go_graphql.NewNonNull(pageInfoType)},
+			"totalCount": &go_graphql.Field{Type: go_graphql.NewNonNull(go_graphql.Int)},
+		},
+	})
+
+	categoryInputType := go_graphql.NewInputObject(go_graphql.InputObjectConfig{
+		Name: "CategoryInput",
+		Fields: go_graphql.InputObjectConfigFieldMap{
+			"name": &go_graphql.InputObjectFieldConfig{Type: go_graphql.NewNonNull(go_graphql.String)},
+		},
+	})
+
+	queryType := go_graphql.NewObject(go_graphql.ObjectConfig{
+		Name: "Query",
+		Fields: go_graphql.Fields{
+			"categories": &go_graphql.Field{
+				Type: go_graphql.NewNonNull(categoryConnectionType),
+				Args: go_graphql.FieldConfigArgument{
+					"first": &go_graphql.ArgumentConfig{Type: go_graphql.Int, DefaultValue: defaultPageSize},
+					"after": &go_graphql.ArgumentConfig{Type: go_graphql.String},
+				},
+				Resolve: c.resolve(c.resolveCategories),
+			},
+			"category": &go_graphql.Field{
+				Type: categoryType,
+				Args: go_graphql.FieldConfigArgument{
+					"id": &go_graphql.ArgumentConfig{Type: go_graphql.NewNonNull(go_graphql.ID)},
+				},
+				Resolve: c.resolve(c.resolveCategory),
+			},
+		},
+	})
+
+	mutationType := go_graphql.NewObject(go_graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: go_graphql.Fields{
+			"createCategory": &go_graphql.Field{
+				Type: go_graphql.NewNonNull(categoryType),
+				Args: go_graphql.FieldConfigArgument{
+					"input": &go_graphql.ArgumentConfig{Type: go_graphql.NewNonNull(categoryInputType)},
+				},
+				Resolve: c.resolve(requireScope(security.ScopeCategoriesWrite, c.resolveCreateCategory)),
+			},
+			"updateCategory": &go_graphql.Field{
+				Type: go_graphql.NewNonNull(categoryType),
+				Args: go_graphql.FieldConfigArgument{
+					"id":    &go_graphql.ArgumentConfig{Type: go_graphql.NewNonNull(go_graphql.ID)},
+					"input": &go_graphql.ArgumentConfig{Type: go_graphql.NewNonNull(categoryInputType)},
+				},
+				Resolve: c.resolve(requireScope(security.ScopeCategoriesWrite, c.resolveUpdateCategory)),
+			},
+			"deleteCategory": &go_graphql.Field{
+				Type: go_graphql.NewNonNull(go_graphql.ID),
+				Args: go_graphql.FieldConfigArgument{
+					"id": &go_graphql.ArgumentConfig{Type: go_graphql.NewNonNull(go_graphql.ID)},
+				},
+				Resolve: c.resolve(requireScope(security.ScopeCategoriesWrite, c.resolveDeleteCategory)),
+			},
+		},
+	})
+
+	return go_graphql.NewSchema(go_graphql.SchemaConfig{
+		Query:    queryType,
+		Mutation: mutationType,
+	})
+}
+
+// requireScope lets a field answer only the API keys with the scope, the
+// route lets in every key which may read the categories
+func requireScope(scope string, resolveFn go_graphql.FieldResolveFn) go_graphql.FieldResolveFn {
+	return func(p go_graphql.ResolveParams) (any, error) {
+		security.RequireScope(p.Context, scope)
+
+		return resolveFn(p)
+	}
+}
+
+func (c *graphqlControllerImpl) resolveCategories(p go_graphql.ResolveParams) (any, error) {
+	requestQuery := &model.PageCategoryRequest{
+		Limit: p.Args["first"].(int),
+	}
+
+	if after, ok := p.Args["after"].(string); ok {
+		requestQuery.AfterId = decodeCategoryCursor(after)
+	}
+
+	categoryPage := c.UseCase.FindPage(p.Context, requestQuery)
+
+	edges := []map[string]any{}
+
+	for _, category := range categoryPage.Categories {
+		edges = append(edges, map[string]any{
+			"cursor": encodeCategoryCursor(category.Id),
+			"node":   category,
+		})
+	}
+
+	pageInfo := map[string]any{
+		"hasNextPage": categoryPage.HasNextPage,
+		"endCursor":   nil,
+	}
+
+	if len(edges) > 0 {
+		pageInfo["endCursor"] = edges[len(edges)-1]["cursor"]
+	}
+
+	return map[string]any{
+		"edges":      edges,
+		"pageInfo":   pageInfo,
+		"totalCount": categoryPage.TotalCount,
+	}, nil
+}
+
+func (c *graphqlControllerImpl) resolveCategory(p go_graphql.ResolveParams) (any, error) {
+	thunk := categoryLoaderFromContext(p.Context).Load(p.Args["id"].(string))
+
+	return c.thunk(p.Context, func() (any, error) {
+		if category := thunk(); category != nil {
+			return category, nil
+		}
+
+		return nil, nil
+	}), nil
+}
+
+func (c *graphqlControllerImpl) resolveCreateCategory(p go_graphql.ResolveParams) (any, error) {
+	input := p.Args["input"].(map[string]any)
+
+	return c.UseCase.Create(p.Context, &model.CreateCategoryRequest{
+		Name: input["name"].(string),
+	}), nil
+}
+
+func (c *graphqlControllerImpl) resolveUpdateCategory(p go_graphql.ResolveParams) (any, error) {
+	input := p.Args["input"].(map[string]any)
+
+	return c.UseCase.Update(p.Context, p.Args["id"].(string), &model.UpdateCategoryRequest{
+		Name: input["name"].(string),
+	}), nil
+}
+
+func (c *graphqlControllerImpl) resolveDeleteCategory(p go_graphql.ResolveParams) (any, error) {
+	categoryId := p.Args["id"].(string)
+
+	c.UseCase.Delete(p.Context, categoryId)
+
+	return categoryId, nil
+}
+
+func encodeCategoryCursor(categoryId string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(categoryCursorPrefix + categoryId))
+}
+
+func decodeCategoryCursor(cursor string) string {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+
+	if err != nil || !strings.HasPrefix(string(decoded), categoryCursorPrefix) {
+		panic(exception.NewErrorClientRequest(errors.New("invalid cursor"), http.StatusBadRequest, "invalid cursor"))
+	}
+
+	return strings.TrimPrefix(string(decoded), categoryCursorPrefix)
+}
@@ -0,0 +1,11 @@
+package graphql
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type GraphqlController interface {
+	Serve(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+}
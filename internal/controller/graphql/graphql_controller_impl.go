@@ -0,0 +1,190 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	go_graphql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/julienschmidt/httprouter"
+	"github.com/sirupsen/logrus"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+)
+
+type graphqlControllerImpl struct {
+	AppConfig *config.AppConfig
+	Logger    *logrus.Logger
+	UseCase   usecase.CategoryUseCase
+	Schema    go_graphql.Schema
+}
+
+func NewGraphqlControllerImpl(appConfig *config.AppConfig, logger *logrus.Logger, useCase usecase.CategoryUseCase) GraphqlController {
+	controller := &graphqlControllerImpl{
+		AppConfig: appConfig,
+		Logger:    logger,
+		UseCase:   useCase,
+	}
+
+	schema, err := controller.newSchema()
+	helper.LogStdPanicIfError(err)
+
+	controller.Schema = schema
+
+	return controller
+}
+
+func (c *graphqlControllerImpl) Serve(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	graphqlRequest := new(model.GraphqlRequest)
+
+	if r.Method == http.MethodGet {
+		graphqlRequest.Query = r.URL.Query().Get("query")
+		graphqlRequest.OperationName = r.URL.Query().Get("operationName")
+
+		// The variables of a GET request are a JSON object in the query string
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &graphqlRequest.Variables); err != nil {
+				c.writeError(w, http.StatusBadRequest, "variables must be a JSON object")
+				return
+			}
+		}
+	} else {
+		err := helper.ReadFromRequestBody(w, r, graphqlRequest, helper.RequestBodyOptions{
+			MaxBodySize:           c.AppConfig.Request.MaxBodySize,
+			DisallowUnknownFields: c.AppConfig.Request.DisallowUnknownFields,
+		})
+		helper.PanicIfError(err)
+	}
+
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(graphqlRequest.Query),
+			Name: "GraphQL request",
+		}),
+	})
+
+	if err != nil {
+		c.writeResult(w, http.StatusBadRequest, &go_graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	validationResult := go_graphql.ValidateDocument(&c.Schema, document, nil)
+
+	if !validationResult.IsValid {
+		c.writeResult(w, http.StatusBadRequest, &go_graphql.Result{Errors: validationResult.Errors})
+		return
+	}
+
+	cost := analyzeQuery(document, graphqlRequest.OperationName, graphqlRequest.Variables)
+
+	if r.Method == http.MethodGet && cost.Operation != "query" {
+		c.writeError(w, http.StatusMethodNotAllowed, "only query operations are allowed over GET")
+		return
+	}
+
+	if cost.Depth > c.AppConfig.Graphql.MaxDepth {
+		c.writeError(w, http.StatusBadRequest, "query depth exceeds the maximum allowed depth")
+		return
+	}
+
+	if cost.Complexity > c.AppConfig.Graphql.MaxComplexity {
+		c.writeError(w, http.StatusBadRequest, "query complexity exceeds the maximum allowed complexity")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), categoryLoaderKey{}, newCategoryLoader(r.Context(), c.UseCase))
+
+	result := go_graphql.Execute(go_graphql.ExecuteParams{
+		Schema:        c.Schema,
+		AST:           document,
+		OperationName: graphqlRequest.OperationName,
+		Args:          graphqlRequest.Variables,
+		Context:       ctx,
+	})
+
+	c.writeResult(w, http.StatusOK, result)
+}
+
+func (c *graphqlControllerImpl) writeError(w http.ResponseWriter, statusCode int, message string) {
+	c.writeResult(w, statusCode, &go_graphql.Result{
+		Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(message)},
+	})
+}
+
+func (c *graphqlControllerImpl) writeResult(w http.ResponseWriter, statusCode int, result *go_graphql.Result) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(statusCode)
+
+	err := helper.WriteToResponseBody(w, result)
+	helper.InternalServerPanicIfError(err, "graphql > controller > Serve")
+}
+
+// resolve turns the panics raised by the use case into GraphQL errors, so one
+// failing field does not abort the whole response.
+func (c *graphqlControllerImpl) resolve(resolveFn go_graphql.FieldResolveFn) go_graphql.FieldResolveFn {
+	return func(p go_graphql.ResolveParams) (result any, err error) {
+		defer func() {
+			if errRecover := recover(); errRecover != nil {
+				result, err = nil, c.recoverError(p.Context, errRecover)
+			}
+		}()
+
+		return resolveFn(p)
+	}
+}
+
+func (c *graphqlControllerImpl) thunk(ctx context.Context, thunkFn func() (any, error)) func() (any, error) {
+	return func() (result any, err error) {
+		defer func() {
+			if errRecover := recover(); errRecover != nil {
+				result, err = nil, c.recoverError(ctx, errRecover)
+			}
+		}()
+
+		return thunkFn()
+	}
+}
+
+func (c *graphqlControllerImpl) recoverError(ctx context.Context, errRecover any) error {
+	switch err := errRecover.(type) {
+	case *exception.ErrorClientRequest:
+		return newGraphqlError(err.GetDetailError(), err.GetStatusCode())
+	case validator.ValidationErrors:
+		return newGraphqlError(err.Error(), http.StatusBadRequest)
+	case *exception.ErrorInternalServer:
+		helper.ContextLogger(c.Logger, ctx).WithError(err).WithField("detail_error", err.DetailError()).Error("internal server error")
+	case error:
+		helper.ContextLogger(c.Logger, ctx).WithError(err).Error("internal server error")
+	default:
+		helper.ContextLogger(c.Logger, ctx).WithField("panic", err).Error("internal server error")
+	}
+
+	return newGraphqlError("something went wrong", http.StatusInternalServerError)
+}
+
+type graphqlError struct {
+	error
+	Code string
+}
+
+func newGraphqlError(message string, statusCode int) *graphqlError {
+	return &graphqlError{
+		error: errors.New(message),
+		Code:  strings.ReplaceAll(strings.ToUpper(http.StatusText(statusCode)), " ", "_"),
+	}
+}
+
+func (e *graphqlError) Extensions() map[string]any {
+	return map[string]any{
+		"code": e.Code,
+	}
+}
@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const defaultPageSize = 10
+
+type queryCost struct {
+	Operation  string
+	Depth      int
+	Complexity int
+}
+
+type queryAnalyzer struct {
+	Fragments map[string]*ast.FragmentDefinition
+	Variables map[string]any
+}
+
+// analyzeQuery computes the depth and complexity of the operation that is going
+// to be executed. Every field costs one point and the cost of the selection
+// below a paginated field is multiplied by its page size. Introspection fields
+// are not counted, so tooling can still fetch the schema.
+func analyzeQuery(document *ast.Document, operationName string, variables map[string]any) *queryCost {
+	analyzer := &queryAnalyzer{
+		Fragments: map[string]*ast.FragmentDefinition{},
+		Variables: variables,
+	}
+
+	var operation *ast.OperationDefinition
+
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			analyzer.Fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operation == nil && (operationName == "" || (definition.Name != nil && definition.Name.Value == operationName)) {
+				operation = definition
+			}
+		}
+	}
+
+	if operation == nil {
+		return new(queryCost)
+	}
+
+	depth, complexity := analyzer.selectionSet(operation.SelectionSet)
+
+	return &queryCost{
+		Operation:  operation.Operation,
+		Depth:      depth,
+		Complexity: complexity,
+	}
+}
+
+func (a *queryAnalyzer) selectionSet(selectionSet *ast.SelectionSet) (int, int) {
+	if selectionSet == nil {
+		return 0, 0
+	}
+
+	maxDepth, totalComplexity := 0, 0
+
+	for _, selection := range selectionSet.Selections {
+		depth, complexity := 0, 0
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+
+			childDepth, childComplexity := a.selectionSet(selection.SelectionSet)
+
+			depth = childDepth + 1
+			complexity = 1 + childComplexity*a.pageSize(selection)
+		case *ast.InlineFragment:
+			depth, complexity = a.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := a.Fragments[selection.Name.Value]; ok {
+				depth, complexity = a.selectionSet(fragment.SelectionSet)
+			}
+		}
+
+		maxDepth = max(maxDepth, depth)
+		totalComplexity += complexity
+	}
+
+	return maxDepth, totalComplexity
+}
+
+func (a *queryAnalyzer) pageSize(field *ast.Field) int {
+	if field.SelectionSet == nil {
+		return 1
+	}
+
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "first" {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if first, err := strconv.Atoi(value.Value); err == nil {
+				return max(first, 1)
+			}
+		case *ast.Variable:
+			if variable, ok := a.Variables[value.Name.Value].(float64); ok {
+				return max(int(variable), 1)
+			}
+		}
+
+		return defaultPageSize
+	}
+
+	if field.Name.Value == "categories" {
+		return defaultPageSize
+	}
+
+	return 1
+}
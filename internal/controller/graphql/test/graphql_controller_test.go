@@ -0,0 +1,369 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/graphql"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
+)
+
+var appTestConfig = &config.AppConfig{
+	Request: &config.Request{
+		MaxBodySize:           1024,
+		DisallowUnknownFields: true,
+	},
+	Graphql: &config.Graphql{
+		MaxDepth:      4,
+		MaxComplexity: 50,
+	},
+}
+
+type graphqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// serveGraphql serves the request of an admin API key unless the request
+// carries a principal already
+func serveGraphql(categoryUseCase usecase.CategoryUseCase, testRequest *http.Request) (*http.Response, *graphqlResponse) {
+	recorder := httptest.NewRecorder()
+
+	if security.PrincipalFromContext(testRequest.Context()) == nil {
+		testRequest = testRequest.WithContext(security.ContextWithPrincipal(testRequest.Context(), &security.Principal{
+			Id:     "KEY-1",
+			Scopes: []string{security.ScopeAdmin},
+		}))
+	}
+
+	// ---SUT (Subject Under Test)
+	graphql.NewGraphqlControllerImpl(appTestConfig, logrus.New(), categoryUseCase).Serve(recorder, testRequest, nil)
+	// ---------------------------
+
+	recorderResponse := recorder.Result()
+
+	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+	helper.PanicIfError(err)
+
+	result := new(graphqlResponse)
+
+	err = json.Unmarshal(responseBodyBytes, result)
+	helper.PanicIfError(err)
+
+	return recorderResponse, result
+}
+
+func TestServeFailed(t *testing.T) {
+	t.Run("Malformed Request Body", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(""))
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		recorder := httptest.NewRecorder()
+
+		// Action & Assert
+		assert.Panics(t, func() {
+			// ---SUT (Subject Under Test)
+			graphql.NewGraphqlControllerImpl(appTestConfig, logrus.New(), categoryUseCase).Serve(recorder, testRequest, nil)
+			// ---------------------------
+		})
+	})
+
+	t.Run("Query Depth Exceeded", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(
+			`{"query":"{ categories { edges { node { id } } pageInfo { endCursor } } }"}`,
+		))
+
+		appTestConfig.Graphql.MaxDepth = 2
+		defer func() { appTestConfig.Graphql.MaxDepth = 4 }()
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, recorderResponse.StatusCode)
+		assert.Equal(t, "query depth exceeds the maximum allowed depth", result.Errors[0].Message)
+
+		categoryUseCase.Mock.AssertNumberOfCalls(t, "FindPage", 0)
+	})
+
+	t.Run("Query Complexity Exceeded", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(
+			`{"query":"{ categories(first: 100) { edges { node { id name } } } }"}`,
+		))
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, recorderResponse.StatusCode)
+		assert.Equal(t, "query complexity exceeds the maximum allowed complexity", result.Errors[0].Message)
+
+		categoryUseCase.Mock.AssertNumberOfCalls(t, "FindPage", 0)
+	})
+
+	t.Run("Mutation Over GET", func(t *testing.T) {
+		// Arrange
+		query := url.QueryEscape(`mutation { deleteCategory(id: "CAT-1") }`)
+
+		testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/api/graphql?query="+query, nil)
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		// Action
+		recorderResponse, _ := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, recorderResponse.StatusCode)
+
+		categoryUseCase.Mock.AssertNumberOfCalls(t, "Delete", 0)
+	})
+
+	t.Run("Malformed Variables Over GET", func(t *testing.T) {
+		// Arrange
+		query := url.QueryEscape(`query Page($first: Int) { categories(first: $first) { totalCount } }`)
+
+		testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/api/graphql?query="+query+"&variables="+url.QueryEscape(`[2]`), nil)
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, recorderResponse.StatusCode)
+		assert.Equal(t, "variables must be a JSON object", result.Errors[0].Message)
+
+		categoryUseCase.Mock.AssertNumberOfCalls(t, "FindPage", 0)
+	})
+
+	t.Run("UseCase Update Method Panic", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(
+			`{"query":"mutation { updateCategory(id: \"CAT-1\", input: {name: \"Foods\"}) { id } }"}`,
+		))
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		categoryUseCase.Mock.
+			On("Update", mock.Anything, "CAT-1", &model.UpdateCategoryRequest{Name: "Foods"}).
+			Run(func(args mock.Arguments) {
+				panic(exception.NewErrorClientRequest(errors.New("not found"), http.StatusNotFound, "category is not found"))
+			})
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+		assert.Equal(t, "category is not found", result.Errors[0].Message)
+		assert.Equal(t, "NOT_FOUND", result.Errors[0].Extensions["code"])
+
+		categoryUseCase.Mock.AssertExpectations(t)
+	})
+
+	t.Run("Mutation Without Write Scope", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(
+			`{"query":"mutation { deleteCategory(id: \"CAT-1\") }"}`,
+		))
+
+		testRequest = testRequest.WithContext(security.ContextWithPrincipal(testRequest.Context(), &security.Principal{
+			Id:     "KEY-2",
+			Scopes: []string{security.ScopeCategoriesRead},
+		}))
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+		assert.Equal(t, "the credentials do not have the categories:write scope", result.Errors[0].Message)
+		assert.Equal(t, "FORBIDDEN", result.Errors[0].Extensions["code"])
+
+		categoryUseCase.Mock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestServeSuccess(t *testing.T) {
+	t.Run("Category By Id Lookups Are Batched", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(
+			`{"query":"{ a: category(id: \"CAT-1\") { id name } b: category(id: \"CAT-2\") { name } c: category(id: \"CAT-3\") { id } }"}`,
+		))
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		categoryUseCase.Mock.On("FindByIds", mock.Anything, []string{"CAT-1", "CAT-2", "CAT-3"}).Return([]model.CategoryResponse{
+			{Id: "CAT-1", Name: "Tools"},
+			{Id: "CAT-2", Name: "Foods"},
+		}).Times(1)
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, map[string]any{
+			"a": map[string]any{"id": "CAT-1", "name": "Tools"},
+			"b": map[string]any{"name": "Foods"},
+			"c": nil,
+		}, result.Data)
+
+		categoryUseCase.Mock.AssertExpectations(t)
+		categoryUseCase.Mock.AssertNumberOfCalls(t, "FindByIds", 1)
+	})
+
+	t.Run("Categories Connection", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(
+			`{"query":"query Page($first: Int) { categories(first: $first) { totalCount edges { node { id name } } pageInfo { hasNextPage endCursor } } }","variables":{"first":2}}`,
+		))
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		categoryUseCase.Mock.On("FindPage", mock.Anything, &model.PageCategoryRequest{Limit: 2}).Return(&model.CategoryPageResponse{
+			Categories: []model.CategoryResponse{
+				{Id: "CAT-1", Name: "Tools"},
+				{Id: "CAT-2", Name: "Foods"},
+			},
+			HasNextPage: true,
+			TotalCount:  3,
+		}).Times(1)
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+		assert.Empty(t, result.Errors)
+
+		categories := result.Data["categories"].(map[string]any)
+
+		assert.Equal(t, float64(3), categories["totalCount"])
+		assert.Len(t, categories["edges"], 2)
+		assert.Equal(t, true, categories["pageInfo"].(map[string]any)["hasNextPage"])
+
+		categoryUseCase.Mock.AssertExpectations(t)
+	})
+
+	t.Run("Categories Connection Over GET With Variables", func(t *testing.T) {
+		// Arrange
+		query := url.QueryEscape(`query Page($first: Int) { categories(first: $first) { totalCount } }`)
+
+		testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/api/graphql?query="+query+"&variables="+url.QueryEscape(`{"first":2}`), nil)
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		categoryUseCase.Mock.On("FindPage", mock.Anything, &model.PageCategoryRequest{Limit: 2}).Return(&model.CategoryPageResponse{
+			Categories: []model.CategoryResponse{
+				{Id: "CAT-1", Name: "Tools"},
+				{Id: "CAT-2", Name: "Foods"},
+			},
+			HasNextPage: true,
+			TotalCount:  3,
+		}).Times(1)
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, float64(3), result.Data["categories"].(map[string]any)["totalCount"])
+
+		categoryUseCase.Mock.AssertExpectations(t)
+	})
+
+	t.Run("Categories Connection After Cursor", func(t *testing.T) {
+		// Arrange
+		firstRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(
+			`{"query":"{ categories(first: 1) { pageInfo { endCursor } } }"}`,
+		))
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		categoryUseCase.Mock.On("FindPage", mock.Anything, &model.PageCategoryRequest{Limit: 1}).Return(&model.CategoryPageResponse{
+			Categories:  []model.CategoryResponse{{Id: "CAT-1", Name: "Tools"}},
+			HasNextPage: true,
+			TotalCount:  2,
+		}).Times(1)
+
+		categoryUseCase.Mock.On("FindPage", mock.Anything, &model.PageCategoryRequest{AfterId: "CAT-1", Limit: 1}).Return(&model.CategoryPageResponse{
+			Categories: []model.CategoryResponse{{Id: "CAT-2", Name: "Foods"}},
+			TotalCount: 2,
+		}).Times(1)
+
+		_, firstResult := serveGraphql(categoryUseCase, firstRequest)
+
+		endCursor := firstResult.Data["categories"].(map[string]any)["pageInfo"].(map[string]any)["endCursor"].(string)
+
+		secondRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(
+			`{"query":"{ categories(first: 1, after: \"`+endCursor+`\") { edges { node { id } } pageInfo { hasNextPage } } }"}`,
+		))
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, secondRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, false, result.Data["categories"].(map[string]any)["pageInfo"].(map[string]any)["hasNextPage"])
+
+		categoryUseCase.Mock.AssertExpectations(t)
+	})
+
+	t.Run("Create Category Mutation", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/api/graphql", strings.NewReader(
+			`{"query":"mutation { createCategory(input: {name: \"Fashions\"}) { id name } }"}`,
+		))
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		categoryUseCase.Mock.On("Create", mock.Anything, &model.CreateCategoryRequest{Name: "Fashions"}).Return(&model.CategoryResponse{
+			Id:   "CAT-1",
+			Name: "Fashions",
+		}).Times(1)
+
+		// Action
+		recorderResponse, result := serveGraphql(categoryUseCase, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, map[string]any{
+			"createCategory": map[string]any{"id": "CAT-1", "name": "Fashions"},
+		}, result.Data)
+
+		categoryUseCase.Mock.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ApiKeyController interface {
+	Create(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	Rotate(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	Revoke(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	FindAll(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+}
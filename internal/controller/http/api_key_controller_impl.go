@@ -0,0 +1,102 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type apiKeyControllerImpl struct {
+	AppConfig *config.AppConfig
+	UseCase   usecase.ApiKeyUseCase
+}
+
+func NewApiKeyControllerImpl(appConfig *config.AppConfig, useCase usecase.ApiKeyUseCase) ApiKeyController {
+	return &apiKeyControllerImpl{
+		AppConfig: appConfig,
+		UseCase:   useCase,
+	}
+}
+
+func (c *apiKeyControllerImpl) Create(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	apiKeyCreateRequest := new(model.CreateApiKeyRequest)
+
+	err := helper.ReadFromRequestBody(w, r, apiKeyCreateRequest, helper.RequestBodyOptions{
+		MaxBodySize:           c.AppConfig.Request.MaxBodySize,
+		DisallowUnknownFields: c.AppConfig.Request.DisallowUnknownFields,
+	})
+	helper.PanicIfError(err)
+
+	apiKeyResponse := c.UseCase.Create(r.Context(), apiKeyCreateRequest)
+
+	webResponse := &model.WebResponse[*model.ApiKeyResponse]{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   apiKeyResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("cache-control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+
+	err = helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "api key > http/controller > Create")
+}
+
+func (c *apiKeyControllerImpl) Rotate(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	apiKeyId := params.ByName("apiKeyId")
+
+	apiKeyResponse := c.UseCase.Rotate(r.Context(), apiKeyId)
+
+	webResponse := &model.WebResponse[*model.ApiKeyResponse]{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   apiKeyResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("cache-control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "api key > http/controller > Rotate")
+}
+
+func (c *apiKeyControllerImpl) Revoke(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	apiKeyId := params.ByName("apiKeyId")
+
+	c.UseCase.Revoke(r.Context(), apiKeyId)
+
+	webResponse := &model.WebResponseMessage{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "api key is successfully revoked",
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "api key > http/controller > Revoke")
+}
+
+func (c *apiKeyControllerImpl) FindAll(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	apiKeysResponse := c.UseCase.FindAll(r.Context())
+
+	webResponse := &model.WebResponse[[]model.ApiKeyResponse]{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   apiKeysResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "api key > http/controller > FindAll")
+}
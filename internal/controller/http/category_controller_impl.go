@@ -1,9 +1,11 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 
-	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
@@ -12,22 +14,36 @@ import (
 )
 
 type categoryControllerImpl struct {
-	UseCase usecase.CategoryUseCase
+	AppConfig *config.AppConfig
+	UseCase   usecase.CategoryUseCase
 }
 
-func NewCategoryControllerImpl(useCase usecase.CategoryUseCase) CategoryController {
+func NewCategoryControllerImpl(appConfig *config.AppConfig, useCase usecase.CategoryUseCase) CategoryController {
 	return &categoryControllerImpl{
-		UseCase: useCase,
+		AppConfig: appConfig,
+		UseCase:   useCase,
 	}
 }
 
 func (c *categoryControllerImpl) Create(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	categoryCreateRequest := new(model.CreateCategoryRequest)
 
-	err := helper.ReadFromRequestBody(r, categoryCreateRequest)
-	helper.ClientPanicIfError(err, exception.NewErrorClientRequest(err, http.StatusBadRequest, "malformed request body"))
+	err := helper.ReadFromRequestBody(w, r, categoryCreateRequest, c.requestBodyOptions())
+	helper.PanicIfError(err)
 
-	categoryResponse := c.UseCase.Create(r.Context(), categoryCreateRequest)
+	var categoryResponse *model.CategoryResponse
+
+	if idempotencyKey := r.Header.Get("idempotency-key"); idempotencyKey != "" {
+		var replayed bool
+
+		categoryResponse, replayed = c.UseCase.CreateIdempotent(r.Context(), idempotencyKey, categoryCreateRequest)
+
+		if replayed {
+			w.Header().Set("idempotent-replayed", "true")
+		}
+	} else {
+		categoryResponse = c.UseCase.Create(r.Context(), categoryCreateRequest)
+	}
 
 	webResponse := &model.WebResponse[*model.CategoryResponse]{
 		Code:   http.StatusCreated,
@@ -45,8 +61,8 @@ func (c *categoryControllerImpl) Create(w http.ResponseWriter, r *http.Request,
 func (c *categoryControllerImpl) Update(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	categoryUpdateRequest := new(model.UpdateCategoryRequest)
 
-	err := helper.ReadFromRequestBody(r, categoryUpdateRequest)
-	helper.ClientPanicIfError(err, exception.NewErrorClientRequest(err, http.StatusBadRequest, "malformed request body"))
+	err := helper.ReadFromRequestBody(w, r, categoryUpdateRequest, c.requestBodyOptions())
+	helper.PanicIfError(err)
 
 	categoryId := params.ByName("categoryId")
 
@@ -86,8 +102,19 @@ func (c *categoryControllerImpl) Delete(w http.ResponseWriter, r *http.Request,
 func (c *categoryControllerImpl) FindById(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	categoryId := params.ByName("categoryId")
 
+	// The version is read first, so it is never newer than the category
+	categoryVersion := c.UseCase.FindVersion(r.Context())
+
 	categoryResponse := c.UseCase.FindById(r.Context(), categoryId)
 
+	etag := helper.StrongETag(categoryResponse)
+
+	writeCredentialsVary(w)
+
+	if helper.WriteNotModified(w, r, etag, categoryVersion.UpdatedAt, c.AppConfig.HttpCache.CacheControl) {
+		return
+	}
+
 	webResponse := &model.WebResponse[*model.CategoryResponse]{
 		Code:   http.StatusOK,
 		Status: "OK",
@@ -102,6 +129,19 @@ func (c *categoryControllerImpl) FindById(w http.ResponseWriter, r *http.Request
 }
 
 func (c *categoryControllerImpl) FindAll(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	// The table version changes on every write, so it validates the whole
+	// collection without serializing it
+	categoryVersion := c.UseCase.FindVersion(r.Context())
+
+	// Every tenant counts its own versions, so the tenant is a part of the tag
+	etag := fmt.Sprintf(`"categories-%s-%d"`, url.PathEscape(categoryVersion.TenantId), categoryVersion.Version)
+
+	writeCredentialsVary(w)
+
+	if helper.WriteNotModified(w, r, etag, categoryVersion.UpdatedAt, c.AppConfig.HttpCache.CacheControl) {
+		return
+	}
+
 	categoriesResponse := c.UseCase.FindAll(r.Context())
 
 	webResponse := &model.WebResponse[[]model.CategoryResponse]{
@@ -116,3 +156,17 @@ func (c *categoryControllerImpl) FindAll(w http.ResponseWriter, r *http.Request,
 	err := helper.WriteToResponseBody(w, webResponse)
 	helper.InternalServerPanicIfError(err, "category > http/controller > FindAll")
 }
+
+func (c *categoryControllerImpl) requestBodyOptions() helper.RequestBodyOptions {
+	return helper.RequestBodyOptions{
+		MaxBodySize:           c.AppConfig.Request.MaxBodySize,
+		DisallowUnknownFields: c.AppConfig.Request.DisallowUnknownFields,
+	}
+}
+
+// writeCredentialsVary marks the category reads as depending on the
+// credentials, so a shared cache never answers one tenant with another's
+func writeCredentialsVary(w http.ResponseWriter) {
+	w.Header().Add("vary", "Authorization")
+	w.Header().Add("vary", "X-API-Key")
+}
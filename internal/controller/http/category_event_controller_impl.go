@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+)
+
+type categoryEventControllerImpl struct {
+	AppConfig *config.AppConfig
+	Broker    stream.Broker
+}
+
+func NewCategoryEventControllerImpl(appConfig *config.AppConfig, broker stream.Broker) CategoryEventController {
+	return &categoryEventControllerImpl{
+		AppConfig: appConfig,
+		Broker:    broker,
+	}
+}
+
+// Stream writes the category events of the tenant of the client as
+// Server-Sent Events until the client goes away, it falls behind, or the
+// server shuts down
+func (c *categoryEventControllerImpl) Stream(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	responseController := http.NewResponseController(w)
+
+	subscription := c.Broker.Subscribe(security.RequireTenant(r.Context()), r.Header.Get("last-event-id"))
+	defer c.Broker.Unsubscribe(subscription)
+
+	w.Header().Set("content-type", "text/event-stream")
+	w.Header().Set("cache-control", "no-store")
+	w.Header().Set("x-accel-buffering", "no")
+
+	// Flushing sends the headers, or fails before anything is written
+	err := responseController.Flush()
+	helper.InternalServerPanicIfError(err, "category event > http/controller > Stream")
+
+	if subscription.Reset {
+		fmt.Fprintf(w, "id: %s\nevent: reset\ndata: {}\n\n", subscription.LastEventId)
+	}
+
+	for i := range subscription.Replay {
+		writeCategoryEvent(w, &subscription.Replay[i])
+	}
+
+	heartbeat := time.NewTicker(c.AppConfig.Stream.Heartbeat * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		if err := responseController.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return
+			}
+
+			writeCategoryEvent(w, &event)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+	}
+}
+
+func writeCategoryEvent(w http.ResponseWriter, event *model.CategoryEventResponse) {
+	data, err := json.Marshal(event)
+	helper.InternalServerPanicIfError(err, "category event > http/controller > writeCategoryEvent")
+
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+}
@@ -0,0 +1,11 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type CategorySubscriptionController interface {
+	Serve(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+}
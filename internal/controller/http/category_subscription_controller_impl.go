@@ -0,0 +1,299 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/julienschmidt/httprouter"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+)
+
+// sessionClose ends a WebSocket session with its close status
+type sessionClose struct {
+	code   websocket.StatusCode
+	reason string
+}
+
+func (e *sessionClose) Error() string {
+	return e.reason
+}
+
+type categorySubscriptionControllerImpl struct {
+	AppConfig     *config.AppConfig
+	Broker        stream.Broker
+	ApiKeyUseCase usecase.ApiKeyUseCase
+}
+
+func NewCategorySubscriptionControllerImpl(appConfig *config.AppConfig, broker stream.Broker, apiKeyUseCase usecase.ApiKeyUseCase) CategorySubscriptionController {
+	return &categorySubscriptionControllerImpl{
+		AppConfig:     appConfig,
+		Broker:        broker,
+		ApiKeyUseCase: apiKeyUseCase,
+	}
+}
+
+// Serve upgrades to a WebSocket. A client without an X-API-Key header, like a
+// browser, sends its API key in an auth message first. The API key needs the
+// categories:read scope, and a key which requires signed requests is accepted
+// only once the auth middleware has checked the signature of the upgrade. The
+// session receives the events of the tenant of the API key only.
+func (c *categorySubscriptionControllerImpl) Serve(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	apiKey := r.Header.Get("X-API-Key")
+
+	// The auth middleware has authenticated the X-API-Key header already,
+	// unless the server takes the bearer tokens only
+	principal := security.PrincipalFromContext(r.Context())
+
+	if principal != nil && principal.AuthMode != security.AuthModeApiKey {
+		principal = nil
+	}
+
+	if principal == nil && apiKey != "" {
+		principal = c.ApiKeyUseCase.Authenticate(r.Context(), apiKey)
+
+		if principal == nil || principal.SigningSecret != "" {
+			panic(security.NewUnauthorizedError(errors.New("unauthorized")))
+		}
+	}
+
+	// A principal without a tenant would receive the events of every tenant
+	var tenantId string
+
+	if principal != nil {
+		principalCtx := security.ContextWithPrincipal(r.Context(), principal)
+
+		security.RequireScope(principalCtx, security.ScopeCategoriesRead)
+		tenantId = security.RequireTenant(principalCtx)
+	}
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		OriginPatterns: c.originPatterns(),
+	})
+
+	// Accept has answered the failed handshake already
+	if err != nil {
+		return
+	}
+
+	defer conn.CloseNow()
+
+	conn.SetReadLimit(c.AppConfig.Websocket.MaxMessageSize)
+
+	if principal == nil {
+		if principal = c.authenticate(r.Context(), conn); principal == nil {
+			return
+		}
+
+		tenantId = principal.TenantId
+	}
+
+	if requestInfo := helper.RequestInfoFromContext(r.Context()); requestInfo != nil {
+		requestInfo.ApiKeyIdentity = principal.Id
+	}
+
+	ctx, cancel := context.WithCancelCause(r.Context())
+	defer cancel(nil)
+
+	c.serveSession(ctx, cancel, conn, tenantId)
+
+	var closeError *sessionClose
+
+	if errors.As(context.Cause(ctx), &closeError) {
+		conn.Close(closeError.code, closeError.reason)
+	}
+}
+
+// authenticate closes the connection unless its first message is an auth
+// message with an API key of the categories:read scope and of a tenant
+func (c *categorySubscriptionControllerImpl) authenticate(ctx context.Context, conn *websocket.Conn) *security.Principal {
+	timer := time.AfterFunc(c.AppConfig.Websocket.AuthTimeout*time.Second, func() {
+		conn.Close(websocket.StatusPolicyViolation, "the auth message is late")
+	})
+
+	message := new(model.CategorySubscriptionMessage)
+
+	err := wsjson.Read(context.Background(), conn, message)
+
+	if !timer.Stop() || err != nil {
+		return nil
+	}
+
+	var principal *security.Principal
+
+	if message.Type == "auth" {
+		principal = c.ApiKeyUseCase.Authenticate(ctx, message.ApiKey)
+	}
+
+	// An auth message can not be signed, the keys which require signed
+	// requests authenticate with their X-API-Key header
+	if principal == nil || principal.SigningSecret != "" {
+		conn.Close(websocket.StatusPolicyViolation, "unauthorized")
+		return nil
+	}
+
+	if !principal.HasScope(security.ScopeCategoriesRead) || principal.TenantId == "" {
+		conn.Close(websocket.StatusPolicyViolation, "forbidden")
+		return nil
+	}
+
+	writeCtx, cancel := context.WithTimeout(context.Background(), c.AppConfig.Websocket.WriteTimeout*time.Second)
+	defer cancel()
+
+	if wsjson.Write(writeCtx, conn, &model.CategorySubscriptionReply{Type: "ack", Id: message.Id}) != nil {
+		return nil
+	}
+
+	return principal
+}
+
+// serveSession reads the client messages, pings the client and forwards the
+// matching events of the tenant until the session is cancelled with its cause. A cancelled
+// read or write closes the connection at once, so they never use the context
+// of the session, and the close status is sent first.
+func (c *categorySubscriptionControllerImpl) serveSession(ctx context.Context, cancel context.CancelCauseFunc, conn *websocket.Conn, tenantId string) {
+	subscription := c.Broker.Subscribe(tenantId, "")
+	defer c.Broker.Unsubscribe(subscription)
+
+	filter := stream.NewCategoryFilter(c.AppConfig.Websocket.MaxSubscriptions)
+	outbound := make(chan *model.CategorySubscriptionReply, c.AppConfig.Websocket.SendBuffer)
+
+	// A reply is dropped with the session when the client does not read fast
+	// enough, so neither the reader nor the broker ever waits for it
+	send := func(reply *model.CategorySubscriptionReply) {
+		select {
+		case outbound <- reply:
+		default:
+			cancel(&sessionClose{code: websocket.StatusPolicyViolation, reason: "the client is too slow"})
+		}
+	}
+
+	go c.write(ctx, cancel, conn, outbound)
+	go c.read(cancel, conn, filter, send)
+	go c.ping(ctx, cancel, conn)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				if c.Broker.IsClosed() {
+					cancel(&sessionClose{code: websocket.StatusGoingAway, reason: "the server is shutting down"})
+				} else {
+					cancel(&sessionClose{code: websocket.StatusTryAgainLater, reason: "the events fell behind"})
+				}
+
+				return
+			}
+
+			if matches := filter.Match(&event); len(matches) > 0 {
+				send(&model.CategorySubscriptionReply{Type: "event", Subscriptions: matches, Event: &event})
+			}
+		}
+	}
+}
+
+func (c *categorySubscriptionControllerImpl) read(cancel context.CancelCauseFunc, conn *websocket.Conn, filter *stream.CategoryFilter, send func(*model.CategorySubscriptionReply)) {
+	for {
+		message := new(model.CategorySubscriptionMessage)
+
+		// The read ends once the connection is closed
+		if err := wsjson.Read(context.Background(), conn, message); err != nil {
+			cancel(err)
+			return
+		}
+
+		switch message.Type {
+		case "subscribe":
+			subscriptionId, err := filter.Subscribe(message.CategoryIds)
+
+			if err != nil {
+				send(&model.CategorySubscriptionReply{Type: "error", Id: message.Id, Message: err.Error()})
+				continue
+			}
+
+			send(&model.CategorySubscriptionReply{Type: "ack", Id: message.Id, Subscription: subscriptionId})
+		case "unsubscribe":
+			if !filter.Unsubscribe(message.Subscription) {
+				send(&model.CategorySubscriptionReply{Type: "error", Id: message.Id, Message: "subscription " + message.Subscription + " is not found"})
+				continue
+			}
+
+			send(&model.CategorySubscriptionReply{Type: "ack", Id: message.Id, Subscription: message.Subscription})
+		case "ping":
+			send(&model.CategorySubscriptionReply{Type: "pong", Id: message.Id})
+		default:
+			send(&model.CategorySubscriptionReply{Type: "error", Id: message.Id, Message: "unknown message type " + message.Type})
+		}
+	}
+}
+
+func (c *categorySubscriptionControllerImpl) write(ctx context.Context, cancel context.CancelCauseFunc, conn *websocket.Conn, outbound chan *model.CategorySubscriptionReply) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case reply := <-outbound:
+			writeCtx, writeCancel := context.WithTimeout(context.Background(), c.AppConfig.Websocket.WriteTimeout*time.Second)
+
+			err := wsjson.Write(writeCtx, conn, reply)
+
+			writeCancel()
+
+			if err != nil {
+				cancel(&sessionClose{code: websocket.StatusPolicyViolation, reason: "the client is too slow"})
+				return
+			}
+		}
+	}
+}
+
+func (c *categorySubscriptionControllerImpl) ping(ctx context.Context, cancel context.CancelCauseFunc, conn *websocket.Conn) {
+	ticker := time.NewTicker(c.AppConfig.Websocket.PingInterval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pingCtx, pingCancel := context.WithTimeout(context.Background(), c.AppConfig.Websocket.PongTimeout*time.Second)
+
+			err := conn.Ping(pingCtx)
+
+			pingCancel()
+
+			if err != nil {
+				cancel(&sessionClose{code: websocket.StatusPolicyViolation, reason: "the pong is late"})
+				return
+			}
+		}
+	}
+}
+
+// originPatterns takes the hosts of the allowed CORS origins, the browsers
+// connect from the same origins as they call the API. They are read from the
+// current snapshot, so a reload of the CORS origins applies here as well.
+func (c *categorySubscriptionControllerImpl) originPatterns() []string {
+	patterns := []string{}
+
+	for _, allowedOrigin := range c.AppConfig.Current().Cors.AllowedOrigins {
+		if _, host, ok := strings.Cut(allowedOrigin, "://"); ok {
+			patterns = append(patterns, host)
+		} else {
+			patterns = append(patterns, allowedOrigin)
+		}
+	}
+
+	return patterns
+}
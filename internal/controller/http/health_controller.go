@@ -0,0 +1,12 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type HealthController interface {
+	Healthz(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	Readyz(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+)
+
+type healthControllerImpl struct {
+	UseCase usecase.HealthUseCase
+}
+
+func NewHealthControllerImpl(useCase usecase.HealthUseCase) HealthController {
+	return &healthControllerImpl{
+		UseCase: useCase,
+	}
+}
+
+func (c *healthControllerImpl) Healthz(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	webResponse := &model.WebResponseMessage{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "the server is up",
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("cache-control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "health > http/controller > Healthz")
+}
+
+func (c *healthControllerImpl) Readyz(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	healthResponse := c.UseCase.Ready(r.Context())
+
+	statusCode := http.StatusOK
+
+	if healthResponse.Status != "pass" {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	webResponse := &model.WebResponse[*model.HealthResponse]{
+		Code:   statusCode,
+		Status: strings.ToUpper(http.StatusText(statusCode)),
+		Data:   healthResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("cache-control", "no-store")
+	w.WriteHeader(statusCode)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "health > http/controller > Readyz")
+}
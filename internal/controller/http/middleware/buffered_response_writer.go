@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// bufferedResponseWriter holds the whole response, unless it is flushed, then
+// it passes the rest of the response through to the target as a stream
+type bufferedResponseWriter struct {
+	target     http.ResponseWriter
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+	streaming  bool
+}
+
+func newBufferedResponseWriter(target http.ResponseWriter) *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		target:     target,
+		header:     http.Header{},
+		statusCode: http.StatusOK,
+	}
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	if w.streaming {
+		return w.target.Header()
+	}
+
+	return w.header
+}
+
+func (w *bufferedResponseWriter) WriteHeader(statusCode int) {
+	if !w.streaming {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	if w.streaming {
+		return w.target.Write(data)
+	}
+
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) FlushError() error {
+	if !w.streaming {
+		w.streaming = true
+		w.flush(w.target)
+	}
+
+	return http.NewResponseController(w.target).Flush()
+}
+
+func (w *bufferedResponseWriter) flush(target http.ResponseWriter) {
+	for key, values := range w.header {
+		target.Header()[key] = values
+	}
+
+	target.WriteHeader(w.statusCode)
+
+	target.Write(w.body.Bytes())
+
+	w.body.Reset()
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+// trustedProxies resolves the client IP of the requests which come through
+// the reverse proxies of the deployment
+type trustedProxies []netip.Prefix
+
+func newTrustedProxies(proxies []string) trustedProxies {
+	prefixes := trustedProxies{}
+
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			addr, err := netip.ParseAddr(proxy)
+			helper.LogStdPanicIfError(err)
+
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(proxy)
+		helper.LogStdPanicIfError(err)
+
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes
+}
+
+// clientIp walks X-Forwarded-For from the right while the hops are trusted
+// proxies, the first untrusted hop is the client
+func (p trustedProxies) clientIp(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if !p.contains(host) {
+		return host
+	}
+
+	forwardedFor := strings.Split(strings.Join(r.Header.Values("x-forwarded-for"), ","), ",")
+
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwardedFor[i])
+
+		if hop == "" {
+			continue
+		}
+
+		host = hop
+
+		if !p.contains(hop) {
+			break
+		}
+	}
+
+	return host
+}
+
+func (p trustedProxies) contains(host string) bool {
+	addr, err := netip.ParseAddr(host)
+
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	return slices.ContainsFunc(p, func(prefix netip.Prefix) bool {
+		return prefix.Contains(addr)
+	})
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content types which are compressed already, compressing them again only
+// costs CPU
+var incompressibleContentTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/gzip",
+	"application/x-gzip",
+	"application/zip",
+	"application/zstd",
+	"application/x-brotli",
+	"application/pdf",
+	"application/octet-stream",
+	// The events are flushed one by one, so they never fill a compression block
+	"text/event-stream",
+}
+
+// compressResponseWriter holds the body until it reaches the minimum size, then
+// it decides once whether the response is written compressed or as it is.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding   string
+	minSize    int
+	statusCode int
+	buffer     []byte
+	encoder    io.WriteCloser
+	decided    bool
+
+	// encodedETagRequested is set when the If-None-Match header carried the
+	// tag of the encoded body, a 304 answers the same tag then
+	encodedETagRequested bool
+}
+
+func newCompressResponseWriter(w http.ResponseWriter, encoding string, minSize int) *compressResponseWriter {
+	return &compressResponseWriter{
+		ResponseWriter: w,
+		encoding:       encoding,
+		minSize:        minSize,
+		statusCode:     http.StatusOK,
+	}
+}
+
+func (w *compressResponseWriter) WriteHeader(statusCode int) {
+	if !w.decided {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *compressResponseWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.encoder != nil {
+			return w.encoder.Write(data)
+		}
+
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buffer = append(w.buffer, data...)
+
+	if len(w.buffer) >= w.minSize {
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Flush lets the streaming responses through without waiting for the minimum size
+func (w *compressResponseWriter) Flush() {
+	if !w.decided {
+		w.decide()
+	}
+
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressResponseWriter) close() error {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return err
+		}
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Close()
+	}
+
+	return nil
+}
+
+func (w *compressResponseWriter) decide() error {
+	w.decided = true
+
+	header := w.Header()
+
+	if w.shouldCompress() {
+		header.Set("content-encoding", w.encoding)
+		header.Del("content-length")
+
+		w.encoder = newEncoder(w.ResponseWriter, w.encoding)
+	}
+
+	if etag := header.Get("etag"); etag != "" && (w.encoder != nil || w.statusCode == http.StatusNotModified && w.encodedETagRequested) {
+		header.Set("etag", encodingETag(etag, w.encoding))
+	}
+
+	w.ResponseWriter.WriteHeader(w.statusCode)
+
+	if len(w.buffer) == 0 {
+		return nil
+	}
+
+	var err error
+
+	if w.encoder != nil {
+		_, err = w.encoder.Write(w.buffer)
+	} else {
+		_, err = w.ResponseWriter.Write(w.buffer)
+	}
+
+	w.buffer = nil
+
+	return err
+}
+
+func (w *compressResponseWriter) shouldCompress() bool {
+	if len(w.buffer) < w.minSize || len(w.buffer) == 0 {
+		return false
+	}
+
+	if w.statusCode < http.StatusOK || w.statusCode == http.StatusNoContent || w.statusCode == http.StatusNotModified {
+		return false
+	}
+
+	if w.Header().Get("content-encoding") != "" {
+		return false
+	}
+
+	contentType := strings.ToLower(w.Header().Get("content-type"))
+
+	for _, incompressible := range incompressibleContentTypes {
+		if strings.HasPrefix(contentType, incompressible) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newEncoder(w io.Writer, encoding string) io.WriteCloser {
+	switch encoding {
+	case "br":
+		return brotli.NewWriterLevel(w, brotli.DefaultCompression)
+	case "zstd":
+		encoder, _ := zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return encoder
+	default:
+		return gzip.NewWriter(w)
+	}
+}
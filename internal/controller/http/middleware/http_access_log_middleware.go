@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+// httpAccessLogMiddleware sits right inside the request ID middleware, so the
+// access log line carries the request ID and the route set by the router.
+type httpAccessLogMiddleware struct {
+	AppConfig      *config.AppConfig
+	Logger         *logrus.Logger
+	TrustedProxies trustedProxies
+	Handler        http.Handler
+}
+
+func NewHttpAccessLogMiddleware(appConfig *config.AppConfig, logger *logrus.Logger, handler http.Handler) HttpMiddleware {
+	return &httpAccessLogMiddleware{
+		AppConfig:      appConfig,
+		Logger:         logger,
+		TrustedProxies: newTrustedProxies(appConfig.Server.TrustedProxies),
+		Handler:        handler,
+	}
+}
+
+func (m *httpAccessLogMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	statusWriter := newStatusResponseWriter(w)
+
+	m.Handler.ServeHTTP(statusWriter, r)
+
+	fields := logrus.Fields{
+		"method":     r.Method,
+		"route":      "-",
+		"status":     statusWriter.status(),
+		"latency_ms": float64(time.Since(startTime).Microseconds()) / 1000,
+		"bytes":      statusWriter.size,
+		"remote_ip":  m.TrustedProxies.clientIp(r),
+		"user_agent": r.UserAgent(),
+	}
+
+	// The route pattern is logged instead of the raw path, it keeps the IDs out
+	// of the access log and groups the requests of the same endpoint
+	if requestInfo := helper.RequestInfoFromContext(r.Context()); requestInfo != nil {
+		fields["request_id"] = requestInfo.Id
+
+		if requestInfo.Route != "" {
+			fields["route"] = requestInfo.Route
+		}
+
+		if requestInfo.ApiKeyIdentity != "" {
+			fields["api_key"] = requestInfo.ApiKeyIdentity
+		}
+	}
+
+	if !m.shouldLog(fields["route"].(string), statusWriter.status()) {
+		return
+	}
+
+	m.Logger.WithFields(fields).Info("access")
+}
+
+func (m *httpAccessLogMiddleware) shouldLog(route string, statusCode int) bool {
+	if slices.Contains(m.AppConfig.Log.Access.ExcludeRoutes, route) {
+		return false
+	}
+
+	if statusCode >= http.StatusInternalServerError {
+		return true
+	}
+
+	return rand.Float64() < m.AppConfig.Log.Access.SampleRate
+}
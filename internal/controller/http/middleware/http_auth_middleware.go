@@ -1,29 +1,154 @@
 package middleware
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/pkg/signing"
+)
+
+const (
+	AuthModeApiKey = security.AuthModeApiKey
+	AuthModeJwt    = security.AuthModeJwt
+	// maxNonceLength is the size of the nonce column
+	maxNonceLength = 64
 )
 
 type httpAuthMiddleware struct {
-	AppConfig *config.AppConfig
-	Handler   http.Handler
+	AppConfig     *config.AppConfig
+	ApiKeyEnabled bool
+	JwtEnabled    bool
+	UseCase       usecase.ApiKeyUseCase
+	JwtVerifier   security.JwtVerifier
+	Handler       http.Handler
 }
 
-func NewHttpAuthMiddleware(appConfig *config.AppConfig, handler http.Handler) HttpMiddleware {
+// NewHttpAuthMiddleware enables the modes of server.authmodes, the configs
+// from before the bearer tokens have none and keep the API keys
+func NewHttpAuthMiddleware(appConfig *config.AppConfig, useCase usecase.ApiKeyUseCase, jwtVerifier security.JwtVerifier, handler http.Handler) HttpMiddleware {
+	authModes := appConfig.Server.AuthModes
+
+	if len(authModes) == 0 {
+		authModes = []string{AuthModeApiKey}
+	}
+
 	return &httpAuthMiddleware{
-		AppConfig: appConfig,
-		Handler:   handler,
+		AppConfig:     appConfig,
+		ApiKeyEnabled: slices.Contains(authModes, AuthModeApiKey),
+		JwtEnabled:    slices.Contains(authModes, AuthModeJwt),
+		UseCase:       useCase,
+		JwtVerifier:   jwtVerifier,
+		Handler:       handler,
 	}
 }
 
+// ServeHTTP passes the principal of the API key or the bearer token on in the
+// request context. A request without valid credentials passes on without a
+// principal, the auth policy of its route answers it.
 func (m *httpAuthMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if m.AppConfig.Server.ApiKey == r.Header.Get("X-API-Key") {
+	principal := m.authenticate(w, r)
+
+	if principal == nil {
 		m.Handler.ServeHTTP(w, r)
-	} else {
-		panic(exception.NewErrorClientRequest(errors.New("unauthorized"), http.StatusUnauthorized, "unauthorized"))
+		return
+	}
+
+	if requestInfo := helper.RequestInfoFromContext(r.Context()); requestInfo != nil {
+		requestInfo.ApiKeyIdentity = principal.Id
+	}
+
+	m.Handler.ServeHTTP(w, r.WithContext(security.ContextWithPrincipal(r.Context(), principal)))
+}
+
+// authenticate takes the bearer token when there is one, a request never
+// falls back to its API key after its token fails. An invalid token answers
+// 401 on every route.
+func (m *httpAuthMiddleware) authenticate(w http.ResponseWriter, r *http.Request) *security.Principal {
+	if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found && m.JwtEnabled {
+		principal, err := m.JwtVerifier.Verify(r.Context(), strings.TrimSpace(token))
+
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			panic(security.NewUnauthorizedError(err))
+		}
+
+		principal.AuthMode = AuthModeJwt
+
+		return principal
+	}
+
+	if m.ApiKeyEnabled {
+		if principal := m.UseCase.Authenticate(r.Context(), r.Header.Get("X-API-Key")); principal != nil {
+			principal.AuthMode = AuthModeApiKey
+
+			if principal.SigningSecret != "" {
+				m.verifySignature(r, principal)
+			}
+
+			return principal
+		}
+	}
+
+	return nil
+}
+
+// verifySignature panics with 401 unless the request carries a signature of
+// the signing secret of its API key over the headers of signing.headers, with
+// a nonce the key has not used yet. The body is read within the body limit and
+// put back for the handlers.
+func (m *httpAuthMiddleware) verifySignature(r *http.Request, principal *security.Principal) {
+	maxBodySize := m.AppConfig.Request.MaxBodySize
+
+	bodyReader := r.Body
+
+	if maxBodySize > 0 {
+		bodyReader = io.NopCloser(io.LimitReader(r.Body, maxBodySize+1))
+	}
+
+	body, err := io.ReadAll(bodyReader)
+	helper.InternalServerPanicIfError(err, "auth > middleware > verifySignature")
+
+	if maxBodySize > 0 && int64(len(body)) > maxBodySize {
+		panic(exception.NewErrorClientRequest(
+			errors.New("request body is too large"),
+			http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body must not be larger than %d bytes", maxBodySize),
+		))
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	signature, err := signing.Verify(
+		principal.SigningSecret,
+		r.Method,
+		r.URL.RequestURI(),
+		r.Header,
+		body,
+		r.Header.Get(signing.SignatureHeader),
+		m.AppConfig.Signing.ClockSkew*time.Second,
+		time.Now(),
+	)
+
+	if err != nil {
+		panic(security.NewUnauthorizedError(err))
+	}
+
+	if !signature.SignsHeaders(m.AppConfig.Signing.Headers) {
+		panic(security.NewUnauthorizedError(errors.New("request signature does not cover the required headers")))
+	}
+
+	if len(signature.Nonce) > maxNonceLength || !m.UseCase.ClaimNonce(r.Context(), principal.Id, signature.Nonce) {
+		panic(security.NewUnauthorizedError(errors.New("request signature nonce is used already")))
 	}
 }
@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+// The preferred encodings when the client accepts several with the same quality
+var supportedEncodings = []string{"br", "zstd", "gzip"}
+
+// httpCompressionMiddleware is the outermost middleware. The panic middleware
+// is inside of it, so the request body errors are written here directly.
+type httpCompressionMiddleware struct {
+	AppConfig *config.AppConfig
+	Handler   http.Handler
+}
+
+func NewHttpCompressionMiddleware(appConfig *config.AppConfig, handler http.Handler) HttpMiddleware {
+	return &httpCompressionMiddleware{
+		AppConfig: appConfig,
+		Handler:   handler,
+	}
+}
+
+func (m *httpCompressionMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch contentEncoding := strings.ToLower(strings.TrimSpace(r.Header.Get("content-encoding"))); contentEncoding {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		gzipReader, err := gzip.NewReader(r.Body)
+
+		if err != nil {
+			writeCompressionError(w, http.StatusBadRequest, "request body is not valid gzip")
+			return
+		}
+
+		defer gzipReader.Close()
+
+		r.Body = gzipReader
+		r.ContentLength = -1
+		r.Header.Del("content-encoding")
+		r.Header.Del("content-length")
+	default:
+		w.Header().Set("accept-encoding", "gzip")
+		writeCompressionError(w, http.StatusUnsupportedMediaType, "unsupported request content encoding "+contentEncoding)
+		return
+	}
+
+	w.Header().Add("vary", "Accept-Encoding")
+
+	encoding := negotiateEncoding(r.Header.Get("accept-encoding"))
+
+	// An upgraded connection is hijacked, it has no response body to compress
+	if encoding == "" || r.Method == http.MethodHead || r.Header.Get("upgrade") != "" {
+		m.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	compressWriter := newCompressResponseWriter(w, encoding, m.AppConfig.Compression.MinSize)
+
+	// The handlers compare the tags of the identity body, so the suffix of
+	// the encoded representation is taken off the tags of the client
+	if ifNoneMatch := r.Header.Get("if-none-match"); ifNoneMatch != "" {
+		identityIfNoneMatch := stripEncodingETags(ifNoneMatch, encoding)
+
+		compressWriter.encodedETagRequested = identityIfNoneMatch != ifNoneMatch
+		r.Header.Set("if-none-match", identityIfNoneMatch)
+	}
+
+	// A failed write means the client is gone, there is nobody to report to
+	defer compressWriter.close()
+
+	m.Handler.ServeHTTP(compressWriter, r)
+}
+
+// negotiateEncoding picks the supported encoding with the highest quality in
+// the Accept-Encoding header, an empty string means identity
+func negotiateEncoding(acceptEncoding string) string {
+	qualities := map[string]float64{}
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+
+		if coding == "" {
+			continue
+		}
+
+		quality := 1.0
+
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		qualities[coding] = quality
+	}
+
+	bestEncoding := ""
+	bestQuality := 0.0
+
+	for _, encoding := range supportedEncodings {
+		quality, ok := qualities[encoding]
+
+		if !ok {
+			quality, ok = qualities["*"]
+		}
+
+		if ok && quality > bestQuality {
+			bestEncoding = encoding
+			bestQuality = quality
+		}
+	}
+
+	return bestEncoding
+}
+
+// encodingETag tells the strong ETag of an encoded body apart from the one of
+// the identity body, as a strong validator differs per content coding (RFC
+// 9110 8.8.3). A weak ETag is left as it is.
+func encodingETag(etag string, encoding string) string {
+	if len(etag) < 2 || !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+
+	return strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+}
+
+// stripEncodingETags turns the tags of the encoded body in an If-None-Match
+// header back into the tags of the identity body
+func stripEncodingETags(ifNoneMatch string, encoding string) string {
+	candidates := strings.Split(ifNoneMatch, ",")
+
+	for i, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+
+		if strippedCandidate, ok := strings.CutSuffix(candidate, "-"+encoding+`"`); ok {
+			candidate = strippedCandidate + `"`
+		}
+
+		candidates[i] = candidate
+	}
+
+	return strings.Join(candidates, ", ")
+}
+
+func writeCompressionError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(statusCode)
+
+	helper.WriteToResponseBody(w, &model.WebResponseMessage{
+		Code:    statusCode,
+		Status:  strings.ToUpper(http.StatusText(statusCode)),
+		Message: message,
+	})
+}
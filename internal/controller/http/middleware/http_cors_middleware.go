@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+)
+
+// httpCorsMiddleware sits before the auth middleware, so the preflight requests,
+// which never carry the API key, are answered without reaching it.
+type httpCorsMiddleware struct {
+	AppConfig *config.AppConfig
+	Handler   http.Handler
+}
+
+func NewHttpCorsMiddleware(appConfig *config.AppConfig, handler http.Handler) HttpMiddleware {
+	return &httpCorsMiddleware{
+		AppConfig: appConfig,
+		Handler:   handler,
+	}
+}
+
+func (m *httpCorsMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("origin")
+	requestMethod := r.Header.Get("access-control-request-method")
+	isPreflight := r.Method == http.MethodOptions && origin != "" && requestMethod != ""
+
+	w.Header().Add("vary", "Origin")
+
+	// The snapshot is read once, so a reload never mixes two configs in a response
+	cors := m.AppConfig.Current().Cors
+
+	if origin == "" {
+		m.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	if !isCorsOriginAllowed(cors, origin) {
+		if isPreflight {
+			panic(exception.NewErrorClientRequest(errors.New("cors origin is not allowed"), http.StatusForbidden, "origin "+origin+" is not allowed"))
+		}
+
+		m.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	if isPreflight {
+		m.preflight(w, r, cors, origin, requestMethod)
+		return
+	}
+
+	writeCorsAllowOrigin(w, cors, origin)
+
+	if len(cors.ExposedHeaders) > 0 {
+		w.Header().Set("access-control-expose-headers", strings.Join(cors.ExposedHeaders, ", "))
+	}
+
+	m.Handler.ServeHTTP(w, r)
+}
+
+func (m *httpCorsMiddleware) preflight(w http.ResponseWriter, r *http.Request, cors *config.Cors, origin string, requestMethod string) {
+	w.Header().Add("vary", "Access-Control-Request-Method")
+	w.Header().Add("vary", "Access-Control-Request-Headers")
+
+	if !slices.ContainsFunc(cors.AllowedMethods, func(method string) bool {
+		return strings.EqualFold(method, requestMethod)
+	}) {
+		panic(exception.NewErrorClientRequest(errors.New("cors method is not allowed"), http.StatusForbidden, "method "+requestMethod+" is not allowed"))
+	}
+
+	requestHeaders := []string{}
+
+	for _, requestHeader := range strings.Split(r.Header.Get("access-control-request-headers"), ",") {
+		requestHeader = strings.TrimSpace(requestHeader)
+
+		if requestHeader == "" {
+			continue
+		}
+
+		if !slices.ContainsFunc(cors.AllowedHeaders, func(header string) bool {
+			return header == "*" || strings.EqualFold(header, requestHeader)
+		}) {
+			panic(exception.NewErrorClientRequest(errors.New("cors header is not allowed"), http.StatusForbidden, "header "+requestHeader+" is not allowed"))
+		}
+
+		requestHeaders = append(requestHeaders, requestHeader)
+	}
+
+	writeCorsAllowOrigin(w, cors, origin)
+
+	w.Header().Set("access-control-allow-methods", strings.Join(cors.AllowedMethods, ", "))
+
+	// Only the requested headers are echoed, "*" is not honoured with credentials
+	if len(requestHeaders) > 0 {
+		w.Header().Set("access-control-allow-headers", strings.Join(requestHeaders, ", "))
+	}
+
+	if cors.MaxAge > 0 {
+		w.Header().Set("access-control-max-age", strconv.Itoa(cors.MaxAge))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeCorsAllowOrigin(w http.ResponseWriter, cors *config.Cors, origin string) {
+	// Browsers reject the "*" origin on credentialed requests, so the origin is echoed
+	if slices.Contains(cors.AllowedOrigins, "*") && !cors.AllowCredentials {
+		w.Header().Set("access-control-allow-origin", "*")
+	} else {
+		w.Header().Set("access-control-allow-origin", origin)
+	}
+
+	if cors.AllowCredentials {
+		w.Header().Set("access-control-allow-credentials", "true")
+	}
+}
+
+// isCorsOriginAllowed matches the origin against the allowed origins, where a
+// "*" in an allowed origin matches any characters, e.g. "https://*.example.com"
+func isCorsOriginAllowed(cors *config.Cors, origin string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, allowedOrigin := range cors.AllowedOrigins {
+		allowedOrigin = strings.ToLower(allowedOrigin)
+
+		prefix, suffix, hasWildcard := strings.Cut(allowedOrigin, "*")
+
+		if !hasWildcard {
+			if origin == allowedOrigin {
+				return true
+			}
+
+			continue
+		}
+
+		if len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/metrics"
+)
+
+// The methods which are not served are grouped in the metrics and the span
+// names, a client must not be able to add label values at will
+var knownMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// httpMetricsMiddleware sits right inside the request ID middleware, like the
+// access log, so it sees the route set by the router and the final status.
+type httpMetricsMiddleware struct {
+	Handler http.Handler
+}
+
+func NewHttpMetricsMiddleware(handler http.Handler) HttpMiddleware {
+	return &httpMetricsMiddleware{
+		Handler: handler,
+	}
+}
+
+func (m *httpMetricsMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	statusWriter := newStatusResponseWriter(w)
+
+	m.Handler.ServeHTTP(statusWriter, r)
+
+	route := "unmatched"
+
+	if requestInfo := helper.RequestInfoFromContext(r.Context()); requestInfo != nil && requestInfo.Route != "" {
+		route = requestInfo.Route
+	}
+
+	method := knownMethod(r.Method)
+
+	status := strconv.Itoa(statusWriter.status())
+
+	metrics.HttpRequestsTotal.WithLabelValues(route, method, status).Inc()
+	metrics.HttpRequestDurationSeconds.WithLabelValues(route, method, status).Observe(time.Since(startTime).Seconds())
+}
+
+func knownMethod(method string) string {
+	if knownMethods[method] {
+		return method
+	}
+
+	return "OTHER"
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+)
+
+type httpOpenApiRequestMiddleware struct {
+	Router  routers.Router
+	Handler http.Handler
+}
+
+func NewHttpOpenApiRequestMiddleware(handler http.Handler) HttpMiddleware {
+	return &httpOpenApiRequestMiddleware{
+		Router:  newOpenApiRouter(),
+		Handler: handler,
+	}
+}
+
+func (m *httpOpenApiRequestMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, pathParams, err := m.Router.FindRoute(r)
+
+	// Endpoints which are not described in the spec are served as is
+	if err != nil {
+		m.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	// A body without a content type is decoded as JSON by the controllers
+	validationRequest := r.Clone(r.Context())
+
+	if validationRequest.Header.Get("content-type") == "" {
+		validationRequest.Header.Set("content-type", "application/json")
+	}
+
+	err = openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+		Request:    validationRequest,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    newOpenApiOptions(),
+	})
+
+	r.Body = validationRequest.Body
+
+	if maxBytesError := new(http.MaxBytesError); errors.As(err, &maxBytesError) {
+		panic(exception.NewErrorClientRequest(
+			err,
+			http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body must not be larger than %d bytes", maxBytesError.Limit),
+		))
+	}
+
+	if err != nil {
+		panic(exception.NewErrorValidationRequest(
+			errors.Join(errors.New("request does not match the API spec"), err),
+			"request does not match the API spec",
+			openApiErrorPointers(err),
+		))
+	}
+
+	m.Handler.ServeHTTP(w, r)
+}
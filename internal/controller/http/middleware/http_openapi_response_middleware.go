@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/sirupsen/logrus"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+// httpOpenApiResponseMiddleware is meant for the test mode. It sits outside of
+// the panic middleware, so the error responses are validated as well, and any
+// response which drifts from the spec is replaced with a 500 response.
+type httpOpenApiResponseMiddleware struct {
+	Logger  *logrus.Logger
+	Router  routers.Router
+	Handler http.Handler
+}
+
+func NewHttpOpenApiResponseMiddleware(logger *logrus.Logger, handler http.Handler) HttpMiddleware {
+	return &httpOpenApiResponseMiddleware{
+		Logger:  logger,
+		Router:  newOpenApiRouter(),
+		Handler: handler,
+	}
+}
+
+func (m *httpOpenApiResponseMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, pathParams, err := m.Router.FindRoute(r)
+
+	if err != nil {
+		m.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	bufferedWriter := newBufferedResponseWriter(w)
+
+	m.Handler.ServeHTTP(bufferedWriter, r)
+
+	// A streamed response is sent before it ends, so only the buffered
+	// responses are validated
+	if bufferedWriter.streaming {
+		return
+	}
+
+	err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    newOpenApiOptions(),
+		},
+		Status:  bufferedWriter.statusCode,
+		Header:  bufferedWriter.Header(),
+		Body:    io.NopCloser(bytes.NewReader(bufferedWriter.body.Bytes())),
+		Options: newOpenApiOptions(),
+	})
+
+	if err != nil {
+		helper.ContextLogger(m.Logger, r.Context()).WithError(err).WithField("detail_error", "openapi > middleware > ValidateResponse").Error("response does not match the API spec")
+
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+
+		helper.WriteToResponseBody(w, &model.WebResponseMessage{
+			Code:    http.StatusInternalServerError,
+			Status:  "INTERNAL SERVER ERROR",
+			Message: "response does not match the API spec: " + err.Error(),
+		})
+
+		return
+	}
+
+	bufferedWriter.flush(w)
+}
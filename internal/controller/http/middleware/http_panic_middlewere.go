@@ -2,11 +2,13 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/metrics"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
 )
 
@@ -31,6 +33,8 @@ func (m *httpPanicMiddleware) recoverError(w http.ResponseWriter, r *http.Reques
 	errRecover := recover()
 
 	if errRecover != nil {
+		metrics.HttpPanicsRecoveredTotal.WithLabelValues(panicClass(errRecover)).Inc()
+
 		w.Header().Set("content-type", "application/json")
 
 		if m.clientError(w, r, errRecover) {
@@ -41,44 +45,54 @@ func (m *httpPanicMiddleware) recoverError(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+func panicClass(err any) string {
+	switch err.(type) {
+	case *exception.ErrorClientRequest:
+		return "client"
+	case *exception.ErrorValidationRequest, validator.ValidationErrors:
+		return "validation"
+	case *exception.ErrorInternalServer:
+		return "internal"
+	}
+
+	return "unknown"
+}
+
 func (m *httpPanicMiddleware) clientError(w http.ResponseWriter, _ *http.Request, err any) bool {
 	if exception, ok := err.(*exception.ErrorClientRequest); ok {
-		if exception.StatusCode == http.StatusBadRequest {
-			w.WriteHeader(http.StatusBadRequest)
+		w.WriteHeader(exception.StatusCode)
 
-			webResponse := &model.WebResponseMessage{
-				Code:    http.StatusBadRequest,
-				Status:  "BAD REQUEST",
-				Message: exception.GetDetailError(),
-			}
-
-			helper.WriteToResponseBody(w, webResponse)
+		webResponse := &model.WebResponseMessage{
+			Code:    exception.StatusCode,
+			Status:  strings.ToUpper(http.StatusText(exception.StatusCode)),
+			Message: exception.GetDetailError(),
 		}
 
-		if exception.StatusCode == http.StatusUnauthorized {
-			w.WriteHeader(http.StatusUnauthorized)
-
-			webResponse := &model.WebResponseMessage{
-				Code:    http.StatusUnauthorized,
-				Status:  "UNAUTHORIZED",
-				Message: exception.GetDetailError(),
-			}
+		helper.WriteToResponseBody(w, webResponse)
 
-			helper.WriteToResponseBody(w, webResponse)
-		}
+		return true
+	}
 
-		if exception.StatusCode == http.StatusNotFound {
-			w.WriteHeader(http.StatusNotFound)
+	if exception, ok := err.(*exception.ErrorValidationRequest); ok {
+		w.WriteHeader(http.StatusBadRequest)
 
-			webResponse := &model.WebResponseMessage{
-				Code:    http.StatusNotFound,
-				Status:  "NOT FOUND",
-				Message: exception.GetDetailError(),
-			}
+		webResponse := &model.WebResponseErrors{
+			Code:    http.StatusBadRequest,
+			Status:  "BAD REQUEST",
+			Message: exception.GetDetailError(),
+			Errors:  []model.ErrorDetail{},
+		}
 
-			helper.WriteToResponseBody(w, webResponse)
+		for _, errorPointer := range exception.Errors {
+			webResponse.Errors = append(webResponse.Errors, model.ErrorDetail{
+				Location: errorPointer.Location,
+				Pointer:  errorPointer.Pointer,
+				Message:  errorPointer.Message,
+			})
 		}
 
+		helper.WriteToResponseBody(w, webResponse)
+
 		return true
 	}
 
@@ -99,7 +113,7 @@ func (m *httpPanicMiddleware) clientError(w http.ResponseWriter, _ *http.Request
 	return false
 }
 
-func (m *httpPanicMiddleware) internalServerError(w http.ResponseWriter, _ *http.Request, err any) {
+func (m *httpPanicMiddleware) internalServerError(w http.ResponseWriter, r *http.Request, err any) {
 	w.WriteHeader(http.StatusInternalServerError)
 
 	webResponse := &model.WebResponseMessage{
@@ -113,8 +127,8 @@ func (m *httpPanicMiddleware) internalServerError(w http.ResponseWriter, _ *http
 	exception, ok := err.(*exception.ErrorInternalServer)
 
 	if ok {
-		m.Logger.WithError(exception).WithField("detail_error", exception.DetailError()).Error("internal server error")
+		helper.ContextLogger(m.Logger, r.Context()).WithError(exception).WithField("detail_error", exception.DetailError()).Error("internal server error")
 	} else {
-		m.Logger.WithError(exception).Error("internal server error")
+		helper.ContextLogger(m.Logger, r.Context()).WithField("panic", err).Error("internal server error")
 	}
 }
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/ratelimit"
+)
+
+// httpRateLimitMiddleware sits right after the auth middleware, so the
+// requests are limited per API key. The routes which are not behind auth are
+// limited per client IP.
+type httpRateLimitMiddleware struct {
+	AppConfig      *config.AppConfig
+	Logger         *logrus.Logger
+	Limiter        ratelimit.Limiter
+	TrustedProxies trustedProxies
+	Handler        http.Handler
+}
+
+func NewHttpRateLimitMiddleware(appConfig *config.AppConfig, logger *logrus.Logger, limiter ratelimit.Limiter, handler http.Handler) HttpMiddleware {
+	return &httpRateLimitMiddleware{
+		AppConfig:      appConfig,
+		Logger:         logger,
+		Limiter:        limiter,
+		TrustedProxies: newTrustedProxies(appConfig.Server.TrustedProxies),
+		Handler:        handler,
+	}
+}
+
+func (m *httpRateLimitMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	group := m.findGroup(r.URL.Path)
+
+	if group == nil {
+		m.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	limit := ratelimit.Limit{
+		Requests: group.Requests,
+		Period:   group.Period * time.Second,
+	}
+
+	result, err := m.Limiter.Allow(r.Context(), "ratelimit:"+group.Name+":"+m.clientKey(r), limit)
+
+	// An unavailable backend lets the requests through rather than taking the
+	// whole API down with it
+	if err != nil {
+		helper.ContextLogger(m.Logger, r.Context()).WithError(err).WithField("detail_error", "ratelimit > middleware > Allow").Error("rate limiter is unavailable")
+
+		m.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("ratelimit-policy", fmt.Sprintf("%d;w=%d", limit.Requests, group.Period))
+	w.Header().Set("ratelimit-limit", strconv.Itoa(result.Limit))
+	w.Header().Set("ratelimit-remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("ratelimit-reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+	if !result.Allowed {
+		w.Header().Set("retry-after", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+
+		panic(exception.NewErrorClientRequest(errors.New("rate limit exceeded"), http.StatusTooManyRequests, "too many requests, retry after "+w.Header().Get("retry-after")+" seconds"))
+	}
+
+	m.Handler.ServeHTTP(w, r)
+}
+
+func (m *httpRateLimitMiddleware) findGroup(path string) *config.RateLimitGroup {
+	var group *config.RateLimitGroup
+
+	// The groups of a snapshot never change, the reloads swap the snapshot
+	groups := m.AppConfig.Current().RateLimit.Groups
+
+	for i, candidate := range groups {
+		if !strings.HasPrefix(path, candidate.Prefix) {
+			continue
+		}
+
+		if group == nil || len(candidate.Prefix) > len(group.Prefix) {
+			group = &groups[i]
+		}
+	}
+
+	return group
+}
+
+func (m *httpRateLimitMiddleware) clientKey(r *http.Request) string {
+	if requestInfo := helper.RequestInfoFromContext(r.Context()); requestInfo != nil && requestInfo.ApiKeyIdentity != "" {
+		return requestInfo.ApiKeyIdentity
+	}
+
+	return "ip_" + m.TrustedProxies.clientIp(r)
+}
+
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+type httpRequestBodyMiddleware struct {
+	AppConfig *config.AppConfig
+	Handler   http.Handler
+}
+
+func NewHttpRequestBodyMiddleware(appConfig *config.AppConfig, handler http.Handler) HttpMiddleware {
+	return &httpRequestBodyMiddleware{
+		AppConfig: appConfig,
+		Handler:   handler,
+	}
+}
+
+// The body limits and the content type are checked before the OpenAPI
+// request validation reads the body, the controllers decode it strictly
+func (m *httpRequestBodyMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		m.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	err := helper.CheckJsonContentType(r)
+	helper.PanicIfError(err)
+
+	maxBodySize := m.AppConfig.Request.MaxBodySize
+
+	if maxBodySize > 0 && r.ContentLength > maxBodySize {
+		panic(exception.NewErrorClientRequest(
+			errors.New("request body is too large"),
+			http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body must not be larger than %d bytes", maxBodySize),
+		))
+	}
+
+	helper.LimitRequestBody(w, r, maxBodySize)
+
+	m.Handler.ServeHTTP(w, r)
+}
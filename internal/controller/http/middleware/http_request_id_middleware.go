@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+// Longer or unusual IDs are replaced, they end up in the logs and in the
+// Postgres application_name, which is limited to 63 bytes
+var validRequestId = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,63}$`)
+
+// httpRequestIdMiddleware is the outermost middleware, so every log line of
+// the request carries the same request ID.
+type httpRequestIdMiddleware struct {
+	IdGen   security.IdGenerator
+	Handler http.Handler
+}
+
+func NewHttpRequestIdMiddleware(idGen security.IdGenerator, handler http.Handler) HttpMiddleware {
+	return &httpRequestIdMiddleware{
+		IdGen:   idGen,
+		Handler: handler,
+	}
+}
+
+func (m *httpRequestIdMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("x-request-id")
+
+	if !validRequestId.MatchString(requestId) {
+		generatedId, err := m.IdGen.Generate(21)
+		helper.LogStdPanicIfError(err)
+
+		requestId = generatedId
+	}
+
+	w.Header().Set("x-request-id", requestId)
+
+	ctx := helper.ContextWithRequestInfo(r.Context(), &helper.RequestInfo{
+		Id:     requestId,
+		Method: r.Method,
+		Path:   r.URL.Path,
+	})
+
+	m.Handler.ServeHTTP(w, r.WithContext(ctx))
+}
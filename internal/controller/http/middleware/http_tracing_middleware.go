@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+
+// httpTracingMiddleware sits right inside the request ID middleware, so the
+// server span continues the inbound traceparent and covers the whole chain.
+type httpTracingMiddleware struct {
+	Handler http.Handler
+}
+
+func NewHttpTracingMiddleware(handler http.Handler) HttpMiddleware {
+	return &httpTracingMiddleware{
+		Handler: handler,
+	}
+}
+
+func (m *httpTracingMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+	method := knownMethod(r.Method)
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.URLPath(r.URL.Path),
+		),
+	)
+	defer span.End()
+
+	statusWriter := newStatusResponseWriter(w)
+
+	m.Handler.ServeHTTP(statusWriter, r.WithContext(ctx))
+
+	if requestInfo := helper.RequestInfoFromContext(ctx); requestInfo != nil {
+		span.SetAttributes(attribute.String("request.id", requestInfo.Id))
+
+		if requestInfo.Route != "" {
+			span.SetName(method + " " + requestInfo.Route)
+			span.SetAttributes(semconv.HTTPRoute(requestInfo.Route))
+		}
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(statusWriter.status()))
+
+	if statusWriter.status() >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(statusWriter.status()))
+	}
+}
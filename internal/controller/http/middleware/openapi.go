@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/api"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+func newOpenApiRouter() routers.Router {
+	loader := openapi3.NewLoader()
+
+	spec, err := loader.LoadFromData(api.Spec)
+	helper.LogStdPanicIfError(err)
+
+	err = spec.Validate(loader.Context)
+	helper.LogStdPanicIfError(err)
+
+	// Routes are matched by path only, the host depends on where the server is deployed
+	for _, server := range spec.Servers {
+		serverUrl, err := url.Parse(server.URL)
+		helper.LogStdPanicIfError(err)
+
+		server.URL = serverUrl.Path
+	}
+
+	router, err := gorillamux.NewRouter(spec)
+	helper.LogStdPanicIfError(err)
+
+	return router
+}
+
+func newOpenApiOptions() *openapi3filter.Options {
+	return &openapi3filter.Options{
+		MultiError:            true,
+		IncludeResponseStatus: true,
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+	}
+}
+
+func openApiErrorPointers(err error) []exception.ErrorPointer {
+	errorPointers := []exception.ErrorPointer{}
+
+	if multiError, ok := err.(openapi3.MultiError); ok {
+		for _, eachError := range multiError {
+			errorPointers = append(errorPointers, openApiErrorPointers(eachError)...)
+		}
+
+		return errorPointers
+	}
+
+	var requestError *openapi3filter.RequestError
+
+	if !errors.As(err, &requestError) {
+		return append(errorPointers, exception.ErrorPointer{Message: err.Error()})
+	}
+
+	location, pointerPrefix := "body", ""
+
+	if requestError.Parameter != nil {
+		location, pointerPrefix = requestError.Parameter.In, "/"+escapeJsonPointer(requestError.Parameter.Name)
+	}
+
+	schemaErrors := []*openapi3.SchemaError{}
+
+	if multiError, ok := requestError.Err.(openapi3.MultiError); ok {
+		for _, eachError := range multiError {
+			var schemaError *openapi3.SchemaError
+
+			if errors.As(eachError, &schemaError) {
+				schemaErrors = append(schemaErrors, schemaError)
+			}
+		}
+	} else {
+		var schemaError *openapi3.SchemaError
+
+		if errors.As(requestError.Err, &schemaError) {
+			schemaErrors = append(schemaErrors, schemaError)
+		}
+	}
+
+	if len(schemaErrors) == 0 {
+		return append(errorPointers, exception.ErrorPointer{
+			Location: location,
+			Pointer:  pointerPrefix,
+			Message:  requestError.Error(),
+		})
+	}
+
+	for _, schemaError := range schemaErrors {
+		pointer := pointerPrefix
+
+		for _, token := range schemaError.JSONPointer() {
+			pointer += "/" + escapeJsonPointer(token)
+		}
+
+		errorPointers = append(errorPointers, exception.ErrorPointer{
+			Location: location,
+			Pointer:  pointer,
+			Message:  schemaError.Reason,
+		})
+	}
+
+	return errorPointers
+}
+
+func escapeJsonPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
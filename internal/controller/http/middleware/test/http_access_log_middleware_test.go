@@ -0,0 +1,158 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logrus_test "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	internal_security_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/security/mock"
+)
+
+func setupAccessLogTestConfig(sampleRate float64, excludeRoutes ...string) *config.AppConfig {
+	return &config.AppConfig{
+		Server: &config.Server{
+			TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1"},
+		},
+		Log: &config.Log{
+			Access: config.AccessLog{
+				Enabled:       true,
+				SampleRate:    sampleRate,
+				ExcludeRoutes: excludeRoutes,
+			},
+		},
+	}
+}
+
+// routeHandler plays the router, which records the matched route pattern
+type routeHandler struct {
+	Route      string
+	StatusCode int
+}
+
+func (h *routeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	helper.RequestInfoFromContext(r.Context()).Route = h.Route
+
+	w.WriteHeader(h.StatusCode)
+	io.WriteString(w, "response body")
+}
+
+func serveAccessLog(appConfig *config.AppConfig, handler http.Handler, testRequest *http.Request) *logrus_test.Hook {
+	hookLogger, hook := logrus_test.NewNullLogger()
+
+	idGen := internal_security_mock.NewIdGenMock()
+
+	idGen.Mock.On("Generate", 21).Return("GENERATED-ID", nil).Maybe()
+
+	middleware.NewHttpRequestIdMiddleware(
+		idGen,
+		middleware.NewHttpAccessLogMiddleware(appConfig, hookLogger, handler),
+	).ServeHTTP(httptest.NewRecorder(), testRequest)
+
+	return hook
+}
+
+func TestAccessLogFields(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories/CAT-1", nil)
+	testRequest.RemoteAddr = "203.0.113.7:51234"
+	testRequest.Header.Set("x-request-id", "req-42")
+	testRequest.Header.Set("user-agent", "admin-panel/1.0")
+
+	// ---SUT (Subject Under Test)
+	hook := serveAccessLog(setupAccessLogTestConfig(1), &routeHandler{
+		Route:      "/api/v2/categories/:categoryId",
+		StatusCode: http.StatusNotFound,
+	}, testRequest)
+	// ---------------------------
+
+	// Assert
+	if assert.Len(t, hook.AllEntries(), 1) {
+		entry := hook.LastEntry()
+
+		assert.Equal(t, "access", entry.Message)
+		assert.Equal(t, "req-42", entry.Data["request_id"])
+		assert.Equal(t, http.MethodGet, entry.Data["method"])
+		assert.Equal(t, "/api/v2/categories/:categoryId", entry.Data["route"])
+		assert.Equal(t, http.StatusNotFound, entry.Data["status"])
+		assert.Equal(t, len("response body"), entry.Data["bytes"])
+		assert.Equal(t, "203.0.113.7", entry.Data["remote_ip"])
+		assert.Equal(t, "admin-panel/1.0", entry.Data["user_agent"])
+		assert.Contains(t, entry.Data, "latency_ms")
+		assert.NotContains(t, entry.Data, "path")
+	}
+}
+
+func TestAccessLogSampling(t *testing.T) {
+	tests := []struct {
+		name       string
+		appConfig  *config.AppConfig
+		route      string
+		statusCode int
+		logged     bool
+	}{
+		{name: "Sampled Out", appConfig: setupAccessLogTestConfig(0), route: "/api/v2/categories", statusCode: http.StatusOK, logged: false},
+		{name: "Server Errors Are Always Logged", appConfig: setupAccessLogTestConfig(0), route: "/api/v2/categories", statusCode: http.StatusInternalServerError, logged: true},
+		{name: "Excluded Route", appConfig: setupAccessLogTestConfig(1, "/api/v2/docs"), route: "/api/v2/docs", statusCode: http.StatusOK, logged: false},
+		{name: "Not Excluded Route", appConfig: setupAccessLogTestConfig(1, "/api/v2/docs"), route: "/api/v2/categories", statusCode: http.StatusOK, logged: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodGet, test.route, nil)
+
+			// ---SUT (Subject Under Test)
+			hook := serveAccessLog(test.appConfig, &routeHandler{
+				Route:      test.route,
+				StatusCode: test.statusCode,
+			}, testRequest)
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, test.logged, len(hook.AllEntries()) == 1)
+		})
+	}
+}
+
+func TestAccessLogRemoteIp(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		remoteIp     string
+	}{
+		{name: "Untrusted Peer Is Not Asked", remoteAddr: "203.0.113.7:1", forwardedFor: []string{"198.51.100.1"}, remoteIp: "203.0.113.7"},
+		{name: "Trusted Proxy", remoteAddr: "10.0.0.2:1", forwardedFor: []string{"198.51.100.1"}, remoteIp: "198.51.100.1"},
+		{name: "Chain Of Trusted Proxies", remoteAddr: "10.0.0.2:1", forwardedFor: []string{"6.6.6.6, 198.51.100.1, 192.168.1.1", "10.1.1.1"}, remoteIp: "198.51.100.1"},
+		{name: "Trusted Proxy Without Header", remoteAddr: "10.0.0.2:1", remoteIp: "10.0.0.2"},
+		{name: "IPv6 Peer", remoteAddr: "[2001:db8::1]:1", forwardedFor: []string{"198.51.100.1"}, remoteIp: "2001:db8::1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories", nil)
+			testRequest.RemoteAddr = test.remoteAddr
+
+			for _, forwardedFor := range test.forwardedFor {
+				testRequest.Header.Add("x-forwarded-for", forwardedFor)
+			}
+
+			// ---SUT (Subject Under Test)
+			hook := serveAccessLog(setupAccessLogTestConfig(1), &routeHandler{
+				Route:      "/api/v2/categories",
+				StatusCode: http.StatusOK,
+			}, testRequest)
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, test.remoteIp, hook.LastEntry().Data["remote_ip"])
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+var compressionTestConfig = &config.AppConfig{
+	Compression: &config.Compression{
+		Enabled: true,
+		MinSize: 64,
+	},
+}
+
+var largeBody = `{"data":"` + strings.Repeat("category ", 100) + `"}`
+
+func decompress(t *testing.T, encoding string, body []byte) string {
+	var reader io.Reader
+
+	switch encoding {
+	case "br":
+		reader = brotli.NewReader(bytes.NewReader(body))
+	case "zstd":
+		zstdReader, err := zstd.NewReader(bytes.NewReader(body))
+		helper.LogStdPanicIfError(err)
+
+		defer zstdReader.Close()
+
+		reader = zstdReader
+	default:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(body))
+		helper.LogStdPanicIfError(err)
+
+		reader = gzipReader
+	}
+
+	result, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+
+	return string(result)
+}
+
+func TestCompressionResponse(t *testing.T) {
+	tests := []struct {
+		name            string
+		acceptEncoding  string
+		contentType     string
+		body            string
+		contentEncoding string
+	}{
+		{name: "Gzip", acceptEncoding: "gzip", contentType: "application/json", body: largeBody, contentEncoding: "gzip"},
+		{name: "Brotli", acceptEncoding: "gzip, br", contentType: "application/json", body: largeBody, contentEncoding: "br"},
+		{name: "Zstd", acceptEncoding: "gzip;q=0.5, zstd", contentType: "application/json", body: largeBody, contentEncoding: "zstd"},
+		{name: "Wildcard", acceptEncoding: "*", contentType: "application/json", body: largeBody, contentEncoding: "br"},
+		{name: "Rejected Encodings", acceptEncoding: "br;q=0, zstd;q=0, gzip;q=0", contentType: "application/json", body: largeBody},
+		{name: "No Accept-Encoding", contentType: "application/json", body: largeBody},
+		{name: "Below Minimum Size", acceptEncoding: "gzip", contentType: "application/json", body: `{"data":"small"}`},
+		{name: "Already Compressed Content Type", acceptEncoding: "gzip", contentType: "image/png", body: largeBody},
+		{name: "Event Stream", acceptEncoding: "gzip", contentType: "text/event-stream", body: largeBody},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			if test.acceptEncoding != "" {
+				testRequest.Header.Set("accept-encoding", test.acceptEncoding)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			// ---SUT (Subject Under Test)
+			middleware.NewHttpCompressionMiddleware(compressionTestConfig, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("content-type", test.contentType)
+				w.WriteHeader(http.StatusCreated)
+
+				// Written in chunks to cross the minimum size in the middle
+				io.WriteString(w, test.body[:len(test.body)/2])
+				io.WriteString(w, test.body[len(test.body)/2:])
+			})).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+
+			// Assert
+			recorderResponse := recorder.Result()
+
+			assert.Equal(t, http.StatusCreated, recorderResponse.StatusCode)
+			assert.Equal(t, "Accept-Encoding", recorderResponse.Header.Get("vary"))
+			assert.Equal(t, test.contentEncoding, recorderResponse.Header.Get("content-encoding"))
+
+			if test.contentEncoding == "" {
+				assert.Equal(t, test.body, recorder.Body.String())
+			} else {
+				assert.Less(t, recorder.Body.Len(), len(test.body))
+				assert.Equal(t, test.body, decompress(t, test.contentEncoding, recorder.Body.Bytes()))
+			}
+		})
+	}
+}
+
+func TestCompressionNotModified(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, "/", nil)
+	testRequest.Header.Set("accept-encoding", "gzip")
+
+	recorder := httptest.NewRecorder()
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpCompressionMiddleware(compressionTestConfig, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})).ServeHTTP(recorder, testRequest)
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, http.StatusNotModified, recorder.Result().StatusCode)
+	assert.Empty(t, recorder.Result().Header.Get("content-encoding"))
+	assert.Empty(t, recorder.Body.Bytes())
+}
+
+func TestCompressionETag(t *testing.T) {
+	lastModified := time.Date(2026, time.October, 19, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		etag           string
+		ifNoneMatch    string
+		statusCode     int
+		responseETag   string
+	}{
+		{name: "Gzip Body", acceptEncoding: "gzip", etag: `"abc"`, statusCode: http.StatusOK, responseETag: `"abc-gzip"`},
+		{name: "Identity Body", etag: `"abc"`, statusCode: http.StatusOK, responseETag: `"abc"`},
+		{name: "Weak ETag", acceptEncoding: "gzip", etag: `W/"abc"`, statusCode: http.StatusOK, responseETag: `W/"abc"`},
+		{name: "Gzip Tag Matches", acceptEncoding: "gzip", etag: `"abc"`, ifNoneMatch: `"abc-gzip"`, statusCode: http.StatusNotModified, responseETag: `"abc-gzip"`},
+		{name: "Identity Tag Matches", acceptEncoding: "gzip", etag: `"abc"`, ifNoneMatch: `"abc"`, statusCode: http.StatusNotModified, responseETag: `"abc"`},
+		{name: "Tag Of Another Encoding", acceptEncoding: "br", etag: `"abc"`, ifNoneMatch: `"abc-gzip"`, statusCode: http.StatusOK, responseETag: `"abc-br"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			if test.acceptEncoding != "" {
+				testRequest.Header.Set("accept-encoding", test.acceptEncoding)
+			}
+
+			if test.ifNoneMatch != "" {
+				testRequest.Header.Set("if-none-match", test.ifNoneMatch)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			// ---SUT (Subject Under Test)
+			middleware.NewHttpCompressionMiddleware(compressionTestConfig, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if helper.WriteNotModified(w, r, test.etag, lastModified, "") {
+					return
+				}
+
+				w.Header().Set("content-type", "application/json")
+				io.WriteString(w, largeBody)
+			})).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, test.statusCode, recorder.Result().StatusCode)
+			assert.Equal(t, test.responseETag, recorder.Result().Header.Get("etag"))
+		})
+	}
+}
+
+func TestCompressionUpgrade(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/ws", nil)
+	testRequest.Header.Set("accept-encoding", "gzip")
+	testRequest.Header.Set("connection", "Upgrade")
+	testRequest.Header.Set("upgrade", "websocket")
+
+	recorder := httptest.NewRecorder()
+
+	var handlerWriter http.ResponseWriter
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpCompressionMiddleware(compressionTestConfig, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerWriter = w
+	})).ServeHTTP(recorder, testRequest)
+	// ---------------------------
+
+	// Assert
+	assert.Same(t, recorder, handlerWriter, "the connection is hijacked from the writer of the server")
+}
+
+func TestCompressionRequestSuccess(t *testing.T) {
+	// Arrange
+	compressedBody := new(bytes.Buffer)
+
+	gzipWriter := gzip.NewWriter(compressedBody)
+	gzipWriter.Write([]byte(largeBody))
+	gzipWriter.Close()
+
+	testRequest := httptest.NewRequest(http.MethodPost, "/", compressedBody)
+	testRequest.Header.Set("content-encoding", "gzip")
+
+	recorder := httptest.NewRecorder()
+
+	var receivedBody string
+	var receivedEncoding string
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpCompressionMiddleware(compressionTestConfig, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		helper.LogStdPanicIfError(err)
+
+		receivedBody = string(body)
+		receivedEncoding = r.Header.Get("content-encoding")
+	})).ServeHTTP(recorder, testRequest)
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	assert.Equal(t, largeBody, receivedBody)
+	assert.Empty(t, receivedEncoding)
+}
+
+func TestCompressionRequestFailed(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentEncoding string
+		statusCode      int
+	}{
+		{name: "Invalid Gzip Body", contentEncoding: "gzip", statusCode: http.StatusBadRequest},
+		{name: "Unsupported Encoding", contentEncoding: "br", statusCode: http.StatusUnsupportedMediaType},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"Drinks"}`))
+			testRequest.Header.Set("content-encoding", test.contentEncoding)
+
+			recorder := httptest.NewRecorder()
+
+			// ---SUT (Subject Under Test)
+			middleware.NewHttpCompressionMiddleware(compressionTestConfig, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("the handler must not be called")
+			})).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+
+			// Assert
+			recorderResponse := recorder.Result()
+
+			assert.Equal(t, test.statusCode, recorderResponse.StatusCode)
+
+			webResponse := new(model.WebResponseMessage)
+
+			err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+			helper.LogStdPanicIfError(err)
+
+			assert.Equal(t, test.statusCode, webResponse.Code)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+func setupCorsTestConfig(allowCredentials bool, allowedOrigins ...string) *config.AppConfig {
+	return &config.AppConfig{
+		Server: &config.Server{
+			ApiKey: "test_key",
+		},
+		Cors: &config.Cors{
+			AllowedOrigins:   allowedOrigins,
+			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},
+			AllowedHeaders:   []string{"Content-Type", "X-API-Key"},
+			ExposedHeaders:   []string{"ETag"},
+			AllowCredentials: allowCredentials,
+			MaxAge:           600,
+		},
+	}
+}
+
+// The CORS middleware is tested in its place of the chain, in front of auth
+// and a route which requires credentials
+func setupCorsMiddleware(appConfig *config.AppConfig) http.Handler {
+	return middleware.NewHttpPanicMiddleware(
+		logger,
+		middleware.NewHttpCorsMiddleware(
+			appConfig,
+			middleware.NewHttpAuthMiddleware(appTestConfig, newApiKeyUseCase("test_key"), nil, new(protectedHandler)),
+		),
+	)
+}
+
+func TestCorsPreflightSuccess(t *testing.T) {
+	tests := []struct {
+		name             string
+		appConfig        *config.AppConfig
+		origin           string
+		allowOrigin      string
+		allowCredentials string
+	}{
+		{name: "Exact Origin", appConfig: setupCorsTestConfig(false, "https://admin.example.com"), origin: "https://admin.example.com", allowOrigin: "https://admin.example.com"},
+		{name: "Wildcard Subdomain", appConfig: setupCorsTestConfig(false, "https://*.example.com"), origin: "https://panel.admin.example.com", allowOrigin: "https://panel.admin.example.com"},
+		{name: "Any Origin", appConfig: setupCorsTestConfig(false, "*"), origin: "https://other.test", allowOrigin: "*"},
+		{name: "Any Origin With Credentials", appConfig: setupCorsTestConfig(true, "*"), origin: "https://other.test", allowOrigin: "https://other.test", allowCredentials: "true"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodOptions, "/api/v2/categories", nil)
+			testRequest.Header.Set("origin", test.origin)
+			testRequest.Header.Set("access-control-request-method", "POST")
+			testRequest.Header.Set("access-control-request-headers", "content-type, x-api-key")
+
+			recorder := httptest.NewRecorder()
+
+			// ---SUT (Subject Under Test)
+			setupCorsMiddleware(test.appConfig).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+
+			// Assert
+			recorderResponse := recorder.Result()
+
+			assert.Equal(t, http.StatusNoContent, recorderResponse.StatusCode)
+			assert.Equal(t, test.allowOrigin, recorderResponse.Header.Get("access-control-allow-origin"))
+			assert.Equal(t, "GET, POST, PUT, DELETE", recorderResponse.Header.Get("access-control-allow-methods"))
+			assert.Equal(t, "content-type, x-api-key", recorderResponse.Header.Get("access-control-allow-headers"))
+			assert.Equal(t, "600", recorderResponse.Header.Get("access-control-max-age"))
+			assert.Equal(t, test.allowCredentials, recorderResponse.Header.Get("access-control-allow-credentials"))
+			assert.Contains(t, recorderResponse.Header.Values("vary"), "Origin")
+			assert.Empty(t, recorder.Body.Bytes())
+		})
+	}
+}
+
+func TestCorsPreflightFailed(t *testing.T) {
+	tests := []struct {
+		name           string
+		origin         string
+		requestMethod  string
+		requestHeaders string
+	}{
+		{name: "Origin Is Not Allowed", origin: "https://evil.test", requestMethod: "GET"},
+		{name: "Wildcard Does Not Match The Bare Domain", origin: "https://.example.com", requestMethod: "GET"},
+		{name: "Method Is Not Allowed", origin: "https://admin.example.com", requestMethod: "PATCH"},
+		{name: "Header Is Not Allowed", origin: "https://admin.example.com", requestMethod: "GET", requestHeaders: "x-secret"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodOptions, "/api/v2/categories", nil)
+			testRequest.Header.Set("origin", test.origin)
+			testRequest.Header.Set("access-control-request-method", test.requestMethod)
+
+			if test.requestHeaders != "" {
+				testRequest.Header.Set("access-control-request-headers", test.requestHeaders)
+			}
+
+			recorder := httptest.NewRecorder()
+
+			// ---SUT (Subject Under Test)
+			setupCorsMiddleware(setupCorsTestConfig(false, "https://*.example.com")).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+
+			// Assert
+			recorderResponse := recorder.Result()
+
+			assert.Equal(t, http.StatusForbidden, recorderResponse.StatusCode)
+			assert.Empty(t, recorderResponse.Header.Get("access-control-allow-origin"))
+
+			webResponse := new(model.WebResponseMessage)
+
+			err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+			helper.LogStdPanicIfError(err)
+
+			assert.Equal(t, http.StatusForbidden, webResponse.Code)
+			assert.Equal(t, "FORBIDDEN", webResponse.Status)
+		})
+	}
+}
+
+func TestCorsActualRequest(t *testing.T) {
+	t.Run("Allowed Origin", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories", nil)
+		testRequest.Header.Set("origin", "https://admin.example.com")
+		testRequest.Header.Set("x-api-key", "test_key")
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		setupCorsMiddleware(setupCorsTestConfig(true, "https://admin.example.com")).ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		recorderResponse := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+		assert.Equal(t, "https://admin.example.com", recorderResponse.Header.Get("access-control-allow-origin"))
+		assert.Equal(t, "true", recorderResponse.Header.Get("access-control-allow-credentials"))
+		assert.Equal(t, "ETag", recorderResponse.Header.Get("access-control-expose-headers"))
+		assert.Equal(t, "response from controllerHandler", recorder.Body.String())
+	})
+
+	t.Run("Unauthorized Response Is Readable By The Browser", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories", nil)
+		testRequest.Header.Set("origin", "https://admin.example.com")
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		setupCorsMiddleware(setupCorsTestConfig(false, "https://admin.example.com")).ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		recorderResponse := recorder.Result()
+
+		assert.Equal(t, http.StatusUnauthorized, recorderResponse.StatusCode)
+		assert.Equal(t, "https://admin.example.com", recorderResponse.Header.Get("access-control-allow-origin"))
+	})
+
+	t.Run("Origin Is Not Allowed", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories", nil)
+		testRequest.Header.Set("origin", "https://evil.test")
+		testRequest.Header.Set("x-api-key", "test_key")
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		setupCorsMiddleware(setupCorsTestConfig(false, "https://admin.example.com")).ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		recorderResponse := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+		assert.Empty(t, recorderResponse.Header.Get("access-control-allow-origin"))
+	})
+
+	t.Run("Without Origin", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodOptions, "/api/v2/categories", nil)
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		setupCorsMiddleware(setupCorsTestConfig(false, "*")).ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, recorder.Result().StatusCode)
+	})
+}
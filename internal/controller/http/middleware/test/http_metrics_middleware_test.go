@@ -0,0 +1,100 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/metrics"
+)
+
+type routedHandler struct {
+	Route string
+	Panic any
+}
+
+func (h *routedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if requestInfo := helper.RequestInfoFromContext(r.Context()); requestInfo != nil {
+		requestInfo.Route = h.Route
+	}
+
+	if h.Panic != nil {
+		panic(h.Panic)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func serveMetrics(method string, handler http.Handler) {
+	testRequest := httptest.NewRequest(method, "/api/v2/categories/CAT-1", nil)
+	testRequest = testRequest.WithContext(helper.ContextWithRequestInfo(testRequest.Context(), &helper.RequestInfo{Id: "req-1"}))
+
+	recorder := httptest.NewRecorder()
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpMetricsMiddleware(middleware.NewHttpPanicMiddleware(logger, handler)).ServeHTTP(recorder, testRequest)
+	// ---------------------------
+}
+
+func TestMetricsRequests(t *testing.T) {
+	t.Run("Route Pattern And Status", func(t *testing.T) {
+		// Arrange
+		requests := metrics.HttpRequestsTotal.WithLabelValues("/api/v2/categories/:categoryId", http.MethodPut, "201")
+		before := testutil.ToFloat64(requests)
+
+		// Action
+		serveMetrics(http.MethodPut, &routedHandler{Route: "/api/v2/categories/:categoryId"})
+
+		// Assert
+		assert.Equal(t, before+1, testutil.ToFloat64(requests))
+	})
+
+	t.Run("Unmatched Route And Unknown Method", func(t *testing.T) {
+		// Arrange
+		requests := metrics.HttpRequestsTotal.WithLabelValues("unmatched", "OTHER", "201")
+		before := testutil.ToFloat64(requests)
+
+		// Action
+		serveMetrics("PROPFIND", new(routedHandler))
+
+		// Assert
+		assert.Equal(t, before+1, testutil.ToFloat64(requests))
+	})
+}
+
+func TestMetricsPanicsRecovered(t *testing.T) {
+	tests := []struct {
+		name   string
+		panic  any
+		class  string
+		status string
+	}{
+		{name: "Client Error", panic: exception.NewErrorClientRequest(errors.New("not found"), http.StatusNotFound, "not found"), class: "client", status: "404"},
+		{name: "Internal Error", panic: exception.NewErrorInternalServer(errors.New("db is down"), "category > usecase > FindById"), class: "internal", status: "500"},
+		{name: "Unknown Error", panic: "unexpected", class: "unknown", status: "500"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			panics := metrics.HttpPanicsRecoveredTotal.WithLabelValues(test.class)
+			requests := metrics.HttpRequestsTotal.WithLabelValues("/api/v2/categories/:categoryId", http.MethodGet, test.status)
+
+			panicsBefore := testutil.ToFloat64(panics)
+			requestsBefore := testutil.ToFloat64(requests)
+
+			// Action
+			serveMetrics(http.MethodGet, &routedHandler{Route: "/api/v2/categories/:categoryId", Panic: test.panic})
+
+			// Assert
+			assert.Equal(t, panicsBefore+1, testutil.ToFloat64(panics))
+			assert.Equal(t, requestsBefore+1, testutil.ToFloat64(requests))
+		})
+	}
+}
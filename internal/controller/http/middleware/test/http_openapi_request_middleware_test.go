@@ -0,0 +1,106 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+func TestOpenApiRequestFailed(t *testing.T) {
+	t.Run("Field Name - Chars Min", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "/api/v2/categories", strings.NewReader(`{"name":"F"}`))
+		testRequest.Header.Set("content-type", "application/json")
+
+		recorder := httptest.NewRecorder()
+
+		// Action
+		var errRecover any
+
+		func() {
+			defer func() { errRecover = recover() }()
+
+			// ---SUT (Subject Under Test)
+			middleware.NewHttpOpenApiRequestMiddleware(new(controllerHandler)).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+		}()
+
+		// Assert
+		errValidation, ok := errRecover.(*exception.ErrorValidationRequest)
+
+		if assert.True(t, ok) {
+			assert.Equal(t, "request does not match the API spec", errValidation.GetDetailError())
+			assert.Equal(t, "body", errValidation.Errors[0].Location)
+			assert.Equal(t, "/name", errValidation.Errors[0].Pointer)
+		}
+	})
+
+	t.Run("Field Name - Wrong Type", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPut, "/api/v2/categories/CAT-1", strings.NewReader(`{"name":10}`))
+
+		recorder := httptest.NewRecorder()
+
+		// Action
+		var errRecover any
+
+		func() {
+			defer func() { errRecover = recover() }()
+
+			// ---SUT (Subject Under Test)
+			middleware.NewHttpOpenApiRequestMiddleware(new(controllerHandler)).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+		}()
+
+		// Assert
+		errValidation, ok := errRecover.(*exception.ErrorValidationRequest)
+
+		if assert.True(t, ok) {
+			assert.Equal(t, "body", errValidation.Errors[0].Location)
+			assert.Equal(t, "/name", errValidation.Errors[0].Pointer)
+			assert.Equal(t, "value must be a string", errValidation.Errors[0].Message)
+		}
+	})
+}
+
+func TestOpenApiRequestSuccess(t *testing.T) {
+	t.Run("Valid Request Body", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "/api/v2/categories", strings.NewReader(`{"name":"Fashions"}`))
+
+		recorder := httptest.NewRecorder()
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			middleware.NewHttpOpenApiRequestMiddleware(new(controllerHandler)).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+		})
+
+		responseBodyBytes, err := io.ReadAll(recorder.Result().Body)
+		helper.LogStdPanicIfError(err)
+
+		assert.Equal(t, "response from controllerHandler", string(responseBodyBytes))
+	})
+
+	t.Run("Endpoint Is Not In The Spec", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(`{"query":"{}"}`))
+
+		recorder := httptest.NewRecorder()
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			middleware.NewHttpOpenApiRequestMiddleware(new(controllerHandler)).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+		})
+	})
+}
@@ -0,0 +1,153 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+type jsonHandler struct {
+	StatusCode int
+	Body       string
+}
+
+func (h *jsonHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(h.StatusCode)
+	fmt.Fprint(w, h.Body)
+}
+
+func TestOpenApiResponseFailed(t *testing.T) {
+	t.Run("Response Drifts From The Spec", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories/CAT-1", nil)
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		middleware.NewHttpOpenApiResponseMiddleware(logger, &jsonHandler{
+			StatusCode: http.StatusOK,
+			Body:       `{"code":"200","status":"OK","data":{"id":"CAT-1"}}`,
+		}).ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		recorderResponse := recorder.Result()
+
+		assert.Equal(t, http.StatusInternalServerError, recorderResponse.StatusCode)
+
+		responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+		helper.LogStdPanicIfError(err)
+
+		webResponse := new(model.WebResponseMessage)
+
+		err = json.Unmarshal(responseBodyBytes, webResponse)
+		helper.LogStdPanicIfError(err)
+
+		assert.True(t, strings.HasPrefix(webResponse.Message, "response does not match the API spec"))
+	})
+
+	t.Run("Status Is Not In The Spec", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodDelete, "/api/v2/categories/CAT-1", nil)
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		middleware.NewHttpOpenApiResponseMiddleware(logger, &jsonHandler{
+			StatusCode: http.StatusTeapot,
+			Body:       `{"code":418,"status":"I'M A TEAPOT","message":"teapot"}`,
+		}).ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode)
+	})
+}
+
+func TestOpenApiResponseSuccess(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories/CAT-1", nil)
+
+	recorder := httptest.NewRecorder()
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpOpenApiResponseMiddleware(logger, &jsonHandler{
+		StatusCode: http.StatusOK,
+		Body:       `{"code":200,"status":"OK","data":{"id":"CAT-1","name":"Tools"}}`,
+	}).ServeHTTP(recorder, testRequest)
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+	assert.Equal(t, "application/json", recorderResponse.Header.Get("content-type"))
+
+	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+	helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, `{"code":200,"status":"OK","data":{"id":"CAT-1","name":"Tools"}}`, string(responseBodyBytes))
+}
+
+func TestOpenApiResponseNotModified(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories", nil)
+	testRequest.Header.Set("if-none-match", `"categories-3"`)
+
+	recorder := httptest.NewRecorder()
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpOpenApiResponseMiddleware(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("etag", `"categories-3"`)
+		w.WriteHeader(http.StatusNotModified)
+	})).ServeHTTP(recorder, testRequest)
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusNotModified, recorderResponse.StatusCode)
+	assert.Equal(t, `"categories-3"`, recorderResponse.Header.Get("etag"))
+	assert.Empty(t, recorder.Body.Bytes())
+}
+
+func TestOpenApiResponseStreaming(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories/events", nil)
+
+	recorder := httptest.NewRecorder()
+
+	var flushedBody string
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpOpenApiResponseMiddleware(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		fmt.Fprint(w, "id: 1\n\n")
+
+		err := http.NewResponseController(w).Flush()
+		helper.LogStdPanicIfError(err)
+
+		flushedBody = recorder.Body.String()
+
+		fmt.Fprint(w, "id: 2\n\n")
+	})).ServeHTTP(recorder, testRequest)
+	// ---------------------------
+
+	// Assert
+	assert.True(t, recorder.Flushed)
+	assert.Equal(t, "id: 1\n\n", flushedBody, "the response is passed through once it is flushed")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("content-type"))
+	assert.Equal(t, "id: 1\n\nid: 2\n\n", recorder.Body.String())
+}
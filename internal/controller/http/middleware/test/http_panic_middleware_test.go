@@ -38,7 +38,7 @@ func Test400Handler(t *testing.T) {
 		// ---SUT (Subject Under Test)
 		middleware.NewHttpPanicMiddleware(logger, &panicHandler{
 			Error: errorValidation,
-		}).ServeHTTP(recorder, nil)
+		}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
 		// ---------------------------
 
 		recorderResponse := recorder.Result()
@@ -66,7 +66,7 @@ func Test400Handler(t *testing.T) {
 		// ---SUT (Subject Under Test)
 		middleware.NewHttpPanicMiddleware(logger, &panicHandler{
 			Error: errorOther400,
-		}).ServeHTTP(recorder, nil)
+		}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
 		// ---------------------------
 
 		recorderResponse := recorder.Result()
@@ -96,7 +96,7 @@ func Test401Handler(t *testing.T) {
 	// ---SUT (Subject Under Test)
 	middleware.NewHttpPanicMiddleware(logger, &panicHandler{
 		Error: error401,
-	}).ServeHTTP(recorder, nil)
+	}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
 	// ---------------------------
 
 	recorderResponse := recorder.Result()
@@ -125,7 +125,7 @@ func Test404Handler(t *testing.T) {
 	// ---SUT (Subject Under Test)
 	middleware.NewHttpPanicMiddleware(logger, &panicHandler{
 		Error: error404,
-	}).ServeHTTP(recorder, nil)
+	}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
 	// ---------------------------
 
 	recorderResponse := recorder.Result()
@@ -154,7 +154,7 @@ func Test500Handler(t *testing.T) {
 	// ---SUT (Subject Under Test)
 	middleware.NewHttpPanicMiddleware(logger, &panicHandler{
 		Error: error500,
-	}).ServeHTTP(recorder, nil)
+	}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
 	// ---------------------------
 
 	recorderResponse := recorder.Result()
@@ -174,3 +174,39 @@ func Test500Handler(t *testing.T) {
 	assert.Equal(t, "INTERNAL SERVER ERROR", webResponse.Status)
 	assert.Equal(t, "something went wrong", webResponse.Message)
 }
+
+func TestValidationRequestHandler(t *testing.T) {
+	errorValidation := exception.NewErrorValidationRequest(errors.New("request does not match the API spec"), "request does not match the API spec", []exception.ErrorPointer{
+		{Location: "body", Pointer: "/name", Message: "minimum string length is 3"},
+	})
+
+	recorder := httptest.NewRecorder()
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpPanicMiddleware(logger, &panicHandler{
+		Error: errorValidation,
+	}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	// ---------------------------
+
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, "application/json", recorderResponse.Header.Get("content-type"))
+	assert.Equal(t, http.StatusBadRequest, recorderResponse.StatusCode)
+
+	requestBodyBytes, err := io.ReadAll(recorderResponse.Body)
+	helper.LogStdPanicIfError(err)
+
+	webResponse := new(model.WebResponseErrors)
+
+	err = json.Unmarshal(requestBodyBytes, webResponse)
+	helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, &model.WebResponseErrors{
+		Code:    http.StatusBadRequest,
+		Status:  "BAD REQUEST",
+		Message: "request does not match the API spec",
+		Errors: []model.ErrorDetail{
+			{Location: "body", Pointer: "/name", Message: "minimum string length is 3"},
+		},
+	}, webResponse)
+}
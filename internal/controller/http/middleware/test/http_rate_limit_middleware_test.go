@@ -0,0 +1,193 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logrus_test "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/ratelimit"
+	internal_security_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/security/mock"
+)
+
+var rateLimitTestConfig = &config.AppConfig{
+	Server: &config.Server{
+		ApiKey: "test_key",
+	},
+	RateLimit: &config.RateLimit{
+		Enabled: true,
+		Groups: []config.RateLimitGroup{
+			{Name: "api", Prefix: "/api", Requests: 10, Period: 60},
+			{Name: "categories", Prefix: "/api/v2/categories", Requests: 2, Period: 60},
+		},
+	},
+}
+
+type failingLimiter struct{}
+
+func (l *failingLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Result, error) {
+	return nil, errors.New("redis is down")
+}
+
+// setupRateLimitMiddleware builds the middleware in its place of the chain,
+// behind auth and inside the request ID and panic middlewares
+func setupRateLimitMiddleware(limiter ratelimit.Limiter, withAuth bool) http.Handler {
+	hookLogger, _ := logrus_test.NewNullLogger()
+
+	var handler http.Handler = middleware.NewHttpRateLimitMiddleware(rateLimitTestConfig, hookLogger, limiter, new(controllerHandler))
+
+	if withAuth {
+		handler = middleware.NewHttpAuthMiddleware(appTestConfig, newApiKeyUseCase("test_key"), nil, handler)
+	}
+
+	idGen := internal_security_mock.NewIdGenMock()
+
+	idGen.Mock.On("Generate", 21).Return("GENERATED-ID", nil).Maybe()
+
+	return middleware.NewHttpRequestIdMiddleware(idGen, middleware.NewHttpPanicMiddleware(hookLogger, handler))
+}
+
+func newRateLimitRequest(path string, remoteAddr string) *http.Request {
+	testRequest := httptest.NewRequest(http.MethodGet, path, nil)
+	testRequest.Header.Set("x-api-key", "test_key")
+	testRequest.RemoteAddr = remoteAddr
+
+	return testRequest
+}
+
+func TestRateLimitExceeded(t *testing.T) {
+	// Arrange
+	handler := setupRateLimitMiddleware(ratelimit.NewMemoryLimiterImpl(), true)
+
+	recorders := []*httptest.ResponseRecorder{}
+
+	// Action
+	for range 3 {
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		handler.ServeHTTP(recorder, newRateLimitRequest("/api/v2/categories", "203.0.113.7:1"))
+		// ---------------------------
+
+		recorders = append(recorders, recorder)
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorders[0].Code)
+	assert.Equal(t, "2;w=60", recorders[0].Header().Get("ratelimit-policy"))
+	assert.Equal(t, "2", recorders[0].Header().Get("ratelimit-limit"))
+	assert.Equal(t, "1", recorders[0].Header().Get("ratelimit-remaining"))
+
+	assert.Equal(t, http.StatusOK, recorders[1].Code)
+	assert.Equal(t, "0", recorders[1].Header().Get("ratelimit-remaining"))
+
+	recorderResponse := recorders[2].Result()
+
+	assert.Equal(t, http.StatusTooManyRequests, recorderResponse.StatusCode)
+	assert.Equal(t, "30", recorderResponse.Header.Get("retry-after"))
+	assert.Equal(t, "60", recorderResponse.Header.Get("ratelimit-reset"))
+
+	webResponse := new(model.WebResponseMessage)
+
+	err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+	helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, &model.WebResponseMessage{
+		Code:    http.StatusTooManyRequests,
+		Status:  "TOO MANY REQUESTS",
+		Message: "too many requests, retry after 30 seconds",
+	}, webResponse)
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	t.Run("API Key Is Shared Across Client IPs", func(t *testing.T) {
+		// Arrange
+		handler := setupRateLimitMiddleware(ratelimit.NewMemoryLimiterImpl(), true)
+
+		for _, remoteAddr := range []string{"203.0.113.7:1", "203.0.113.8:1"} {
+			handler.ServeHTTP(httptest.NewRecorder(), newRateLimitRequest("/api/v2/categories", remoteAddr))
+		}
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		handler.ServeHTTP(recorder, newRateLimitRequest("/api/v2/categories", "203.0.113.9:1"))
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	})
+
+	t.Run("Client IP Without API Key", func(t *testing.T) {
+		// Arrange
+		handler := setupRateLimitMiddleware(ratelimit.NewMemoryLimiterImpl(), false)
+
+		for range 2 {
+			handler.ServeHTTP(httptest.NewRecorder(), newRateLimitRequest("/api/v2/categories", "203.0.113.7:1"))
+		}
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		handler.ServeHTTP(recorder, newRateLimitRequest("/api/v2/categories", "203.0.113.8:1"))
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Route Groups Are Separated", func(t *testing.T) {
+		// Arrange
+		handler := setupRateLimitMiddleware(ratelimit.NewMemoryLimiterImpl(), true)
+
+		for range 3 {
+			handler.ServeHTTP(httptest.NewRecorder(), newRateLimitRequest("/api/v2/categories", "203.0.113.7:1"))
+		}
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		handler.ServeHTTP(recorder, newRateLimitRequest("/api/graphql", "203.0.113.7:1"))
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "10", recorder.Header().Get("ratelimit-limit"))
+	})
+}
+
+func TestRateLimitPassThrough(t *testing.T) {
+	t.Run("Route Without Group", func(t *testing.T) {
+		// Arrange
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		setupRateLimitMiddleware(ratelimit.NewMemoryLimiterImpl(), true).ServeHTTP(recorder, newRateLimitRequest("/healthz", "203.0.113.7:1"))
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("ratelimit-limit"))
+	})
+
+	t.Run("Backend Is Unavailable", func(t *testing.T) {
+		// Arrange
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		setupRateLimitMiddleware(new(failingLimiter), true).ServeHTTP(recorder, newRateLimitRequest("/api/v2/categories", "203.0.113.7:1"))
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "response from controllerHandler", recorder.Body.String())
+	})
+}
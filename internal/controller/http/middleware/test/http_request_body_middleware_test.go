@@ -0,0 +1,111 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+var requestBodyTestConfig = &config.AppConfig{
+	Request: &config.Request{
+		MaxBodySize: 32,
+	},
+}
+
+// The body middleware is tested in front of the OpenAPI request validation,
+// which reads the body before the controllers
+func setupRequestBodyMiddleware() http.Handler {
+	return middleware.NewHttpPanicMiddleware(
+		logger,
+		middleware.NewHttpRequestBodyMiddleware(
+			requestBodyTestConfig,
+			middleware.NewHttpOpenApiRequestMiddleware(new(controllerHandler)),
+		),
+	)
+}
+
+func TestRequestBodySuccess(t *testing.T) {
+	t.Run("JSON Body", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "/api/v2/categories", strings.NewReader(`{"name":"Gadget"}`))
+		testRequest.Header.Set("content-type", "application/json")
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		setupRequestBodyMiddleware().ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "response from controllerHandler", recorder.Body.String())
+	})
+
+	t.Run("Without Body", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories", nil)
+		testRequest.Header.Set("content-type", "text/plain")
+
+		recorder := httptest.NewRecorder()
+
+		// ---SUT (Subject Under Test)
+		setupRequestBodyMiddleware().ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func TestRequestBodyFailed(t *testing.T) {
+	tests := []struct {
+		name          string
+		contentType   string
+		contentLength int64
+		statusCode    int
+		message       string
+	}{
+		{name: "Unsupported Content Type", contentType: "text/plain", statusCode: http.StatusUnsupportedMediaType, message: "content type must be application/json"},
+		{name: "Too Large Content Length", contentType: "application/json", statusCode: http.StatusRequestEntityTooLarge, message: "request body must not be larger than 32 bytes"},
+		{name: "Too Large Chunked Body", contentType: "application/json", contentLength: -1, statusCode: http.StatusRequestEntityTooLarge, message: "request body must not be larger than 32 bytes"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodPost, "/api/v2/categories", strings.NewReader(`{"name":"`+strings.Repeat("a", 64)+`"}`))
+			testRequest.Header.Set("content-type", test.contentType)
+
+			if test.contentLength != 0 {
+				testRequest.ContentLength = test.contentLength
+			}
+
+			recorder := httptest.NewRecorder()
+
+			// ---SUT (Subject Under Test)
+			setupRequestBodyMiddleware().ServeHTTP(recorder, testRequest)
+			// ---------------------------
+
+			// Assert
+			recorderResponse := recorder.Result()
+
+			assert.Equal(t, test.statusCode, recorderResponse.StatusCode)
+
+			webResponse := new(model.WebResponseMessage)
+
+			err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+			helper.LogStdPanicIfError(err)
+
+			assert.Equal(t, test.statusCode, webResponse.Code)
+			assert.Equal(t, test.message, webResponse.Message)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logrus_test "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	internal_security_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/security/mock"
+)
+
+func TestRequestIdMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestId string
+		expected  string
+	}{
+		{name: "Accept Incoming ID", requestId: "a1b2-c3d4", expected: "a1b2-c3d4"},
+		{name: "Generate Missing ID", requestId: "", expected: "GENERATED-ID"},
+		{name: "Replace Invalid ID", requestId: "bad id\nwith newline", expected: "GENERATED-ID"},
+		{name: "Replace Too Long ID", requestId: strings.Repeat("a", 64), expected: "GENERATED-ID"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories", nil)
+
+			if test.requestId != "" {
+				testRequest.Header.Set("x-request-id", test.requestId)
+			}
+
+			idGen := internal_security_mock.NewIdGenMock()
+
+			idGen.Mock.On("Generate", 21).Return("GENERATED-ID", nil).Maybe()
+
+			recorder := httptest.NewRecorder()
+
+			var requestInfo *helper.RequestInfo
+
+			// ---SUT (Subject Under Test)
+			middleware.NewHttpRequestIdMiddleware(idGen, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestInfo = helper.RequestInfoFromContext(r.Context())
+			})).ServeHTTP(recorder, testRequest)
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, test.expected, recorder.Result().Header.Get("x-request-id"))
+			assert.Equal(t, &helper.RequestInfo{
+				Id:     test.expected,
+				Method: http.MethodGet,
+				Path:   "/api/v2/categories",
+			}, requestInfo)
+		})
+	}
+}
+
+func TestRequestIdInLogs(t *testing.T) {
+	// Arrange
+	hookLogger, hook := logrus_test.NewNullLogger()
+	hookLogger.SetLevel(logrus.ErrorLevel)
+
+	testRequest := httptest.NewRequest(http.MethodPost, "/api/v2/categories", nil)
+	testRequest.Header.Set("x-request-id", "req-42")
+	testRequest.Header.Set("x-api-key", "test_key")
+
+	recorder := httptest.NewRecorder()
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpRequestIdMiddleware(
+		internal_security_mock.NewIdGenMock(),
+		middleware.NewHttpPanicMiddleware(
+			hookLogger,
+			middleware.NewHttpAuthMiddleware(appTestConfig, newApiKeyUseCase("test_key"), nil, &panicHandler{
+				Error: exception.NewErrorInternalServer(errors.New("db is down"), "category > usecase > Create"),
+			}),
+		),
+	).ServeHTTP(recorder, testRequest)
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode)
+	assert.Equal(t, "req-42", recorder.Result().Header.Get("x-request-id"))
+
+	if assert.Len(t, hook.AllEntries(), 1) {
+		entry := hook.LastEntry()
+
+		assert.Equal(t, "req-42", entry.Data["request_id"])
+		assert.Equal(t, http.MethodPost, entry.Data["method"])
+		assert.Equal(t, "/api/v2/categories", entry.Data["path"])
+		assert.Equal(t, helper.ApiKeyIdentity("test_key"), entry.Data["api_key"])
+		assert.Equal(t, "category > usecase > Create", entry.Data["detail_error"])
+		assert.NotContains(t, entry.Data["api_key"], "test_key")
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func serveTracing(testRequest *http.Request, handler http.Handler) tracetest.SpanStubs {
+	exporter := tracetest.NewInMemoryExporter()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	testRequest = testRequest.WithContext(helper.ContextWithRequestInfo(testRequest.Context(), &helper.RequestInfo{Id: "req-1"}))
+
+	// ---SUT (Subject Under Test)
+	middleware.NewHttpTracingMiddleware(middleware.NewHttpPanicMiddleware(logger, handler)).ServeHTTP(httptest.NewRecorder(), testRequest)
+	// ---------------------------
+
+	return exporter.GetSpans()
+}
+
+func TestTracingServerSpan(t *testing.T) {
+	t.Run("Continues Traceparent", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPut, "/api/v2/categories/CAT-1", nil)
+		testRequest.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		// Action
+		spans := serveTracing(testRequest, &routedHandler{Route: "/api/v2/categories/:categoryId"})
+
+		// Assert
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, "PUT /api/v2/categories/:categoryId", spans[0].Name)
+			assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind)
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+			assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+			assert.Contains(t, spans[0].Attributes, semconv.HTTPResponseStatusCode(http.StatusCreated))
+			assert.Equal(t, codes.Unset, spans[0].Status.Code)
+		}
+	})
+
+	t.Run("New Trace Without Traceparent", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+
+		// Action
+		spans := serveTracing(testRequest, new(routedHandler))
+
+		// Assert
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, "GET", spans[0].Name)
+			assert.False(t, spans[0].Parent.IsValid())
+		}
+	})
+
+	t.Run("Internal Server Error", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories", nil)
+
+		// Action
+		spans := serveTracing(testRequest, &routedHandler{
+			Route: "/api/v2/categories",
+			Panic: exception.NewErrorInternalServer(errors.New("connection refused"), "category > usecase > FindAll"),
+		})
+
+		// Assert
+		if assert.Len(t, spans, 1) {
+			assert.Contains(t, spans[0].Attributes, semconv.HTTPResponseStatusCode(http.StatusInternalServerError))
+			assert.Equal(t, codes.Error, spans[0].Status.Code)
+		}
+	})
+}
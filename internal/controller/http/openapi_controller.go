@@ -0,0 +1,12 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type OpenApiController interface {
+	Spec(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	Docs(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+}
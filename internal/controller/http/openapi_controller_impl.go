@@ -0,0 +1,64 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/api"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+// swaggerUiOrigin is the pinned release the docs load Swagger UI from, a
+// published npm version never changes
+const swaggerUiOrigin = "https://unpkg.com/swagger-ui-dist@5.17.14/"
+
+var inlineScriptPattern = regexp.MustCompile(`(?s)<script>(.*?)</script>`)
+
+type openApiControllerImpl struct {
+	DocsContentSecurityPolicy string
+}
+
+func NewOpenApiControllerImpl() OpenApiController {
+	return &openApiControllerImpl{
+		DocsContentSecurityPolicy: docsContentSecurityPolicy(api.Docs),
+	}
+}
+
+func (c *openApiControllerImpl) Spec(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write(api.Spec)
+	helper.InternalServerPanicIfError(err, "openapi > http/controller > Spec")
+}
+
+func (c *openApiControllerImpl) Docs(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	w.Header().Set("content-type", "text/html; charset=utf-8")
+	w.Header().Set("content-security-policy", c.DocsContentSecurityPolicy)
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write(api.Docs)
+	helper.InternalServerPanicIfError(err, "openapi > http/controller > Docs")
+}
+
+// docsContentSecurityPolicy lets the docs page run the scripts of the pinned
+// Swagger UI release and its own inline script only, by the hash of it, so no
+// other script runs on the origin of the API
+func docsContentSecurityPolicy(docs []byte) string {
+	scriptSources := swaggerUiOrigin
+
+	for _, match := range inlineScriptPattern.FindAllSubmatch(docs, -1) {
+		hash := sha256.Sum256(match[1])
+		scriptSources += fmt.Sprintf(" 'sha256-%s'", base64.StdEncoding.EncodeToString(hash[:]))
+	}
+
+	return fmt.Sprintf(
+		"default-src 'none'; script-src %s; style-src %s 'unsafe-inline'; img-src 'self' data:; connect-src 'self'",
+		scriptSources,
+		swaggerUiOrigin,
+	)
+}
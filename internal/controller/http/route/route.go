@@ -1,5 +1,15 @@
 package route
 
+import "github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+
 type RouteConfig interface {
 	Setup()
+	// Routes answers the routes registered by Setup with their auth policies
+	Routes() []Route
+}
+
+type Route struct {
+	Method string
+	Path   string
+	Policy security.AuthPolicy
 }
@@ -4,31 +4,135 @@ import (
 	go_http "net/http"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/graphql"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
 )
 
 type RouteConfigHttpRouter struct {
-	Router             *httprouter.Router
-	CategoryController http.CategoryController
+	AppConfig                      *config.AppConfig
+	Router                         *httprouter.Router
+	CategoryController             http.CategoryController
+	CategoryEventController        http.CategoryEventController
+	CategorySubscriptionController http.CategorySubscriptionController
+	OpenApiController              http.OpenApiController
+	GraphqlController              graphql.GraphqlController
+	HealthController               http.HealthController
+	WebhookController              http.WebhookController
+	ApiKeyController               http.ApiKeyController
+	TenantController               http.TenantController
+	routes                         []Route
 }
 
-func NewRouteConfigHttpRouter(router *httprouter.Router, categoryController http.CategoryController) RouteConfig {
+func NewRouteConfigHttpRouter(appConfig *config.AppConfig, router *httprouter.Router, categoryController http.CategoryController, categoryEventController http.CategoryEventController, categorySubscriptionController http.CategorySubscriptionController, openApiController http.OpenApiController, graphqlController graphql.GraphqlController, healthController http.HealthController, webhookController http.WebhookController, apiKeyController http.ApiKeyController, tenantController http.TenantController) RouteConfig {
 	return &RouteConfigHttpRouter{
-		Router:             router,
-		CategoryController: categoryController,
+		AppConfig:                      appConfig,
+		Router:                         router,
+		CategoryController:             categoryController,
+		CategoryEventController:        categoryEventController,
+		CategorySubscriptionController: categorySubscriptionController,
+		OpenApiController:              openApiController,
+		GraphqlController:              graphqlController,
+		HealthController:               healthController,
+		WebhookController:              webhookController,
+		ApiKeyController:               apiKeyController,
+		TenantController:               tenantController,
 	}
 }
 
 func (r *RouteConfigHttpRouter) Setup() {
+	categoryReadPolicy := security.ScopePolicy(security.ScopeCategoriesRead)
+
+	if tenantId := r.AppConfig.Server.PublicTenant; tenantId != "" {
+		categoryReadPolicy = security.PublicTenantPolicy(tenantId)
+	}
+
+	adminPolicy := security.ScopePolicy(security.ScopeAdmin)
+
 	// Category Endpoints
-	r.Router.GET("/api/v2/categories", r.CategoryController.FindAll)
-	r.Router.GET("/api/v2/categories/:categoryId", r.CategoryController.FindById)
-	r.Router.POST("/api/v2/categories", r.CategoryController.Create)
-	r.Router.PUT("/api/v2/categories/:categoryId", r.CategoryController.Update)
-	r.Router.DELETE("/api/v2/categories/:categoryId", r.CategoryController.Delete)
+	r.handle(go_http.MethodGet, "/api/v2/categories", categoryReadPolicy, r.CategoryController.FindAll)
+	r.handle(go_http.MethodGet, "/api/v2/categories/:categoryId", categoryReadPolicy, r.findCategoryByIdOrStreamEvents)
+	r.handle(go_http.MethodPost, "/api/v2/categories", security.ScopePolicy(security.ScopeCategoriesWrite), r.CategoryController.Create)
+	r.handle(go_http.MethodPut, "/api/v2/categories/:categoryId", security.ScopePolicy(security.ScopeCategoriesWrite), r.CategoryController.Update)
+	r.handle(go_http.MethodDelete, "/api/v2/categories/:categoryId", security.ScopePolicy(security.ScopeCategoriesWrite), r.CategoryController.Delete)
+
+	// WebSocket Endpoint, the browsers cannot set the X-API-Key header on a
+	// WebSocket, the controller authenticates the client and checks its scope
+	r.handle(go_http.MethodGet, "/api/v2/ws", security.PublicPolicy(), r.CategorySubscriptionController.Serve)
+
+	// Webhook Endpoints
+	r.handle(go_http.MethodGet, "/api/v2/webhooks", adminPolicy, r.WebhookController.FindAll)
+	r.handle(go_http.MethodGet, "/api/v2/webhooks/:webhookId", adminPolicy, r.WebhookController.FindById)
+	r.handle(go_http.MethodPost, "/api/v2/webhooks", adminPolicy, r.WebhookController.Create)
+	r.handle(go_http.MethodDelete, "/api/v2/webhooks/:webhookId", adminPolicy, r.WebhookController.Delete)
+	r.handle(go_http.MethodGet, "/api/v2/webhooks/:webhookId/deliveries", adminPolicy, r.WebhookController.FindDeliveries)
+	r.handle(go_http.MethodPost, "/api/v2/webhooks/:webhookId/deliveries/:deliveryId/redeliver", adminPolicy, r.WebhookController.Redeliver)
+
+	// API Key Endpoints
+	r.handle(go_http.MethodGet, "/api/v2/api-keys", adminPolicy, r.ApiKeyController.FindAll)
+	r.handle(go_http.MethodPost, "/api/v2/api-keys", adminPolicy, r.ApiKeyController.Create)
+	r.handle(go_http.MethodPost, "/api/v2/api-keys/:apiKeyId/rotate", adminPolicy, r.ApiKeyController.Rotate)
+	r.handle(go_http.MethodDelete, "/api/v2/api-keys/:apiKeyId", adminPolicy, r.ApiKeyController.Revoke)
+
+	// Tenant Endpoints, the use case allows the default tenant only
+	r.handle(go_http.MethodGet, "/api/v2/tenants", adminPolicy, r.TenantController.FindAll)
+	r.handle(go_http.MethodGet, "/api/v2/tenants/:tenantId", adminPolicy, r.TenantController.FindById)
+	r.handle(go_http.MethodPost, "/api/v2/tenants", adminPolicy, r.TenantController.Create)
+
+	// OpenAPI Endpoints
+	r.handle(go_http.MethodGet, "/api/v2/openapi.json", security.PublicPolicy(), r.OpenApiController.Spec)
+	r.handle(go_http.MethodGet, "/api/v2/docs", security.PublicPolicy(), r.OpenApiController.Docs)
+
+	// GraphQL Endpoint, the mutations need the categories:write scope too
+	r.handle(go_http.MethodGet, "/api/graphql", security.ScopePolicy(security.ScopeCategoriesRead), r.GraphqlController.Serve)
+	r.handle(go_http.MethodPost, "/api/graphql", security.ScopePolicy(security.ScopeCategoriesRead), r.GraphqlController.Serve)
+
+	// Health Endpoints, the probes of the load balancer carry no credentials
+	r.handle(go_http.MethodGet, "/healthz", security.PublicPolicy(), r.HealthController.Healthz)
+	r.handle(go_http.MethodGet, "/readyz", security.PublicPolicy(), r.HealthController.Readyz)
 
 	// Panic Endpoint
 	r.Router.PanicHandler = func(w go_http.ResponseWriter, r *go_http.Request, err any) {
 		panic(err)
 	}
 }
+
+// findCategoryByIdOrStreamEvents serves /api/v2/categories/events, httprouter
+// does not register a static segment beside the :categoryId wildcard
+func (r *RouteConfigHttpRouter) findCategoryByIdOrStreamEvents(w go_http.ResponseWriter, req *go_http.Request, params httprouter.Params) {
+	if params.ByName("categoryId") != "events" {
+		r.CategoryController.FindById(w, req, params)
+		return
+	}
+
+	if requestInfo := helper.RequestInfoFromContext(req.Context()); requestInfo != nil {
+		requestInfo.Route = "/api/v2/categories/events"
+	}
+
+	r.CategoryEventController.Stream(w, req, params)
+}
+
+func (r *RouteConfigHttpRouter) Routes() []Route {
+	return r.routes
+}
+
+// handle registers the handle and records its route pattern for the access
+// log, httprouter does not expose the matched pattern itself. The handle is
+// called only for the requests which satisfy the auth policy of the route.
+func (r *RouteConfigHttpRouter) handle(method string, path string, policy security.AuthPolicy, handle httprouter.Handle) {
+	r.routes = append(r.routes, Route{Method: method, Path: path, Policy: policy})
+
+	r.Router.Handle(method, path, func(w go_http.ResponseWriter, req *go_http.Request, params httprouter.Params) {
+		if requestInfo := helper.RequestInfoFromContext(req.Context()); requestInfo != nil {
+			requestInfo.Route = path
+		}
+
+		if ctx := security.Authorize(req.Context(), policy); ctx != req.Context() {
+			req = req.WithContext(ctx)
+		}
+
+		handle(w, req, params)
+	})
+}
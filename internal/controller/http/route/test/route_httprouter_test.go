@@ -0,0 +1,223 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/graphql"
+	internal_controller_http "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/route"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
+)
+
+func setupAppTestConfig(publicTenant string) *config.AppConfig {
+	return &config.AppConfig{
+		Server: &config.Server{
+			PublicTenant: publicTenant,
+		},
+		Request: &config.Request{
+			MaxBodySize: 64,
+		},
+		HttpCache: &config.HttpCache{
+			CacheControl: "private, no-cache",
+		},
+	}
+}
+
+// setupRouteConfig registers the routes with the controllers over the mocks
+// of the use cases, the tests call the category and health use cases only
+func setupRouteConfig(appConfig *config.AppConfig, categoryUseCase usecase.CategoryUseCase) (route.RouteConfig, *httprouter.Router) {
+	router := httprouter.New()
+
+	routeConfig := route.NewRouteConfigHttpRouter(
+		appConfig,
+		router,
+		internal_controller_http.NewCategoryControllerImpl(appConfig, categoryUseCase),
+		internal_controller_http.NewCategoryEventControllerImpl(appConfig, nil),
+		internal_controller_http.NewCategorySubscriptionControllerImpl(appConfig, nil, internal_usecase_mock.NewApiKeyUseCaseMock()),
+		internal_controller_http.NewOpenApiControllerImpl(),
+		graphql.NewGraphqlControllerImpl(appConfig, logrus.New(), categoryUseCase),
+		internal_controller_http.NewHealthControllerImpl(internal_usecase_mock.NewHealthUseCaseMock()),
+		internal_controller_http.NewWebhookControllerImpl(appConfig, internal_usecase_mock.NewWebhookUseCaseMock()),
+		internal_controller_http.NewApiKeyControllerImpl(appConfig, internal_usecase_mock.NewApiKeyUseCaseMock()),
+		internal_controller_http.NewTenantControllerImpl(appConfig, internal_usecase_mock.NewTenantUseCaseMock()),
+	)
+
+	routeConfig.Setup()
+
+	return routeConfig, router
+}
+
+func TestRoutePolicies(t *testing.T) {
+	readPolicy := security.ScopePolicy(security.ScopeCategoriesRead)
+	writePolicy := security.ScopePolicy(security.ScopeCategoriesWrite)
+	adminPolicy := security.ScopePolicy(security.ScopeAdmin)
+
+	// Every route is listed, so a new route declares its policy on purpose
+	expectedPolicies := map[string]security.AuthPolicy{
+		"GET /api/v2/categories":                                            readPolicy,
+		"GET /api/v2/categories/:categoryId":                                readPolicy,
+		"POST /api/v2/categories":                                           writePolicy,
+		"PUT /api/v2/categories/:categoryId":                                writePolicy,
+		"DELETE /api/v2/categories/:categoryId":                             writePolicy,
+		"GET /api/v2/ws":                                                    security.PublicPolicy(),
+		"GET /api/v2/webhooks":                                              adminPolicy,
+		"GET /api/v2/webhooks/:webhookId":                                   adminPolicy,
+		"POST /api/v2/webhooks":                                             adminPolicy,
+		"DELETE /api/v2/webhooks/:webhookId":                                adminPolicy,
+		"GET /api/v2/webhooks/:webhookId/deliveries":                        adminPolicy,
+		"POST /api/v2/webhooks/:webhookId/deliveries/:deliveryId/redeliver": adminPolicy,
+		"GET /api/v2/api-keys":                                              adminPolicy,
+		"POST /api/v2/api-keys":                                             adminPolicy,
+		"POST /api/v2/api-keys/:apiKeyId/rotate":                            adminPolicy,
+		"DELETE /api/v2/api-keys/:apiKeyId":                                 adminPolicy,
+		"GET /api/v2/tenants":                                               adminPolicy,
+		"GET /api/v2/tenants/:tenantId":                                     adminPolicy,
+		"POST /api/v2/tenants":                                              adminPolicy,
+		"GET /api/v2/openapi.json":                                          security.PublicPolicy(),
+		"GET /api/v2/docs":                                                  security.PublicPolicy(),
+		"GET /api/graphql":                                                  readPolicy,
+		"POST /api/graphql":                                                 readPolicy,
+		"GET /healthz":                                                      security.PublicPolicy(),
+		"GET /readyz":                                                       security.PublicPolicy(),
+	}
+
+	// Arrange
+	routeConfig, _ := setupRouteConfig(setupAppTestConfig(""), internal_usecase_mock.NewCategoryUseCaseMock())
+
+	// Action
+	// ---SUT (Subject Under Test)
+	routes := routeConfig.Routes()
+	// ---------------------------
+
+	// Assert
+	assert.Len(t, routes, len(expectedPolicies))
+
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+
+		if assert.Contains(t, expectedPolicies, key) {
+			assert.Equal(t, expectedPolicies[key], route.Policy, key)
+		}
+	}
+}
+
+func TestPublicCategoryReads(t *testing.T) {
+	// Arrange
+	routeConfig, _ := setupRouteConfig(setupAppTestConfig("TENANT-2"), internal_usecase_mock.NewCategoryUseCaseMock())
+
+	// Action
+	// ---SUT (Subject Under Test)
+	routes := routeConfig.Routes()
+	// ---------------------------
+
+	// Assert
+	for _, route := range routes {
+		switch {
+		case route.Method == http.MethodGet && (route.Path == "/api/v2/categories" || route.Path == "/api/v2/categories/:categoryId"):
+			assert.Equal(t, security.PublicTenantPolicy("TENANT-2"), route.Policy, route.Path)
+		case route.Path == "/api/v2/categories" || route.Path == "/api/v2/categories/:categoryId":
+			assert.Equal(t, security.ScopePolicy(security.ScopeCategoriesWrite), route.Policy, route.Path)
+		}
+	}
+}
+
+func TestRouteAuthorizationFailed(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		principal  *security.Principal
+		statusCode int
+	}{
+		{name: "Without Credentials", method: http.MethodGet, path: "/api/v2/categories/CAT-1", principal: nil, statusCode: http.StatusUnauthorized},
+		{name: "Without Scope", method: http.MethodPost, path: "/api/v2/webhooks", principal: &security.Principal{Id: "KEY-1", TenantId: "TENANT-1", Scopes: []string{security.ScopeCategoriesRead}, AuthMode: security.AuthModeApiKey}, statusCode: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+			_, router := setupRouteConfig(setupAppTestConfig(""), categoryUseCase)
+
+			testRequest := httptest.NewRequest(test.method, test.path, nil)
+
+			if test.principal != nil {
+				testRequest = testRequest.WithContext(security.ContextWithPrincipal(testRequest.Context(), test.principal))
+			}
+
+			// Action & Assert
+			defer func() {
+				errRecover := recover()
+
+				if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+					assert.Equal(t, test.statusCode, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+				}
+
+				categoryUseCase.Mock.AssertNotCalled(t, "FindVersion", mock.Anything)
+			}()
+
+			// ---SUT (Subject Under Test)
+			router.ServeHTTP(httptest.NewRecorder(), testRequest)
+			// ---------------------------
+		})
+	}
+}
+
+func TestRouteAuthorizationSuccess(t *testing.T) {
+	t.Run("Public Route Without Credentials", func(t *testing.T) {
+		// Arrange
+		_, router := setupRouteConfig(setupAppTestConfig(""), internal_usecase_mock.NewCategoryUseCaseMock())
+
+		testRequest := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		recorder := httptest.NewRecorder()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		router.ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Anonymous Category Read Of The Public Tenant", func(t *testing.T) {
+		// Arrange
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		publicTenant := mock.MatchedBy(func(ctx context.Context) bool {
+			return security.RequireTenant(ctx) == "TENANT-2"
+		})
+
+		categoryUseCase.Mock.On("FindVersion", publicTenant).Return(&model.CategoryVersionResponse{Version: 1, UpdatedAt: time.Now()})
+		categoryUseCase.Mock.On("FindById", publicTenant, "CAT-1").Return(&model.CategoryResponse{Id: "CAT-1", Name: "Category 1"})
+
+		_, router := setupRouteConfig(setupAppTestConfig("TENANT-2"), categoryUseCase)
+
+		testRequest := httptest.NewRequest(http.MethodGet, "/api/v2/categories/CAT-1", nil)
+		recorder := httptest.NewRecorder()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		router.ServeHTTP(recorder, testRequest)
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		categoryUseCase.Mock.AssertExpectations(t)
+	})
+}
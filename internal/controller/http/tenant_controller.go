@@ -0,0 +1,13 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type TenantController interface {
+	Create(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	FindById(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	FindAll(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+}
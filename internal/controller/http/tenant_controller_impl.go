@@ -0,0 +1,82 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type tenantControllerImpl struct {
+	AppConfig *config.AppConfig
+	UseCase   usecase.TenantUseCase
+}
+
+func NewTenantControllerImpl(appConfig *config.AppConfig, useCase usecase.TenantUseCase) TenantController {
+	return &tenantControllerImpl{
+		AppConfig: appConfig,
+		UseCase:   useCase,
+	}
+}
+
+func (c *tenantControllerImpl) Create(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	tenantCreateRequest := new(model.CreateTenantRequest)
+
+	err := helper.ReadFromRequestBody(w, r, tenantCreateRequest, helper.RequestBodyOptions{
+		MaxBodySize:           c.AppConfig.Request.MaxBodySize,
+		DisallowUnknownFields: c.AppConfig.Request.DisallowUnknownFields,
+	})
+	helper.PanicIfError(err)
+
+	tenantResponse := c.UseCase.Create(r.Context(), tenantCreateRequest)
+
+	webResponse := &model.WebResponse[*model.TenantResponse]{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   tenantResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "tenant > http/controller > Create")
+}
+
+func (c *tenantControllerImpl) FindById(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	tenantId := params.ByName("tenantId")
+
+	tenantResponse := c.UseCase.FindById(r.Context(), tenantId)
+
+	webResponse := &model.WebResponse[*model.TenantResponse]{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   tenantResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "tenant > http/controller > FindById")
+}
+
+func (c *tenantControllerImpl) FindAll(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	tenantsResponse := c.UseCase.FindAll(r.Context())
+
+	webResponse := &model.WebResponse[[]model.TenantResponse]{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   tenantsResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "tenant > http/controller > FindAll")
+}
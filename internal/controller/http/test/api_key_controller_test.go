@@ -0,0 +1,93 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	internal_controller_http "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
+)
+
+func TestApiKeyCreateSuccess(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader(`{"name":"reader","scopes":["categories:read"]}`))
+
+	testRequest.Header.Add("content-type", "application/json")
+
+	apiKeyUseCase := internal_usecase_mock.NewApiKeyUseCaseMock()
+
+	apiKeyUseCase.Mock.On("Create", mock.Anything, &model.CreateApiKeyRequest{
+		Name:   "reader",
+		Scopes: []string{"categories:read"},
+	}).Return(&model.ApiKeyResponse{
+		Id:     "KEY-1",
+		Name:   "reader",
+		Prefix: "pzn_abcdefgh",
+		Scopes: []string{"categories:read"},
+		Key:    "pzn_abcdefghijkl",
+	}).Times(1)
+
+	recorder := httptest.NewRecorder()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	internal_controller_http.NewApiKeyControllerImpl(appTestConfig, apiKeyUseCase).Create(recorder, testRequest, nil)
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusCreated, recorderResponse.StatusCode)
+	assert.Equal(t, "no-store", recorderResponse.Header.Get("cache-control"))
+
+	webResponse := new(model.WebResponse[*model.ApiKeyResponse])
+
+	err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+	helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, "CREATED", webResponse.Status)
+	assert.Equal(t, "KEY-1", webResponse.Data.Id)
+	assert.Equal(t, "pzn_abcdefghijkl", webResponse.Data.Key)
+
+	apiKeyUseCase.Mock.AssertExpectations(t)
+}
+
+func TestApiKeyRevokeSuccess(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodDelete, "http://localhost/", nil)
+
+	apiKeyUseCase := internal_usecase_mock.NewApiKeyUseCaseMock()
+
+	apiKeyUseCase.Mock.On("Revoke", mock.Anything, "KEY-1").Times(1)
+
+	recorder := httptest.NewRecorder()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	internal_controller_http.NewApiKeyControllerImpl(appTestConfig, apiKeyUseCase).Revoke(recorder, testRequest, httprouter.Params{
+		{Key: "apiKeyId", Value: "KEY-1"},
+	})
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+
+	webResponse := new(model.WebResponseMessage)
+
+	err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+	helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, "api key is successfully revoked", webResponse.Message)
+
+	apiKeyUseCase.Mock.AssertExpectations(t)
+}
@@ -7,16 +7,35 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
 	internal_controller_http "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
 	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
 )
 
+var appTestConfig = &config.AppConfig{
+	Request: &config.Request{
+		MaxBodySize:           64,
+		DisallowUnknownFields: true,
+	},
+	HttpCache: &config.HttpCache{
+		CacheControl: "private, no-cache",
+	},
+}
+
+var categoryVersion = &model.CategoryVersionResponse{
+	TenantId:  "TENANT-1",
+	Version:   3,
+	UpdatedAt: time.Date(2026, time.October, 19, 11, 0, 0, 500, time.UTC),
+}
+
 func TestCreateFailed(t *testing.T) {
 	t.Run("Malformed Request Body", func(t *testing.T) {
 		// Arrange
@@ -29,7 +48,7 @@ func TestCreateFailed(t *testing.T) {
 		// Action & Assert
 		assert.Panics(t, func() {
 			// ---SUT (Subject Under Test)
-			internal_controller_http.NewCategoryControllerImpl(categoryUseCase).Create(recorder, testRequest, nil)
+			internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).Create(recorder, testRequest, nil)
 			// ---------------------------
 		})
 
@@ -37,6 +56,38 @@ func TestCreateFailed(t *testing.T) {
 		categoryUseCase.Mock.AssertNumberOfCalls(t, "Create", 0)
 	})
 
+	t.Run("Unknown Field In Request Body", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader(`{"name":"Gadget","color":"red"}`))
+
+		testRequest.Header.Add("content-type", "application/json")
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		recorder := httptest.NewRecorder()
+
+		// Action
+		var errRecover any
+
+		func() {
+			defer func() { errRecover = recover() }()
+
+			// ---SUT (Subject Under Test)
+			internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).Create(recorder, testRequest, nil)
+			// ---------------------------
+		}()
+
+		// Assert
+		errClient, ok := errRecover.(*exception.ErrorClientRequest)
+
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, errClient.GetStatusCode())
+			assert.Equal(t, `request body has unknown field "color"`, errClient.GetDetailError())
+		}
+
+		categoryUseCase.Mock.AssertNumberOfCalls(t, "Create", 0)
+	})
+
 	t.Run("UseCase Create Method Panic", func(t *testing.T) {
 		// Arrange
 		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader(`{}`))
@@ -52,7 +103,7 @@ func TestCreateFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "usecase Create method panic", func() {
 			// ---SUT (Subject Under Test)
-			internal_controller_http.NewCategoryControllerImpl(categoryUseCase).Create(recorder, testRequest, nil)
+			internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).Create(recorder, testRequest, nil)
 			// ---------------------------
 		})
 
@@ -77,7 +128,7 @@ func TestCreateSuccess(t *testing.T) {
 	// Action & Assert
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
-		internal_controller_http.NewCategoryControllerImpl(categoryUseCase).Create(recorder, testRequest, nil)
+		internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).Create(recorder, testRequest, nil)
 		// ---------------------------
 	})
 
@@ -108,6 +159,49 @@ func TestCreateSuccess(t *testing.T) {
 	categoryUseCase.Mock.AssertNumberOfCalls(t, "Create", 1)
 }
 
+func TestCreateIdempotent(t *testing.T) {
+	tests := []struct {
+		name     string
+		replayed bool
+		header   string
+	}{
+		{name: "First Request", replayed: false, header: ""},
+		{name: "Replayed Request", replayed: true, header: "true"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader(`{"name":"Fashions"}`))
+			testRequest.Header.Set("idempotency-key", "key-1")
+
+			categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+			categoryUseCase.Mock.On("CreateIdempotent", mock.Anything, "key-1", &model.CreateCategoryRequest{Name: "Fashions"}).Return(&model.CategoryResponse{
+				Id:   "CAT-1",
+				Name: "Fashions",
+			}, test.replayed).Times(1)
+
+			recorder := httptest.NewRecorder()
+
+			// Action & Assert
+			assert.NotPanics(t, func() {
+				// ---SUT (Subject Under Test)
+				internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).Create(recorder, testRequest, nil)
+				// ---------------------------
+			})
+
+			recorderResponse := recorder.Result()
+
+			assert.Equal(t, http.StatusCreated, recorderResponse.StatusCode)
+			assert.Equal(t, test.header, recorderResponse.Header.Get("idempotent-replayed"))
+
+			categoryUseCase.Mock.AssertExpectations(t)
+			categoryUseCase.Mock.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestUpdateFailed(t *testing.T) {
 	t.Run("Malformed Request Body", func(t *testing.T) {
 		// Arrange
@@ -121,7 +215,7 @@ func TestUpdateFailed(t *testing.T) {
 		assert.Panics(t, func() {
 			// ---SUT (Subject Under Test)
 			internal_controller_http.
-				NewCategoryControllerImpl(categoryUseCase).
+				NewCategoryControllerImpl(appTestConfig, categoryUseCase).
 				Update(
 					recorder,
 					testRequest,
@@ -150,7 +244,7 @@ func TestUpdateFailed(t *testing.T) {
 		assert.PanicsWithValue(t, "usecase Update method panic", func() {
 			// ---SUT (Subject Under Test)
 			internal_controller_http.
-				NewCategoryControllerImpl(categoryUseCase).
+				NewCategoryControllerImpl(appTestConfig, categoryUseCase).
 				Update(
 					recorder,
 					testRequest,
@@ -185,7 +279,7 @@ func TestUpdateSuccess(t *testing.T) {
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
 		internal_controller_http.
-			NewCategoryControllerImpl(categoryUseCase).
+			NewCategoryControllerImpl(appTestConfig, categoryUseCase).
 			Update(
 				recorder,
 				testRequest,
@@ -239,7 +333,7 @@ func TestDeleteFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "usecase Delete method panic", func() {
 			// ---SUT (Subject Under Test)
-			internal_controller_http.NewCategoryControllerImpl(categoryUseCase).Delete(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
+			internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).Delete(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
 			// ---------------------------
 		})
 
@@ -261,7 +355,7 @@ func TestDeleteSuccess(t *testing.T) {
 	// Action & Assert
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
-		internal_controller_http.NewCategoryControllerImpl(categoryUseCase).Delete(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
+		internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).Delete(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
 		// ---------------------------
 	})
 
@@ -294,6 +388,8 @@ func TestFindByIdFailed(t *testing.T) {
 
 		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
 
+		categoryUseCase.Mock.On("FindVersion", mock.Anything).Return(categoryVersion).Times(1)
+
 		categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-5").Panic("usecase FindById method panic")
 
 		recorder := httptest.NewRecorder()
@@ -301,7 +397,7 @@ func TestFindByIdFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "usecase FindById method panic", func() {
 			// ---SUT (Subject Under Test)
-			internal_controller_http.NewCategoryControllerImpl(categoryUseCase).FindById(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
+			internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).FindById(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
 			// ---------------------------
 		})
 
@@ -316,6 +412,8 @@ func TestFindByIdSuccess(t *testing.T) {
 
 	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
 
+	categoryUseCase.Mock.On("FindVersion", mock.Anything).Return(categoryVersion).Times(1)
+
 	categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-5").Return(&model.CategoryResponse{
 		Id:   "CAT-5",
 		Name: "Drinks",
@@ -326,7 +424,7 @@ func TestFindByIdSuccess(t *testing.T) {
 	// Action & Assert
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
-		internal_controller_http.NewCategoryControllerImpl(categoryUseCase).FindById(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
+		internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).FindById(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
 		// ---------------------------
 	})
 
@@ -339,6 +437,9 @@ func TestFindByIdSuccess(t *testing.T) {
 	)
 
 	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+	assert.NotEmpty(t, recorderResponse.Header.Get("etag"))
+	assert.Equal(t, "Mon, 19 Oct 2026 11:00:00 GMT", recorderResponse.Header.Get("last-modified"))
+	assert.Equal(t, []string{"Authorization", "X-API-Key"}, recorderResponse.Header.Values("vary"))
 
 	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
 	helper.PanicIfError(err)
@@ -368,6 +469,8 @@ func TestFindAllFailed(t *testing.T) {
 
 		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
 
+		categoryUseCase.Mock.On("FindVersion", mock.Anything).Return(categoryVersion).Times(1)
+
 		categoryUseCase.Mock.On("FindAll", mock.Anything).Panic("usecase FindAll method panic")
 
 		recorder := httptest.NewRecorder()
@@ -375,7 +478,7 @@ func TestFindAllFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "usecase FindAll method panic", func() {
 			// ---SUT (Subject Under Test)
-			internal_controller_http.NewCategoryControllerImpl(categoryUseCase).FindAll(recorder, testRequest, nil)
+			internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).FindAll(recorder, testRequest, nil)
 			// ---------------------------
 		})
 
@@ -390,6 +493,8 @@ func TestFindAllSuccess(t *testing.T) {
 
 	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
 
+	categoryUseCase.Mock.On("FindVersion", mock.Anything).Return(categoryVersion).Times(1)
+
 	categoryUseCase.Mock.On("FindAll", mock.Anything).Return([]model.CategoryResponse{
 		{Id: "CAT-5", Name: "Drinks"},
 		{Id: "CAT-6", Name: "Foods"},
@@ -402,7 +507,7 @@ func TestFindAllSuccess(t *testing.T) {
 	// Action & Assert
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
-		internal_controller_http.NewCategoryControllerImpl(categoryUseCase).FindAll(recorder, testRequest, nil)
+		internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).FindAll(recorder, testRequest, nil)
 		// ---------------------------
 	})
 
@@ -415,6 +520,10 @@ func TestFindAllSuccess(t *testing.T) {
 	)
 
 	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+	assert.Equal(t, `"categories-TENANT-1-3"`, recorderResponse.Header.Get("etag"))
+	assert.Equal(t, "Mon, 19 Oct 2026 11:00:00 GMT", recorderResponse.Header.Get("last-modified"))
+	assert.Equal(t, "private, no-cache", recorderResponse.Header.Get("cache-control"))
+	assert.Equal(t, []string{"Authorization", "X-API-Key"}, recorderResponse.Header.Values("vary"))
 
 	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
 	helper.PanicIfError(err)
@@ -438,3 +547,111 @@ func TestFindAllSuccess(t *testing.T) {
 	categoryUseCase.Mock.AssertExpectations(t)
 	categoryUseCase.Mock.AssertNumberOfCalls(t, "FindAll", 1)
 }
+
+func TestFindByIdNotModified(t *testing.T) {
+	categoryResponse := &model.CategoryResponse{
+		Id:   "CAT-5",
+		Name: "Drinks",
+	}
+
+	t.Run("If-None-Match Matches", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+		testRequest.Header.Set("if-none-match", `"other", `+helper.StrongETag(categoryResponse))
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		categoryUseCase.Mock.On("FindVersion", mock.Anything).Return(categoryVersion).Times(1)
+		categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-5").Return(categoryResponse).Times(1)
+
+		recorder := httptest.NewRecorder()
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).FindById(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
+			// ---------------------------
+		})
+
+		recorderResponse := recorder.Result()
+
+		assert.Equal(t, http.StatusNotModified, recorderResponse.StatusCode)
+		assert.Equal(t, helper.StrongETag(categoryResponse), recorderResponse.Header.Get("etag"))
+		assert.Empty(t, recorder.Body.Bytes())
+	})
+
+	t.Run("If-None-Match Does Not Match", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+		testRequest.Header.Set("if-none-match", `"other"`)
+		testRequest.Header.Set("if-modified-since", "Mon, 19 Oct 2026 11:00:00 GMT")
+
+		categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+		categoryUseCase.Mock.On("FindVersion", mock.Anything).Return(categoryVersion).Times(1)
+		categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-5").Return(categoryResponse).Times(1)
+
+		recorder := httptest.NewRecorder()
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).FindById(recorder, testRequest, httprouter.Params{{Key: "categoryId", Value: "CAT-5"}})
+			// ---------------------------
+		})
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	})
+}
+
+func TestFindAllNotModified(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		statusCode int
+	}{
+		{name: "If-None-Match Matches", header: "if-none-match", value: `"categories-TENANT-1-3"`, statusCode: http.StatusNotModified},
+		{name: "If-None-Match Weak Matches", header: "if-none-match", value: `W/"categories-TENANT-1-3"`, statusCode: http.StatusNotModified},
+		{name: "If-None-Match Wildcard", header: "if-none-match", value: "*", statusCode: http.StatusNotModified},
+		{name: "If-None-Match Outdated", header: "if-none-match", value: `"categories-2"`, statusCode: http.StatusOK},
+		{name: "If-Modified-Since Same Second", header: "if-modified-since", value: "Mon, 19 Oct 2026 11:00:00 GMT", statusCode: http.StatusNotModified},
+		{name: "If-Modified-Since Outdated", header: "if-modified-since", value: "Mon, 19 Oct 2026 10:59:59 GMT", statusCode: http.StatusOK},
+		{name: "If-Modified-Since Invalid", header: "if-modified-since", value: "yesterday", statusCode: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+			testRequest.Header.Set(test.header, test.value)
+
+			categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+			categoryUseCase.Mock.On("FindVersion", mock.Anything).Return(categoryVersion).Times(1)
+			categoryUseCase.Mock.On("FindAll", mock.Anything).Return([]model.CategoryResponse{}).Maybe()
+
+			recorder := httptest.NewRecorder()
+
+			// Action & Assert
+			assert.NotPanics(t, func() {
+				// ---SUT (Subject Under Test)
+				internal_controller_http.NewCategoryControllerImpl(appTestConfig, categoryUseCase).FindAll(recorder, testRequest, nil)
+				// ---------------------------
+			})
+
+			recorderResponse := recorder.Result()
+
+			assert.Equal(t, test.statusCode, recorderResponse.StatusCode)
+			assert.Equal(t, `"categories-TENANT-1-3"`, recorderResponse.Header.Get("etag"))
+
+			if test.statusCode == http.StatusNotModified {
+				// The categories are not loaded when the client copy is fresh
+				categoryUseCase.Mock.AssertNotCalled(t, "FindAll", mock.Anything)
+				assert.Empty(t, recorder.Body.Bytes())
+			} else {
+				categoryUseCase.Mock.AssertNumberOfCalls(t, "FindAll", 1)
+			}
+		})
+	}
+}
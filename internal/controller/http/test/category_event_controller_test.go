@@ -0,0 +1,141 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	internal_controller_http "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+)
+
+var appStreamTestConfig = &config.AppConfig{
+	Stream: &config.Stream{
+		LogSize:    10,
+		BufferSize: 10,
+		Heartbeat:  1,
+	},
+}
+
+func newCategoryEvent(id int64, eventType string) *model.CategoryEventResponse {
+	return &model.CategoryEventResponse{
+		Id:        id,
+		TenantId:  "TENANT-1",
+		Type:      eventType,
+		CreatedAt: time.Date(2026, time.October, 19, 14, 0, 0, 0, time.UTC),
+		Data:      []byte(`{"id":"CAT-1","name":"Gadget"}`),
+	}
+}
+
+// serveCategoryEvents streams from the controller in the background, the
+// recorder is read once the stream ends
+func serveCategoryEvents(broker stream.Broker, lastEventId string) (*httptest.ResponseRecorder, context.CancelFunc, chan bool) {
+	ctx, cancel := context.WithCancel(security.ContextWithPrincipal(context.Background(), &security.Principal{Id: "KEY-1", TenantId: "TENANT-1"}))
+
+	testRequest := httptest.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/api/v2/categories/events", nil)
+
+	if lastEventId != "" {
+		testRequest.Header.Set("last-event-id", lastEventId)
+	}
+
+	recorder := httptest.NewRecorder()
+	doneChan := make(chan bool)
+
+	go func() {
+		internal_controller_http.NewCategoryEventControllerImpl(appStreamTestConfig, broker).Stream(recorder, testRequest, nil)
+
+		doneChan <- true
+	}()
+
+	return recorder, cancel, doneChan
+}
+
+func readLines(recorder *httptest.ResponseRecorder) []string {
+	lines := []string{}
+
+	scanner := bufio.NewScanner(recorder.Body)
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines
+}
+
+func TestCategoryEventStreamSuccess(t *testing.T) {
+	// Arrange
+	broker := stream.NewBrokerImpl(appStreamTestConfig)
+
+	broker.Publish(newCategoryEvent(1, "category.created"))
+	broker.Publish(newCategoryEvent(2, "category.updated"))
+
+	// Action
+	// ---SUT (Subject Under Test)
+	recorder, cancel, doneChan := serveCategoryEvents(broker, "1")
+	defer cancel()
+	// ---------------------------
+
+	// Assert
+	// The stream outlives a heartbeat, then the server shuts down
+	time.Sleep(1500 * time.Millisecond)
+
+	otherTenantEvent := newCategoryEvent(3, "category.created")
+	otherTenantEvent.TenantId = "TENANT-2"
+
+	broker.Publish(otherTenantEvent)
+	broker.Publish(newCategoryEvent(4, "category.deleted"))
+	broker.Close()
+
+	<-doneChan
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("content-type"))
+	assert.Equal(t, "no-store", recorder.Header().Get("cache-control"))
+	assert.True(t, recorder.Flushed)
+
+	assert.Equal(t, []string{
+		"id: 2",
+		"event: category.updated",
+		`data: {"id":2,"type":"category.updated","created_at":"2026-10-19T14:00:00Z","data":{"id":"CAT-1","name":"Gadget"}}`,
+		"",
+		": heartbeat",
+		"",
+		"id: 4",
+		"event: category.deleted",
+		`data: {"id":4,"type":"category.deleted","created_at":"2026-10-19T14:00:00Z","data":{"id":"CAT-1","name":"Gadget"}}`,
+		"",
+	}, readLines(recorder))
+}
+
+func TestCategoryEventStreamReset(t *testing.T) {
+	// Arrange
+	broker := stream.NewBrokerImpl(appStreamTestConfig)
+
+	broker.Publish(newCategoryEvent(5, "category.created"))
+
+	// Action
+	// ---SUT (Subject Under Test)
+	recorder, cancel, doneChan := serveCategoryEvents(broker, "4")
+	// ---------------------------
+
+	// Assert
+	// The client goes away
+	time.Sleep(100 * time.Millisecond)
+
+	cancel()
+	<-doneChan
+
+	assert.Equal(t, []string{
+		"id: 5",
+		"event: reset",
+		"data: {}",
+		"",
+	}, readLines(recorder))
+}
@@ -0,0 +1,321 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	internal_controller_http "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
+)
+
+var appWebsocketTestConfig = &config.AppConfig{
+	Server: &config.Server{
+		ApiKey: "secret",
+	},
+	Cors: &config.Cors{
+		AllowedOrigins: []string{"https://*.example.com"},
+	},
+	Stream: &config.Stream{
+		LogSize:    10,
+		BufferSize: 10,
+	},
+	Websocket: &config.Websocket{
+		AuthTimeout:      1,
+		PingInterval:     1,
+		PongTimeout:      2,
+		WriteTimeout:     1,
+		SendBuffer:       4,
+		MaxSubscriptions: 2,
+		MaxMessageSize:   1024,
+	},
+}
+
+// newSubscriptionApiKeyUseCase authenticates "secret" with the admin scope,
+// "writer" without the categories:read scope and "tenantless" without a tenant
+func newSubscriptionApiKeyUseCase() usecase.ApiKeyUseCase {
+	apiKeyUseCase := internal_usecase_mock.NewApiKeyUseCaseMock()
+
+	apiKeyUseCase.Mock.On("Authenticate", mock.Anything, "secret").Return(&security.Principal{Id: "KEY-1", TenantId: "TENANT-1", Scopes: []string{security.ScopeAdmin}})
+	apiKeyUseCase.Mock.On("Authenticate", mock.Anything, "writer").Return(&security.Principal{Id: "KEY-2", TenantId: "TENANT-1", Scopes: []string{security.ScopeCategoriesWrite}})
+	apiKeyUseCase.Mock.On("Authenticate", mock.Anything, "tenantless").Return(&security.Principal{Id: "KEY-3", Scopes: []string{security.ScopeAdmin}})
+	apiKeyUseCase.Mock.On("Authenticate", mock.Anything, mock.Anything).Return(nil)
+
+	return apiKeyUseCase
+}
+
+func startSubscriptionServer(broker stream.Broker) *httptest.Server {
+	controller := internal_controller_http.NewCategorySubscriptionControllerImpl(appWebsocketTestConfig, broker, newSubscriptionApiKeyUseCase())
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		controller.Serve(w, r, nil)
+	}))
+}
+
+func dialSubscriptionServer(t *testing.T, server *httptest.Server, header http.Header) *websocket.Conn {
+	conn, _, err := websocket.Dial(t.Context(), strings.Replace(server.URL, "http", "ws", 1), &websocket.DialOptions{
+		HTTPHeader: header,
+	})
+	helper.PanicIfError(err)
+
+	return conn
+}
+
+func writeMessage(t *testing.T, conn *websocket.Conn, message *model.CategorySubscriptionMessage) {
+	err := wsjson.Write(t.Context(), conn, message)
+	helper.PanicIfError(err)
+}
+
+func readReply(t *testing.T, conn *websocket.Conn) *model.CategorySubscriptionReply {
+	ctx, cancel := context.WithTimeout(t.Context(), 3*time.Second)
+	defer cancel()
+
+	reply := new(model.CategorySubscriptionReply)
+
+	err := wsjson.Read(ctx, conn, reply)
+	helper.PanicIfError(err)
+
+	return reply
+}
+
+func readCloseStatus(t *testing.T, conn *websocket.Conn) websocket.StatusCode {
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	for {
+		if _, _, err := conn.Read(ctx); err != nil {
+			return websocket.CloseStatus(err)
+		}
+	}
+}
+
+func TestCategorySubscriptionSuccess(t *testing.T) {
+	// Arrange
+	broker := stream.NewBrokerImpl(appWebsocketTestConfig)
+
+	server := startSubscriptionServer(broker)
+	defer server.Close()
+
+	conn := dialSubscriptionServer(t, server, http.Header{"X-Api-Key": []string{"secret"}})
+	defer conn.CloseNow()
+
+	// Action & Assert
+	// ---SUT (Subject Under Test)
+	writeMessage(t, conn, &model.CategorySubscriptionMessage{Type: "subscribe", Id: "1", CategoryIds: []string{"CAT-1"}})
+	assert.Equal(t, &model.CategorySubscriptionReply{Type: "ack", Id: "1", Subscription: "sub-1"}, readReply(t, conn))
+
+	writeMessage(t, conn, &model.CategorySubscriptionMessage{Type: "subscribe", Id: "2"})
+	assert.Equal(t, &model.CategorySubscriptionReply{Type: "ack", Id: "2", Subscription: "sub-2"}, readReply(t, conn))
+
+	writeMessage(t, conn, &model.CategorySubscriptionMessage{Type: "subscribe", Id: "3"})
+	assert.Equal(t, &model.CategorySubscriptionReply{Type: "error", Id: "3", Message: "a connection must not have more than 2 subscriptions"}, readReply(t, conn))
+
+	writeMessage(t, conn, &model.CategorySubscriptionMessage{Type: "ping", Id: "4"})
+	assert.Equal(t, &model.CategorySubscriptionReply{Type: "pong", Id: "4"}, readReply(t, conn))
+
+	broker.Publish(&model.CategoryEventResponse{TenantId: "TENANT-1", Id: 1, Type: "category.created", Data: []byte(`{"id":"CAT-1","name":"Gadget"}`)})
+
+	eventReply := readReply(t, conn)
+
+	assert.Equal(t, "event", eventReply.Type)
+	assert.Equal(t, []string{"sub-1", "sub-2"}, eventReply.Subscriptions)
+	assert.Equal(t, int64(1), eventReply.Event.Id)
+
+	writeMessage(t, conn, &model.CategorySubscriptionMessage{Type: "unsubscribe", Id: "5", Subscription: "sub-2"})
+	assert.Equal(t, &model.CategorySubscriptionReply{Type: "ack", Id: "5", Subscription: "sub-2"}, readReply(t, conn))
+
+	writeMessage(t, conn, &model.CategorySubscriptionMessage{Type: "unsubscribe", Id: "6", Subscription: "sub-2"})
+	assert.Equal(t, &model.CategorySubscriptionReply{Type: "error", Id: "6", Message: "subscription sub-2 is not found"}, readReply(t, conn))
+
+	writeMessage(t, conn, &model.CategorySubscriptionMessage{Type: "watch", Id: "7"})
+	assert.Equal(t, &model.CategorySubscriptionReply{Type: "error", Id: "7", Message: "unknown message type watch"}, readReply(t, conn))
+
+	// Only sub-1 is left, the events of another category and of another tenant
+	// are not sent, and the connection outlives a ping
+	broker.Publish(&model.CategoryEventResponse{TenantId: "TENANT-2", Id: 2, Type: "category.updated", Data: []byte(`{"id":"CAT-1","name":"Gadget"}`)})
+	broker.Publish(&model.CategoryEventResponse{TenantId: "TENANT-1", Id: 2, Type: "category.created", Data: []byte(`{"id":"CAT-2","name":"Tools"}`)})
+
+	time.Sleep(1500 * time.Millisecond)
+
+	broker.Publish(&model.CategoryEventResponse{TenantId: "TENANT-1", Id: 3, Type: "category.deleted", Data: []byte(`{"id":"CAT-1","name":"Gadget"}`)})
+
+	eventReply = readReply(t, conn)
+
+	assert.Equal(t, []string{"sub-1"}, eventReply.Subscriptions)
+	assert.Equal(t, int64(3), eventReply.Event.Id)
+
+	broker.Close()
+
+	assert.Equal(t, websocket.StatusGoingAway, readCloseStatus(t, conn))
+	// ---------------------------
+}
+
+func TestCategorySubscriptionAuthMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message *model.CategorySubscriptionMessage
+		status  websocket.StatusCode
+	}{
+		{name: "Valid API Key", message: &model.CategorySubscriptionMessage{Type: "auth", Id: "1", ApiKey: "secret"}, status: websocket.StatusGoingAway},
+		{name: "Invalid API Key", message: &model.CategorySubscriptionMessage{Type: "auth", Id: "1", ApiKey: "wrong"}, status: websocket.StatusPolicyViolation},
+		{name: "API Key Without Read Scope", message: &model.CategorySubscriptionMessage{Type: "auth", Id: "1", ApiKey: "writer"}, status: websocket.StatusPolicyViolation},
+		{name: "API Key Without Tenant", message: &model.CategorySubscriptionMessage{Type: "auth", Id: "1", ApiKey: "tenantless"}, status: websocket.StatusPolicyViolation},
+		{name: "Not An Auth Message", message: &model.CategorySubscriptionMessage{Type: "subscribe", Id: "1"}, status: websocket.StatusPolicyViolation},
+		{name: "Missing Auth Message", message: nil, status: websocket.StatusPolicyViolation},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			broker := stream.NewBrokerImpl(appWebsocketTestConfig)
+
+			server := startSubscriptionServer(broker)
+			defer server.Close()
+
+			conn := dialSubscriptionServer(t, server, nil)
+			defer conn.CloseNow()
+
+			// Action
+			// ---SUT (Subject Under Test)
+			if test.message != nil {
+				writeMessage(t, conn, test.message)
+			}
+			// ---------------------------
+
+			// Assert
+			if test.status == websocket.StatusGoingAway {
+				assert.Equal(t, &model.CategorySubscriptionReply{Type: "ack", Id: "1"}, readReply(t, conn))
+
+				broker.Close()
+			}
+
+			assert.Equal(t, test.status, readCloseStatus(t, conn))
+		})
+	}
+}
+
+func TestCategorySubscriptionFailed(t *testing.T) {
+	headerTests := []struct {
+		name       string
+		apiKey     string
+		statusCode int
+	}{
+		{name: "Invalid API Key Header", apiKey: "wrong", statusCode: http.StatusUnauthorized},
+		{name: "API Key Header Without Read Scope", apiKey: "writer", statusCode: http.StatusForbidden},
+		{name: "API Key Header Without Tenant", apiKey: "tenantless", statusCode: http.StatusForbidden},
+	}
+
+	for _, test := range headerTests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/api/v2/ws", nil)
+
+			testRequest.Header.Set("X-API-Key", test.apiKey)
+
+			recorder := httptest.NewRecorder()
+
+			// Action & Assert
+			defer func() {
+				errRecover := recover()
+
+				if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+					assert.Equal(t, test.statusCode, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+				}
+			}()
+
+			// ---SUT (Subject Under Test)
+			internal_controller_http.NewCategorySubscriptionControllerImpl(appWebsocketTestConfig, stream.NewBrokerImpl(appWebsocketTestConfig), newSubscriptionApiKeyUseCase()).Serve(recorder, testRequest, nil)
+			// ---------------------------
+		})
+	}
+
+	t.Run("Origin Is Not Allowed", func(t *testing.T) {
+		// Arrange
+		server := startSubscriptionServer(stream.NewBrokerImpl(appWebsocketTestConfig))
+		defer server.Close()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		_, response, err := websocket.Dial(t.Context(), strings.Replace(server.URL, "http", "ws", 1), &websocket.DialOptions{
+			HTTPHeader: http.Header{"X-Api-Key": []string{"secret"}, "Origin": []string{"https://evil.io"}},
+		})
+		// ---------------------------
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	})
+
+	t.Run("Allowed Origin", func(t *testing.T) {
+		// Arrange
+		server := startSubscriptionServer(stream.NewBrokerImpl(appWebsocketTestConfig))
+		defer server.Close()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		conn := dialSubscriptionServer(t, server, http.Header{"X-Api-Key": []string{"secret"}, "Origin": []string{"https://admin.example.com"}})
+		// ---------------------------
+
+		// Assert
+		assert.NoError(t, conn.CloseNow())
+	})
+
+	t.Run("Slow Client", func(t *testing.T) {
+		// Arrange
+		broker := stream.NewBrokerImpl(appWebsocketTestConfig)
+
+		server := startSubscriptionServer(broker)
+		defer server.Close()
+
+		conn := dialSubscriptionServer(t, server, http.Header{"X-Api-Key": []string{"secret"}})
+		defer conn.CloseNow()
+
+		writeMessage(t, conn, &model.CategorySubscriptionMessage{Type: "subscribe", Id: "1"})
+		readReply(t, conn)
+
+		data := []byte(`{"id":"CAT-1","name":"` + strings.Repeat("A", 64*1024) + `"}`)
+
+		// Action
+		// ---SUT (Subject Under Test)
+		// The client reads nothing until the server stops writing to it
+		for id := range int64(500) {
+			broker.Publish(&model.CategoryEventResponse{TenantId: "TENANT-1", Id: id + 1, Type: "category.updated", Data: data})
+		}
+
+		time.Sleep(1500 * time.Millisecond)
+		// ---------------------------
+
+		// Assert
+		received := 0
+
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+
+		conn.SetReadLimit(-1)
+
+		for {
+			if _, _, err := conn.Read(ctx); err != nil {
+				assert.NotErrorIs(t, err, context.DeadlineExceeded)
+				break
+			}
+
+			received++
+		}
+
+		assert.Less(t, received, 500, "the slow client is disconnected")
+	})
+}
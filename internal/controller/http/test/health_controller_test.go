@@ -0,0 +1,93 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	internal_controller_http "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
+)
+
+func TestHealthz(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/healthz", nil)
+
+	healthUseCase := internal_usecase_mock.NewHealthUseCaseMock()
+
+	recorder := httptest.NewRecorder()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	internal_controller_http.NewHealthControllerImpl(healthUseCase).Healthz(recorder, testRequest, nil)
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+	assert.Equal(t, "no-store", recorderResponse.Header.Get("cache-control"))
+
+	healthUseCase.Mock.AssertNumberOfCalls(t, "Ready", 0)
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name           string
+		healthResponse *model.HealthResponse
+		statusCode     int
+		status         string
+	}{
+		{
+			name:           "Ready",
+			healthResponse: &model.HealthResponse{Status: "pass", Checks: []model.HealthCheckResponse{{Name: "database", Status: "pass"}}},
+			statusCode:     http.StatusOK,
+			status:         "OK",
+		},
+		{
+			name:           "Not Ready",
+			healthResponse: &model.HealthResponse{Status: "fail", Checks: []model.HealthCheckResponse{{Name: "database", Status: "fail", Message: "unavailable"}}},
+			statusCode:     http.StatusServiceUnavailable,
+			status:         "SERVICE UNAVAILABLE",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/readyz", nil)
+
+			healthUseCase := internal_usecase_mock.NewHealthUseCaseMock()
+
+			healthUseCase.Mock.On("Ready", mock.Anything).Return(test.healthResponse)
+
+			recorder := httptest.NewRecorder()
+
+			// Action
+			// ---SUT (Subject Under Test)
+			internal_controller_http.NewHealthControllerImpl(healthUseCase).Readyz(recorder, testRequest, nil)
+			// ---------------------------
+
+			// Assert
+			recorderResponse := recorder.Result()
+
+			assert.Equal(t, test.statusCode, recorderResponse.StatusCode)
+
+			webResponse := new(model.WebResponse[*model.HealthResponse])
+
+			err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+			helper.PanicIfError(err)
+
+			assert.Equal(t, test.statusCode, webResponse.Code)
+			assert.Equal(t, test.status, webResponse.Status)
+			assert.Equal(t, test.healthResponse, webResponse.Data)
+
+			healthUseCase.Mock.AssertExpectations(t)
+		})
+	}
+}
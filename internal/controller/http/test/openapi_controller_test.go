@@ -0,0 +1,45 @@
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/api"
+	internal_controller_http "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+)
+
+func TestDocs(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/api/v2/docs", nil)
+
+	recorder := httptest.NewRecorder()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	internal_controller_http.NewOpenApiControllerImpl().Docs(recorder, testRequest, nil)
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+	assert.Equal(t, api.Docs, recorder.Body.Bytes())
+
+	// Every script of the page is pinned to an exact Swagger UI release
+	for _, match := range regexp.MustCompile(`src="([^"]+)"`).FindAllStringSubmatch(string(api.Docs), -1) {
+		assert.Regexp(t, `^https://unpkg\.com/swagger-ui-dist@\d+\.\d+\.\d+/`, match[1])
+	}
+
+	inlineScript := regexp.MustCompile(`(?s)<script>(.*?)</script>`).FindSubmatch(api.Docs)[1]
+	inlineScriptHash := sha256.Sum256(inlineScript)
+
+	contentSecurityPolicy := recorderResponse.Header.Get("content-security-policy")
+
+	assert.Contains(t, contentSecurityPolicy, "default-src 'none'")
+	assert.Contains(t, contentSecurityPolicy, "script-src https://unpkg.com/swagger-ui-dist@5.17.14/ 'sha256-"+base64.StdEncoding.EncodeToString(inlineScriptHash[:])+"';")
+}
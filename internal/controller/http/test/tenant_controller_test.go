@@ -0,0 +1,90 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	internal_controller_http "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
+)
+
+func TestTenantCreateSuccess(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader(`{"name":"brand-b"}`))
+
+	testRequest.Header.Add("content-type", "application/json")
+
+	tenantUseCase := internal_usecase_mock.NewTenantUseCaseMock()
+
+	tenantUseCase.Mock.On("Create", mock.Anything, &model.CreateTenantRequest{
+		Name: "brand-b",
+	}).Return(&model.TenantResponse{
+		Id:   "TENANT-2",
+		Name: "brand-b",
+	}).Times(1)
+
+	recorder := httptest.NewRecorder()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	internal_controller_http.NewTenantControllerImpl(appTestConfig, tenantUseCase).Create(recorder, testRequest, nil)
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusCreated, recorderResponse.StatusCode)
+
+	webResponse := new(model.WebResponse[*model.TenantResponse])
+
+	err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+	helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, "CREATED", webResponse.Status)
+	assert.Equal(t, "TENANT-2", webResponse.Data.Id)
+
+	tenantUseCase.Mock.AssertExpectations(t)
+}
+
+func TestTenantFindByIdSuccess(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+
+	tenantUseCase := internal_usecase_mock.NewTenantUseCaseMock()
+
+	tenantUseCase.Mock.On("FindById", mock.Anything, "TENANT-2").Return(&model.TenantResponse{
+		Id:   "TENANT-2",
+		Name: "brand-b",
+	}).Times(1)
+
+	recorder := httptest.NewRecorder()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	internal_controller_http.NewTenantControllerImpl(appTestConfig, tenantUseCase).FindById(recorder, testRequest, httprouter.Params{
+		{Key: "tenantId", Value: "TENANT-2"},
+	})
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+
+	webResponse := new(model.WebResponse[*model.TenantResponse])
+
+	err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+	helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, "brand-b", webResponse.Data.Name)
+
+	tenantUseCase.Mock.AssertExpectations(t)
+}
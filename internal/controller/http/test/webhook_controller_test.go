@@ -0,0 +1,132 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	internal_controller_http "github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
+)
+
+func TestWebhookCreateSuccess(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader(`{"url":"https://a.io/h","event_types":["category.created"]}`))
+
+	testRequest.Header.Add("content-type", "application/json")
+
+	webhookUseCase := internal_usecase_mock.NewWebhookUseCaseMock()
+
+	webhookUseCase.Mock.On("Create", mock.Anything, &model.CreateWebhookRequest{
+		Url:        "https://a.io/h",
+		EventTypes: []string{"category.created"},
+	}).Return(&model.WebhookResponse{
+		Id:         "WH-1",
+		Url:        "https://a.io/h",
+		EventTypes: []string{"category.created"},
+		Secret:     "whsec_secret",
+	}).Times(1)
+
+	recorder := httptest.NewRecorder()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	internal_controller_http.NewWebhookControllerImpl(appTestConfig, webhookUseCase).Create(recorder, testRequest, nil)
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusCreated, recorderResponse.StatusCode)
+
+	webResponse := new(model.WebResponse[*model.WebhookResponse])
+
+	err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+	helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, "CREATED", webResponse.Status)
+	assert.Equal(t, "WH-1", webResponse.Data.Id)
+	assert.Equal(t, "whsec_secret", webResponse.Data.Secret)
+
+	webhookUseCase.Mock.AssertExpectations(t)
+}
+
+func TestWebhookRedeliverFailed(t *testing.T) {
+	t.Run("Malformed Delivery Id", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/", nil)
+
+		webhookUseCase := internal_usecase_mock.NewWebhookUseCaseMock()
+
+		recorder := httptest.NewRecorder()
+
+		// Action
+		var errRecover any
+
+		func() {
+			defer func() { errRecover = recover() }()
+
+			// ---SUT (Subject Under Test)
+			internal_controller_http.NewWebhookControllerImpl(appTestConfig, webhookUseCase).Redeliver(recorder, testRequest, httprouter.Params{
+				{Key: "webhookId", Value: "WH-1"},
+				{Key: "deliveryId", Value: "abc"},
+			})
+			// ---------------------------
+		}()
+
+		// Assert
+		errClient, ok := errRecover.(*exception.ErrorClientRequest)
+
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusNotFound, errClient.GetStatusCode())
+			assert.Equal(t, "webhook delivery is not found", errClient.GetDetailError())
+		}
+
+		webhookUseCase.Mock.AssertNumberOfCalls(t, "Redeliver", 0)
+	})
+}
+
+func TestWebhookRedeliverSuccess(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/", nil)
+
+	webhookUseCase := internal_usecase_mock.NewWebhookUseCaseMock()
+
+	webhookUseCase.Mock.On("Redeliver", mock.Anything, "WH-1", int64(7)).Return(&model.WebhookDeliveryResponse{
+		Id:     7,
+		Status: "pending",
+	}).Times(1)
+
+	recorder := httptest.NewRecorder()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	internal_controller_http.NewWebhookControllerImpl(appTestConfig, webhookUseCase).Redeliver(recorder, testRequest, httprouter.Params{
+		{Key: "webhookId", Value: "WH-1"},
+		{Key: "deliveryId", Value: "7"},
+	})
+	// ---------------------------
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusAccepted, recorderResponse.StatusCode)
+
+	webResponse := new(model.WebResponse[*model.WebhookDeliveryResponse])
+
+	err := json.NewDecoder(recorderResponse.Body).Decode(webResponse)
+	helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, "ACCEPTED", webResponse.Status)
+	assert.Equal(t, "pending", webResponse.Data.Status)
+
+	webhookUseCase.Mock.AssertExpectations(t)
+}
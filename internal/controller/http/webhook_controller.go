@@ -0,0 +1,16 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type WebhookController interface {
+	Create(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	Delete(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	FindById(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	FindAll(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	FindDeliveries(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	Redeliver(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type webhookControllerImpl struct {
+	AppConfig *config.AppConfig
+	UseCase   usecase.WebhookUseCase
+}
+
+func NewWebhookControllerImpl(appConfig *config.AppConfig, useCase usecase.WebhookUseCase) WebhookController {
+	return &webhookControllerImpl{
+		AppConfig: appConfig,
+		UseCase:   useCase,
+	}
+}
+
+func (c *webhookControllerImpl) Create(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	webhookCreateRequest := new(model.CreateWebhookRequest)
+
+	err := helper.ReadFromRequestBody(w, r, webhookCreateRequest, helper.RequestBodyOptions{
+		MaxBodySize:           c.AppConfig.Request.MaxBodySize,
+		DisallowUnknownFields: c.AppConfig.Request.DisallowUnknownFields,
+	})
+	helper.PanicIfError(err)
+
+	webhookResponse := c.UseCase.Create(r.Context(), webhookCreateRequest)
+
+	webResponse := &model.WebResponse[*model.WebhookResponse]{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   webhookResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "webhook > http/controller > Create")
+}
+
+func (c *webhookControllerImpl) Delete(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	webhookId := params.ByName("webhookId")
+
+	c.UseCase.Delete(r.Context(), webhookId)
+
+	webResponse := &model.WebResponseMessage{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "webhook is successfully deleted",
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "webhook > http/controller > Delete")
+}
+
+func (c *webhookControllerImpl) FindById(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	webhookId := params.ByName("webhookId")
+
+	webhookResponse := c.UseCase.FindById(r.Context(), webhookId)
+
+	webResponse := &model.WebResponse[*model.WebhookResponse]{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "webhook > http/controller > FindById")
+}
+
+func (c *webhookControllerImpl) FindAll(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	webhooksResponse := c.UseCase.FindAll(r.Context())
+
+	webResponse := &model.WebResponse[[]model.WebhookResponse]{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   webhooksResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "webhook > http/controller > FindAll")
+}
+
+func (c *webhookControllerImpl) FindDeliveries(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	webhookId := params.ByName("webhookId")
+
+	deliveriesResponse := c.UseCase.FindDeliveries(r.Context(), webhookId)
+
+	webResponse := &model.WebResponse[[]model.WebhookDeliveryResponse]{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   deliveriesResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "webhook > http/controller > FindDeliveries")
+}
+
+func (c *webhookControllerImpl) Redeliver(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	webhookId := params.ByName("webhookId")
+
+	deliveryId, err := strconv.ParseInt(params.ByName("deliveryId"), 10, 64)
+	helper.ClientPanicIfError(err, exception.NewErrorClientRequest(err, http.StatusNotFound, "webhook delivery is not found"))
+
+	deliveryResponse := c.UseCase.Redeliver(r.Context(), webhookId, deliveryId)
+
+	webResponse := &model.WebResponse[*model.WebhookDeliveryResponse]{
+		Code:   http.StatusAccepted,
+		Status: "ACCEPTED",
+		Data:   deliveryResponse,
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	err = helper.WriteToResponseBody(w, webResponse)
+	helper.InternalServerPanicIfError(err, "webhook > http/controller > Redeliver")
+}
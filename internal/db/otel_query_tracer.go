@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+
+// otelQueryTracer starts a client span for every query pgx sends, the
+// statements of the transactions included. The arguments are never recorded.
+type otelQueryTracer struct{}
+
+func NewOtelQueryTracer() pgx.QueryTracer {
+	return new(otelQueryTracer)
+}
+
+func (t *otelQueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, querySpanName(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+
+	if conn != nil {
+		span.SetAttributes(semconv.DBNamespace(conn.Config().Database))
+	}
+
+	return ctx
+}
+
+func (t *otelQueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+
+	span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	span.End()
+}
+
+// querySpanName names the span after the SQL command, the full statement is
+// an attribute
+func querySpanName(sql string) string {
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		return "pgx " + strings.ToUpper(fields[0])
+	}
+
+	return "pgx"
+}
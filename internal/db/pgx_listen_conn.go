@@ -0,0 +1,19 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PgxListenConn is a connection of its own, apart from the pool, since a
+// LISTEN holds its connection for as long as it listens
+type PgxListenConn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+// PgxConnector opens a new PgxListenConn, so a listener can reconnect after
+// losing its connection
+type PgxConnector func(ctx context.Context) (PgxListenConn, error)
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+// requestIdPgxPool tags every transaction begun for a request with its request
+// ID as the application_name, so it shows up in pg_stat_activity and the
+// Postgres logs. The setting is local, it ends with the transaction.
+type requestIdPgxPool struct {
+	PgxPool PgxPool
+}
+
+func NewRequestIdPgxPool(pgxPool PgxPool) PgxPool {
+	return &requestIdPgxPool{
+		PgxPool: pgxPool,
+	}
+}
+
+func (p *requestIdPgxPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := p.PgxPool.Begin(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	requestInfo := helper.RequestInfoFromContext(ctx)
+
+	if requestInfo == nil {
+		return tx, nil
+	}
+
+	_, err = tx.Exec(ctx, "SELECT set_config('application_name', $1, true)", requestInfo.Id)
+
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (p *requestIdPgxPool) Ping(ctx context.Context) error {
+	return p.PgxPool.Ping(ctx)
+}
+
+func (p *requestIdPgxPool) Stat() *pgxpool.Stat {
+	return p.PgxPool.Stat()
+}
+
+func (p *requestIdPgxPool) Close() {
+	p.PgxPool.Close()
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupSpanExporter() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	return exporter
+}
+
+func TestOtelQueryTracer(t *testing.T) {
+	t.Run("Query Succeeded", func(t *testing.T) {
+		// Arrange
+		exporter := setupSpanExporter()
+
+		tracer := db.NewOtelQueryTracer()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		ctx := tracer.TraceQueryStart(t.Context(), nil, pgx.TraceQueryStartData{SQL: "\n  select id, name FROM categories"})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 2")})
+		// ---------------------------
+
+		// Assert
+		spans := exporter.GetSpans()
+
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, "pgx SELECT", spans[0].Name)
+			assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+			assert.Contains(t, spans[0].Attributes, semconv.DBSystemNamePostgreSQL)
+			assert.Contains(t, spans[0].Attributes, semconv.DBQueryText("\n  select id, name FROM categories"))
+			assert.Equal(t, codes.Unset, spans[0].Status.Code)
+		}
+	})
+
+	t.Run("Query Failed", func(t *testing.T) {
+		// Arrange
+		exporter := setupSpanExporter()
+
+		tracer := db.NewOtelQueryTracer()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		ctx := tracer.TraceQueryStart(t.Context(), nil, pgx.TraceQueryStartData{SQL: "INSERT INTO categories (id, name) VALUES ($1, $2)"})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("duplicate key value")})
+		// ---------------------------
+
+		// Assert
+		spans := exporter.GetSpans()
+
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, "pgx INSERT", spans[0].Name)
+			assert.Equal(t, codes.Error, spans[0].Status.Code)
+			assert.Equal(t, "duplicate key value", spans[0].Status.Description)
+		}
+	})
+}
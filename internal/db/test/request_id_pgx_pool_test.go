@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+func TestBeginWithRequestId(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("SELECT set_config").WithArgs("req-42").WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	pool.ExpectCommit()
+
+	ctx := helper.ContextWithRequestInfo(context.Background(), &helper.RequestInfo{Id: "req-42"})
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		tx, err := db.NewRequestIdPgxPool(pool).Begin(ctx)
+		// ---------------------------
+		helper.PanicIfError(err)
+
+		helper.TxCommit(ctx, tx)
+	})
+
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestBeginWithoutRequestId(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		tx, err := db.NewRequestIdPgxPool(pool).Begin(context.Background())
+		// ---------------------------
+		helper.PanicIfError(err)
+
+		helper.TxCommit(context.Background(), tx)
+	})
+
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestBeginSetConfigFailed(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectExec("SELECT set_config").WithArgs("req-42").WillReturnError(errors.New("connection reset"))
+	pool.ExpectRollback()
+
+	ctx := helper.ContextWithRequestInfo(context.Background(), &helper.RequestInfo{Id: "req-42"})
+
+	// ---SUT (Subject Under Test)
+	tx, err := db.NewRequestIdPgxPool(pool).Begin(ctx)
+	// ---------------------------
+
+	// Assert
+	assert.Nil(t, tx)
+	assert.EqualError(t, err, "connection reset")
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type ApiKey struct {
+	Id        string   `db:"id"`
+	TenantId  string   `db:"tenant_id"`
+	Name      string   `db:"name"`
+	KeyPrefix string   `db:"key_prefix"`
+	KeyHash   string   `db:"key_hash"`
+	Scopes    []string `db:"scopes"`
+	// SigningSecret is set on the keys which require signed requests
+	SigningSecret *string    `db:"signing_secret"`
+	ExpiresAt     *time.Time `db:"expires_at"`
+	LastUsedAt    *time.Time `db:"last_used_at"`
+	RevokedAt     *time.Time `db:"revoked_at"`
+	RotatedAt     *time.Time `db:"rotated_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type IdempotencyKey struct {
+	Key          string    `db:"key"`
+	RequestHash  string    `db:"request_hash"`
+	ResponseBody []byte    `db:"response_body"`
+	CreatedAt    time.Time `db:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
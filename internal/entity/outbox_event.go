@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type OutboxEvent struct {
+	Id           int64      `db:"id"`
+	TenantId     string     `db:"tenant_id"`
+	EventType    string     `db:"event_type"`
+	AggregateId  string     `db:"aggregate_id"`
+	Payload      []byte     `db:"payload"`
+	CreatedAt    time.Time  `db:"created_at"`
+	DispatchedAt *time.Time `db:"dispatched_at"`
+}
+
+const (
+	EventCategoryCreated = "category.created"
+	EventCategoryUpdated = "category.updated"
+	EventCategoryDeleted = "category.deleted"
+)
@@ -0,0 +1,6 @@
+package entity
+
+type SchemaMigration struct {
+	Version int64 `db:"version"`
+	Dirty   bool  `db:"dirty"`
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+type TableVersion struct {
+	TableName string    `db:"table_name"`
+	TenantId  string    `db:"tenant_id"`
+	Version   int64     `db:"version"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
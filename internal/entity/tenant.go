@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+type Tenant struct {
+	Id        string    `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
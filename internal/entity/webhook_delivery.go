@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+type WebhookDelivery struct {
+	Id             int64      `db:"id"`
+	EventId        int64      `db:"event_id"`
+	SubscriptionId string     `db:"subscription_id"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	LastStatusCode *int       `db:"last_status_code"`
+	LastError      *string    `db:"last_error"`
+	CreatedAt      time.Time  `db:"created_at"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+}
+
+// WebhookDeliveryJob is a due delivery with what the dispatcher needs to send it
+type WebhookDeliveryJob struct {
+	WebhookDelivery
+	EventType string    `db:"event_type"`
+	Payload   []byte    `db:"payload"`
+	EventAt   time.Time `db:"event_at"`
+	Url       string    `db:"url"`
+	Secret    string    `db:"secret"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type WebhookSubscription struct {
+	Id         string    `db:"id"`
+	Url        string    `db:"url"`
+	Secret     string    `db:"secret"`
+	EventTypes []string  `db:"event_types"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -0,0 +1,29 @@
+package exception
+
+type ErrorPointer struct {
+	Location string
+	Pointer  string
+	Message  string
+}
+
+type ErrorValidationRequest struct {
+	ActualError error
+	Detail      string
+	Errors      []ErrorPointer
+}
+
+func NewErrorValidationRequest(err error, detail string, errors []ErrorPointer) *ErrorValidationRequest {
+	return &ErrorValidationRequest{
+		ActualError: err,
+		Detail:      detail,
+		Errors:      errors,
+	}
+}
+
+func (e *ErrorValidationRequest) Error() string {
+	return e.ActualError.Error()
+}
+
+func (e *ErrorValidationRequest) GetDetailError() string {
+	return e.Detail
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/metrics"
 )
 
 func TxCommitRollback(ctx context.Context, tx pgx.Tx) {
@@ -13,10 +14,14 @@ func TxCommitRollback(ctx context.Context, tx pgx.Tx) {
 		errRollback := tx.Rollback(ctx)
 		PanicIfError(errRollback)
 
+		metrics.DbTransactionsTotal.WithLabelValues("rollback").Inc()
+
 		panic(errRecover)
 	} else {
 		errCommit := tx.Commit(ctx)
 		PanicIfError(errCommit)
+
+		metrics.DbTransactionsTotal.WithLabelValues("commit").Inc()
 	}
 }
 
@@ -26,6 +31,9 @@ func TxRollbackIfPanic(ctx context.Context, tx pgx.Tx) {
 	if errRecover != nil {
 		err := tx.Rollback(ctx)
 		PanicIfError(err)
+
+		metrics.DbTransactionsTotal.WithLabelValues("rollback").Inc()
+
 		panic(errRecover)
 	}
 }
@@ -34,6 +42,9 @@ func TxRollbackIfError(ctx context.Context, tx pgx.Tx, err error) {
 	if err != nil {
 		errRollback := tx.Rollback(ctx)
 		PanicIfError(errRollback)
+
+		metrics.DbTransactionsTotal.WithLabelValues("rollback").Inc()
+
 		panic(err)
 	}
 }
@@ -41,4 +52,14 @@ func TxRollbackIfError(ctx context.Context, tx pgx.Tx, err error) {
 func TxCommit(ctx context.Context, tx pgx.Tx) {
 	errCommit := tx.Commit(ctx)
 	PanicIfError(errCommit)
+
+	metrics.DbTransactionsTotal.WithLabelValues("commit").Inc()
+}
+
+// TxSetLocalTenant confines the rest of the transaction to the rows of the
+// tenant. It is SET LOCAL ROLE app_tenant and SET LOCAL app.tenant_id, in the
+// form of set_config, which takes the tenant as a parameter.
+func TxSetLocalTenant(ctx context.Context, tx pgx.Tx, tenantId string) error {
+	_, err := tx.Exec(ctx, "SELECT set_config('role', 'app_tenant', true), set_config('app.tenant_id', $1, true)", tenantId)
+	return err
 }
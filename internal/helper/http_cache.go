@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func StrongETag(anyResponse any) string {
+	responseBytes, err := json.Marshal(anyResponse)
+	PanicIfError(err)
+
+	hash := sha256.Sum256(responseBytes)
+
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// WriteNotModified sets the validators and the cache control of the response.
+// If the request preconditions show that the client already holds the current
+// representation, it writes 304 Not Modified and returns true.
+func WriteNotModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time, cacheControl string) bool {
+	w.Header().Set("etag", etag)
+	w.Header().Set("last-modified", lastModified.UTC().Format(http.TimeFormat))
+
+	if cacheControl != "" {
+		w.Header().Set("cache-control", cacheControl)
+	}
+
+	// If-Modified-Since is ignored when If-None-Match is present (RFC 9110)
+	if ifNoneMatch := r.Header.Get("if-none-match"); ifNoneMatch != "" {
+		if !etagMatches(ifNoneMatch, etag) {
+			return false
+		}
+	} else {
+		ifModifiedSince, err := http.ParseTime(r.Header.Get("if-modified-since"))
+
+		if err != nil || lastModified.Truncate(time.Second).After(ifModifiedSince) {
+			return false
+		}
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+
+	return true
+}
+
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
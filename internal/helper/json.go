@@ -2,12 +2,116 @@ package helper
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strings"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
 )
 
-func ReadFromRequestBody(r *http.Request, anyCreateRequest any) error {
+type RequestBodyOptions struct {
+	MaxBodySize           int64
+	DisallowUnknownFields bool
+}
+
+// A request without a content type is decoded as JSON, the same way the
+// OpenAPI request validation treats it
+func CheckJsonContentType(r *http.Request) error {
+	contentType := r.Header.Get("content-type")
+
+	if contentType == "" {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	if err != nil || mediaType != "application/json" {
+		return exception.NewErrorClientRequest(
+			fmt.Errorf("unsupported content type %q", contentType),
+			http.StatusUnsupportedMediaType,
+			"content type must be application/json",
+		)
+	}
+
+	return nil
+}
+
+func LimitRequestBody(w http.ResponseWriter, r *http.Request, maxBodySize int64) {
+	if maxBodySize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	}
+}
+
+func ReadFromRequestBody(w http.ResponseWriter, r *http.Request, anyCreateRequest any, options RequestBodyOptions) error {
+	if err := CheckJsonContentType(r); err != nil {
+		return err
+	}
+
+	LimitRequestBody(w, r, options.MaxBodySize)
+
 	jsonDecoder := json.NewDecoder(r.Body)
-	return jsonDecoder.Decode(anyCreateRequest)
+
+	if options.DisallowUnknownFields {
+		jsonDecoder.DisallowUnknownFields()
+	}
+
+	if err := jsonDecoder.Decode(anyCreateRequest); err != nil {
+		return requestBodyError(err)
+	}
+
+	offset := jsonDecoder.InputOffset()
+
+	if _, err := jsonDecoder.Token(); !errors.Is(err, io.EOF) {
+		if maxBytesError := new(http.MaxBytesError); errors.As(err, &maxBytesError) {
+			return requestBodyError(err)
+		}
+
+		return exception.NewErrorClientRequest(
+			errors.New("request body has trailing data"),
+			http.StatusBadRequest,
+			fmt.Sprintf("request body must contain a single JSON value, unexpected data at offset %d", offset),
+		)
+	}
+
+	return nil
+}
+
+func requestBodyError(err error) error {
+	var (
+		syntaxError        *json.SyntaxError
+		unmarshalTypeError *json.UnmarshalTypeError
+		maxBytesError      *http.MaxBytesError
+	)
+
+	switch {
+	case errors.As(err, &maxBytesError):
+		return exception.NewErrorClientRequest(err, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body must not be larger than %d bytes", maxBytesError.Limit))
+	case errors.As(err, &syntaxError):
+		return exception.NewErrorClientRequest(err, http.StatusBadRequest,
+			fmt.Sprintf("malformed request body at offset %d", syntaxError.Offset))
+	case errors.As(err, &unmarshalTypeError):
+		if unmarshalTypeError.Field == "" {
+			return exception.NewErrorClientRequest(err, http.StatusBadRequest,
+				fmt.Sprintf("request body must be a JSON object, got %s", unmarshalTypeError.Value))
+		}
+
+		return exception.NewErrorClientRequest(err, http.StatusBadRequest,
+			fmt.Sprintf("request body field %q must be %s, got %s at offset %d",
+				unmarshalTypeError.Field, unmarshalTypeError.Type, unmarshalTypeError.Value, unmarshalTypeError.Offset))
+	case errors.Is(err, io.EOF):
+		return exception.NewErrorClientRequest(err, http.StatusBadRequest, "request body must not be empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return exception.NewErrorClientRequest(err, http.StatusBadRequest, "malformed request body, unexpected end of input")
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return exception.NewErrorClientRequest(err, http.StatusBadRequest,
+			fmt.Sprintf("request body has unknown field %s", strings.TrimPrefix(err.Error(), "json: unknown field ")))
+	}
+
+	return exception.NewErrorClientRequest(err, http.StatusBadRequest, "malformed request body")
 }
 
 func WriteToResponseBody(w http.ResponseWriter, webResponse any) error {
@@ -0,0 +1,21 @@
+package helper
+
+import "sync/atomic"
+
+// Lifecycle is shared between main and the readiness check, so the service
+// reports itself unready as soon as it starts to shut down
+type Lifecycle struct {
+	draining atomic.Bool
+}
+
+func NewLifecycle() *Lifecycle {
+	return new(Lifecycle)
+}
+
+func (l *Lifecycle) Drain() {
+	l.draining.Store(true)
+}
+
+func (l *Lifecycle) IsDraining() bool {
+	return l.draining.Load()
+}
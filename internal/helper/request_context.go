@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RequestInfo is shared by pointer, so the identity set by the auth middleware
+// and the route set by the router are visible to the outer middlewares as well.
+type RequestInfo struct {
+	Id             string
+	Method         string
+	Path           string
+	Route          string
+	ApiKeyIdentity string
+}
+
+type requestInfoKey struct{}
+
+func ContextWithRequestInfo(ctx context.Context, requestInfo *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, requestInfo)
+}
+
+func RequestInfoFromContext(ctx context.Context) *RequestInfo {
+	requestInfo, _ := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return requestInfo
+}
+
+// ContextLogger returns a log entry tagged with the request of the context
+func ContextLogger(logger *logrus.Logger, ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(logger)
+
+	requestInfo := RequestInfoFromContext(ctx)
+
+	if requestInfo == nil {
+		return entry
+	}
+
+	fields := logrus.Fields{
+		"request_id": requestInfo.Id,
+		"method":     requestInfo.Method,
+		"path":       requestInfo.Path,
+	}
+
+	if requestInfo.ApiKeyIdentity != "" {
+		fields["api_key"] = requestInfo.ApiKeyIdentity
+	}
+
+	return entry.WithFields(fields)
+}
+
+// ApiKeyIdentity identifies an API key in the logs without revealing it
+func ApiKeyIdentity(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+
+	return "key_" + hex.EncodeToString(hash[:4])
+}
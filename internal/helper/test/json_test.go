@@ -0,0 +1,146 @@
+package helper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+var requestBodyTestOptions = helper.RequestBodyOptions{
+	MaxBodySize:           32,
+	DisallowUnknownFields: true,
+}
+
+func readCategoryRequest(contentType, requestBody string, options helper.RequestBodyOptions) (*model.CreateCategoryRequest, error) {
+	testRequest := httptest.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader(requestBody))
+
+	if contentType != "" {
+		testRequest.Header.Set("content-type", contentType)
+	}
+
+	categoryRequest := new(model.CreateCategoryRequest)
+
+	// ---SUT (Subject Under Test)
+	err := helper.ReadFromRequestBody(httptest.NewRecorder(), testRequest, categoryRequest, options)
+	// ---------------------------
+
+	return categoryRequest, err
+}
+
+func assertClientError(t *testing.T, err error, statusCode int, detail string) {
+	errorClient, ok := err.(*exception.ErrorClientRequest)
+
+	if assert.True(t, ok, "error must be a client request error") {
+		assert.Equal(t, statusCode, errorClient.StatusCode)
+		assert.Equal(t, detail, errorClient.Detail)
+	}
+}
+
+func TestReadFromRequestBodySuccess(t *testing.T) {
+	t.Run("JSON Content Type", func(t *testing.T) {
+		// Arrange & Action
+		categoryRequest, err := readCategoryRequest("application/json; charset=utf-8", `{"name":"Gadget"}`+"\n", requestBodyTestOptions)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Gadget", categoryRequest.Name)
+	})
+
+	t.Run("Missing Content Type", func(t *testing.T) {
+		// Arrange & Action
+		categoryRequest, err := readCategoryRequest("", `{"name":"Gadget"}`, requestBodyTestOptions)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Gadget", categoryRequest.Name)
+	})
+
+	t.Run("Unknown Field Allowed", func(t *testing.T) {
+		// Arrange & Action
+		categoryRequest, err := readCategoryRequest("application/json", `{"name":"Gadget","color":"red"}`, helper.RequestBodyOptions{})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Gadget", categoryRequest.Name)
+	})
+}
+
+func TestReadFromRequestBodyFailed(t *testing.T) {
+	t.Run("Unsupported Content Type", func(t *testing.T) {
+		// Arrange & Action
+		_, err := readCategoryRequest("text/plain", `{"name":"Gadget"}`, requestBodyTestOptions)
+
+		// Assert
+		assertClientError(t, err, http.StatusUnsupportedMediaType, "content type must be application/json")
+	})
+
+	t.Run("Too Large", func(t *testing.T) {
+		// Arrange & Action
+		_, err := readCategoryRequest("application/json", `{"name":"`+strings.Repeat("a", 64)+`"}`, requestBodyTestOptions)
+
+		// Assert
+		assertClientError(t, err, http.StatusRequestEntityTooLarge, "request body must not be larger than 32 bytes")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		// Arrange & Action
+		_, err := readCategoryRequest("application/json", "", requestBodyTestOptions)
+
+		// Assert
+		assertClientError(t, err, http.StatusBadRequest, "request body must not be empty")
+	})
+
+	t.Run("Syntax Error", func(t *testing.T) {
+		// Arrange & Action
+		_, err := readCategoryRequest("application/json", `{"name" "Gadget"}`, requestBodyTestOptions)
+
+		// Assert
+		assertClientError(t, err, http.StatusBadRequest, "malformed request body at offset 9")
+	})
+
+	t.Run("Unexpected End", func(t *testing.T) {
+		// Arrange & Action
+		_, err := readCategoryRequest("application/json", `{"name":"Gad`, requestBodyTestOptions)
+
+		// Assert
+		assertClientError(t, err, http.StatusBadRequest, "malformed request body, unexpected end of input")
+	})
+
+	t.Run("Wrong Field Type", func(t *testing.T) {
+		// Arrange & Action
+		_, err := readCategoryRequest("application/json", `{"name":10}`, requestBodyTestOptions)
+
+		// Assert
+		assertClientError(t, err, http.StatusBadRequest, `request body field "name" must be string, got number at offset 10`)
+	})
+
+	t.Run("Not An Object", func(t *testing.T) {
+		// Arrange & Action
+		_, err := readCategoryRequest("application/json", `["Gadget"]`, requestBodyTestOptions)
+
+		// Assert
+		assertClientError(t, err, http.StatusBadRequest, "request body must be a JSON object, got array")
+	})
+
+	t.Run("Unknown Field", func(t *testing.T) {
+		// Arrange & Action
+		_, err := readCategoryRequest("application/json", `{"name":"Gadget","color":"red"}`, requestBodyTestOptions)
+
+		// Assert
+		assertClientError(t, err, http.StatusBadRequest, `request body has unknown field "color"`)
+	})
+
+	t.Run("Trailing Data", func(t *testing.T) {
+		// Arrange & Action
+		_, err := readCategoryRequest("application/json", `{"name":"Gadget"} {}`, requestBodyTestOptions)
+
+		// Assert
+		assertClientError(t, err, http.StatusBadRequest, "request body must contain a single JSON value, unexpected data at offset 17")
+	})
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds the metrics of the app only, so the tests and the /metrics
+// endpoint never see the collectors which libraries add to the default one
+var Registry = prometheus.NewRegistry()
+
+var (
+	HttpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by route pattern, method and status.",
+	}, []string{"route", "method", "status"})
+
+	HttpRequestDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of the HTTP requests by route pattern, method and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	HttpPanicsRecoveredTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_panics_recovered_total",
+		Help: "Panics recovered by the panic middleware by error class.",
+	}, []string{"class"})
+
+	DbTransactionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_transactions_total",
+		Help: "Database transactions by outcome, commit or rollback.",
+	}, []string{"outcome"})
+
+	WebhookDeliveriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "webhook_deliveries_total",
+		Help: "Webhook delivery attempts by outcome, delivered, retried or dead.",
+	}, []string{"outcome"})
+
+	CacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_requests_total",
+		Help: "Cache lookups by cache, method and result, hit or miss.",
+	}, []string{"cache", "method", "result"})
+
+	CacheInvalidationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_invalidations_total",
+		Help: "Cache invalidations by cache and source, write, notify or reset.",
+	}, []string{"cache", "source"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HttpRequestsTotal,
+		HttpRequestDurationSeconds,
+		HttpPanicsRecoveredTotal,
+		DbTransactionsTotal,
+		WebhookDeliveriesTotal,
+		CacheRequestsTotal,
+		CacheInvalidationsTotal,
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
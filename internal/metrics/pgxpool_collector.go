@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PoolStater interface {
+	Stat() *pgxpool.Stat
+}
+
+// pgxPoolCollector reads the pool stats on every scrape instead of keeping
+// gauges up to date
+type pgxPoolCollector struct {
+	Pool PoolStater
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireTotal         *prometheus.Desc
+	acquireSeconds       *prometheus.Desc
+	emptyAcquireTotal    *prometheus.Desc
+	emptyAcquireSeconds  *prometheus.Desc
+	canceledAcquireTotal *prometheus.Desc
+}
+
+func NewPgxPoolCollector(pool PoolStater) prometheus.Collector {
+	return &pgxPoolCollector{
+		Pool: pool,
+
+		acquiredConns:        prometheus.NewDesc("pgxpool_acquired_conns", "Connections currently acquired from the pool.", nil, nil),
+		idleConns:            prometheus.NewDesc("pgxpool_idle_conns", "Idle connections in the pool.", nil, nil),
+		totalConns:           prometheus.NewDesc("pgxpool_total_conns", "Connections in the pool, acquired, idle and constructing.", nil, nil),
+		maxConns:             prometheus.NewDesc("pgxpool_max_conns", "Maximum size of the pool.", nil, nil),
+		acquireTotal:         prometheus.NewDesc("pgxpool_acquire_total", "Successful acquires from the pool.", nil, nil),
+		acquireSeconds:       prometheus.NewDesc("pgxpool_acquire_duration_seconds_total", "Time spent acquiring connections from the pool.", nil, nil),
+		emptyAcquireTotal:    prometheus.NewDesc("pgxpool_empty_acquire_total", "Acquires which waited for a connection because the pool was empty.", nil, nil),
+		emptyAcquireSeconds:  prometheus.NewDesc("pgxpool_empty_acquire_wait_seconds_total", "Time spent waiting for a connection because the pool was empty.", nil, nil),
+		canceledAcquireTotal: prometheus.NewDesc("pgxpool_canceled_acquire_total", "Acquires canceled by their context.", nil, nil),
+	}
+}
+
+func (c *pgxPoolCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- c.acquiredConns
+	descs <- c.idleConns
+	descs <- c.totalConns
+	descs <- c.maxConns
+	descs <- c.acquireTotal
+	descs <- c.acquireSeconds
+	descs <- c.emptyAcquireTotal
+	descs <- c.emptyAcquireSeconds
+	descs <- c.canceledAcquireTotal
+}
+
+func (c *pgxPoolCollector) Collect(metrics chan<- prometheus.Metric) {
+	stat := c.Pool.Stat()
+
+	// A pool which keeps no stats, such as a mocked one, has a zero Stat
+	if stat == nil || *stat == (pgxpool.Stat{}) {
+		return
+	}
+
+	metrics <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	metrics <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	metrics <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	metrics <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	metrics <- prometheus.MustNewConstMetric(c.acquireTotal, prometheus.CounterValue, float64(stat.AcquireCount()))
+	metrics <- prometheus.MustNewConstMetric(c.acquireSeconds, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	metrics <- prometheus.MustNewConstMetric(c.emptyAcquireTotal, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	metrics <- prometheus.MustNewConstMetric(c.emptyAcquireSeconds, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+	metrics <- prometheus.MustNewConstMetric(c.canceledAcquireTotal, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
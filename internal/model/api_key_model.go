@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type (
+	CreateApiKeyRequest struct {
+		Name      string     `json:"name" validate:"required,min=3,max=128"`
+		Scopes    []string   `json:"scopes" validate:"required,min=1,max=3,unique,dive,oneof=categories:read categories:write admin"`
+		ExpiresAt *time.Time `json:"expires_at"`
+		TenantId  string     `json:"tenant_id" validate:"omitempty,max=36"`
+		Signed    bool       `json:"signed"`
+	}
+
+	ApiKeyResponse struct {
+		Id       string   `json:"id"`
+		TenantId string   `json:"tenant_id"`
+		Name     string   `json:"name"`
+		Prefix   string   `json:"prefix"`
+		Scopes   []string `json:"scopes"`
+		Key      string   `json:"key,omitempty"`
+		Signed   bool     `json:"signed"`
+		// SigningSecret is answered with the key only
+		SigningSecret string     `json:"signing_secret,omitempty"`
+		ExpiresAt     *time.Time `json:"expires_at"`
+		LastUsedAt    *time.Time `json:"last_used_at"`
+		RevokedAt     *time.Time `json:"revoked_at"`
+		RotatedAt     *time.Time `json:"rotated_at"`
+		CreatedAt     time.Time  `json:"created_at"`
+	}
+)
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type (
 	CategoryResponse struct {
 		Id   string `json:"id"`
@@ -13,4 +18,30 @@ type (
 	UpdateCategoryRequest struct {
 		Name string `json:"name" validate:"required,min=3,max=128"`
 	}
+
+	PageCategoryRequest struct {
+		AfterId string `json:"after_id" validate:"max=36"`
+		Limit   int    `json:"limit" validate:"min=1,max=100"`
+	}
+
+	CategoryVersionResponse struct {
+		TenantId  string    `json:"tenant_id"`
+		Version   int64     `json:"version"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	CategoryPageResponse struct {
+		Categories  []CategoryResponse `json:"categories"`
+		HasNextPage bool               `json:"has_next_page"`
+		TotalCount  int                `json:"total_count"`
+	}
+
+	// CategoryEventResponse is the data of every event of the category stream
+	CategoryEventResponse struct {
+		Id        int64           `json:"id"`
+		TenantId  string          `json:"-"`
+		Type      string          `json:"type"`
+		CreatedAt time.Time       `json:"created_at"`
+		Data      json.RawMessage `json:"data"`
+	}
 )
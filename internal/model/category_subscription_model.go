@@ -0,0 +1,24 @@
+package model
+
+type (
+	// CategorySubscriptionMessage is a message a WebSocket client sends, its
+	// id is echoed in the reply
+	CategorySubscriptionMessage struct {
+		Type         string   `json:"type"`
+		Id           string   `json:"id"`
+		ApiKey       string   `json:"api_key,omitempty"`
+		CategoryIds  []string `json:"category_ids,omitempty"`
+		Subscription string   `json:"subscription,omitempty"`
+	}
+
+	// CategorySubscriptionReply is a message the server sends, an ack, a pong,
+	// an error, or an event with the subscriptions it matches
+	CategorySubscriptionReply struct {
+		Type          string                 `json:"type"`
+		Id            string                 `json:"id,omitempty"`
+		Subscription  string                 `json:"subscription,omitempty"`
+		Subscriptions []string               `json:"subscriptions,omitempty"`
+		Message       string                 `json:"message,omitempty"`
+		Event         *CategoryEventResponse `json:"event,omitempty"`
+	}
+)
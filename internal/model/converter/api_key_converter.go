@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+// ApiKeyToResponse leaves the key and its signing secret out, they are only
+// shown once on creation and on rotation
+func ApiKeyToResponse(apiKey *entity.ApiKey) *model.ApiKeyResponse {
+	return &model.ApiKeyResponse{
+		Id:         apiKey.Id,
+		TenantId:   apiKey.TenantId,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.KeyPrefix,
+		Scopes:     apiKey.Scopes,
+		Signed:     apiKey.SigningSecret != nil,
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+		RotatedAt:  apiKey.RotatedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+func ApiKeysToResponse(apiKeys []entity.ApiKey) []model.ApiKeyResponse {
+	apiKeysResponse := []model.ApiKeyResponse{}
+
+	for _, apiKey := range apiKeys {
+		apiKeysResponse = append(apiKeysResponse, *ApiKeyToResponse(&apiKey))
+	}
+
+	return apiKeysResponse
+}
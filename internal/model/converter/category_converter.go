@@ -21,3 +21,21 @@ func CategoriesToResponse(categories []entity.Category) []model.CategoryResponse
 
 	return categoriesResponse
 }
+
+func TableVersionToCategoryVersionResponse(tableVersion *entity.TableVersion) *model.CategoryVersionResponse {
+	return &model.CategoryVersionResponse{
+		TenantId:  tableVersion.TenantId,
+		Version:   tableVersion.Version,
+		UpdatedAt: tableVersion.UpdatedAt,
+	}
+}
+
+func OutboxEventToCategoryEventResponse(outboxEvent *entity.OutboxEvent) *model.CategoryEventResponse {
+	return &model.CategoryEventResponse{
+		Id:        outboxEvent.Id,
+		TenantId:  outboxEvent.TenantId,
+		Type:      outboxEvent.EventType,
+		CreatedAt: outboxEvent.CreatedAt,
+		Data:      outboxEvent.Payload,
+	}
+}
@@ -0,0 +1,24 @@
+package converter
+
+import (
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+func TenantToResponse(tenant *entity.Tenant) *model.TenantResponse {
+	return &model.TenantResponse{
+		Id:        tenant.Id,
+		Name:      tenant.Name,
+		CreatedAt: tenant.CreatedAt,
+	}
+}
+
+func TenantsToResponse(tenants []entity.Tenant) []model.TenantResponse {
+	tenantsResponse := []model.TenantResponse{}
+
+	for _, tenant := range tenants {
+		tenantsResponse = append(tenantsResponse, *TenantToResponse(&tenant))
+	}
+
+	return tenantsResponse
+}
@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+// WebhookToResponse leaves the secret out, it is only shown once on creation
+func WebhookToResponse(subscription *entity.WebhookSubscription) *model.WebhookResponse {
+	return &model.WebhookResponse{
+		Id:         subscription.Id,
+		Url:        subscription.Url,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func WebhooksToResponse(subscriptions []entity.WebhookSubscription) []model.WebhookResponse {
+	webhooksResponse := []model.WebhookResponse{}
+
+	for _, subscription := range subscriptions {
+		webhooksResponse = append(webhooksResponse, *WebhookToResponse(&subscription))
+	}
+
+	return webhooksResponse
+}
+
+func WebhookDeliveryToResponse(delivery *entity.WebhookDelivery) *model.WebhookDeliveryResponse {
+	return &model.WebhookDeliveryResponse{
+		Id:             delivery.Id,
+		EventId:        delivery.EventId,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func WebhookDeliveriesToResponse(deliveries []entity.WebhookDelivery) []model.WebhookDeliveryResponse {
+	deliveriesResponse := []model.WebhookDeliveryResponse{}
+
+	for _, delivery := range deliveries {
+		deliveriesResponse = append(deliveriesResponse, *WebhookDeliveryToResponse(&delivery))
+	}
+
+	return deliveriesResponse
+}
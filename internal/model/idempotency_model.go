@@ -0,0 +1,5 @@
+package model
+
+type IdempotencyKeyRequest struct {
+	Key string `validate:"required,max=255,printascii"`
+}
@@ -11,3 +11,16 @@ type WebResponseMessage struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
 }
+
+type WebResponseErrors struct {
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Errors  []ErrorDetail `json:"errors"`
+}
+
+type ErrorDetail struct {
+	Location string `json:"location"`
+	Pointer  string `json:"pointer"`
+	Message  string `json:"message"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type (
+	CreateTenantRequest struct {
+		Name string `json:"name" validate:"required,min=3,max=128"`
+	}
+
+	TenantResponse struct {
+		Id        string    `json:"id"`
+		Name      string    `json:"name"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type (
+	CreateWebhookRequest struct {
+		Url        string   `json:"url" validate:"required,http_url,max=2048"`
+		EventTypes []string `json:"event_types" validate:"required,min=1,max=3,unique,dive,oneof=category.created category.updated category.deleted"`
+	}
+
+	WebhookResponse struct {
+		Id         string    `json:"id"`
+		Url        string    `json:"url"`
+		EventTypes []string  `json:"event_types"`
+		Secret     string    `json:"secret,omitempty"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	WebhookDeliveryResponse struct {
+		Id             int64      `json:"id"`
+		EventId        int64      `json:"event_id"`
+		Status         string     `json:"status"`
+		Attempts       int        `json:"attempts"`
+		NextAttemptAt  time.Time  `json:"next_attempt_at"`
+		LastStatusCode *int       `json:"last_status_code"`
+		LastError      *string    `json:"last_error"`
+		CreatedAt      time.Time  `json:"created_at"`
+		DeliveredAt    *time.Time `json:"delivered_at"`
+	}
+
+	// WebhookEvent is the body of every webhook request
+	WebhookEvent struct {
+		Id        int64           `json:"id"`
+		Type      string          `json:"type"`
+		CreatedAt time.Time       `json:"created_at"`
+		Data      json.RawMessage `json:"data"`
+	}
+)
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+// memoryLimiterImpl keeps the buckets of a single instance, the buckets which
+// are full again are dropped, they are the same as new ones
+type memoryLimiterImpl struct {
+	mutex   sync.Mutex
+	buckets map[string]*bucket
+	sweptAt time.Time
+}
+
+func NewMemoryLimiterImpl() Limiter {
+	return &memoryLimiterImpl{
+		buckets: map[string]*bucket{},
+		sweptAt: time.Now(),
+	}
+}
+
+func (l *memoryLimiterImpl) Allow(_ context.Context, key string, limit Limit) (*Result, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+
+	l.sweep(now)
+
+	currentBucket, ok := l.buckets[key]
+
+	if !ok {
+		currentBucket = &bucket{
+			tokens:    float64(limit.Requests),
+			updatedAt: now,
+		}
+
+		l.buckets[key] = currentBucket
+	}
+
+	rate := limit.tokensPerMillisecond()
+
+	elapsed := float64(now.Sub(currentBucket.updatedAt).Milliseconds())
+	currentBucket.tokens = min(float64(limit.Requests), currentBucket.tokens+elapsed*rate)
+	currentBucket.updatedAt = now
+
+	allowed := currentBucket.tokens >= 1
+
+	if allowed {
+		currentBucket.tokens--
+	}
+
+	result := newResult(limit, currentBucket.tokens, allowed)
+	currentBucket.fullAt = now.Add(result.Reset)
+
+	return result, nil
+}
+
+func (l *memoryLimiterImpl) sweep(now time.Time) {
+	if now.Sub(l.sweptAt) < time.Minute {
+		return
+	}
+
+	for key, currentBucket := range l.buckets {
+		if !now.Before(currentBucket.fullAt) {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.sweptAt = now
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// The bucket is refilled and taken from in one script, so the instances which
+// share the Redis never race on it. The tokens are returned as a string,
+// Redis truncates the Lua numbers to integers.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated_at")
+local tokens = tonumber(state[1])
+local updatedAt = tonumber(state[2])
+
+if tokens == nil then
+	tokens = capacity
+	updatedAt = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - updatedAt) * rate)
+
+local allowed = 0
+
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated_at", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil((capacity - tokens) / rate) + 1000)
+
+return {allowed, tostring(tokens)}
+`)
+
+type redisLimiterImpl struct {
+	Client redis.Scripter
+}
+
+func NewRedisLimiterImpl(client redis.Scripter) Limiter {
+	return &redisLimiterImpl{
+		Client: client,
+	}
+}
+
+func (l *redisLimiterImpl) Allow(ctx context.Context, key string, limit Limit) (*Result, error) {
+	reply, err := tokenBucketScript.Run(ctx, l.Client, []string{key},
+		limit.Requests,
+		strconv.FormatFloat(limit.tokensPerMillisecond(), 'f', -1, 64),
+		time.Now().UnixMilli(),
+	).Slice()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := strconv.ParseFloat(reply[1].(string), 64)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newResult(limit, tokens, reply[0].(int64) == 1), nil
+}
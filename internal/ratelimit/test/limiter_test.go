@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/ratelimit"
+)
+
+func setupLimiters(t *testing.T) map[string]ratelimit.Limiter {
+	redisServer := miniredis.RunT(t)
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: redisServer.Addr(),
+	})
+
+	t.Cleanup(func() {
+		redisClient.Close()
+	})
+
+	return map[string]ratelimit.Limiter{
+		"Memory": ratelimit.NewMemoryLimiterImpl(),
+		"Redis":  ratelimit.NewRedisLimiterImpl(redisClient),
+	}
+}
+
+func TestAllowUntilEmpty(t *testing.T) {
+	for name, limiter := range setupLimiters(t) {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			limit := ratelimit.Limit{Requests: 3, Period: time.Minute}
+
+			results := []*ratelimit.Result{}
+
+			// Action
+			for range 4 {
+				// ---SUT (Subject Under Test)
+				result, err := limiter.Allow(context.Background(), "key_1", limit)
+				// ---------------------------
+				helper.PanicIfError(err)
+
+				results = append(results, result)
+			}
+
+			// Assert
+			for i, remaining := range []int{2, 1, 0} {
+				assert.True(t, results[i].Allowed)
+				assert.Equal(t, 3, results[i].Limit)
+				assert.Equal(t, remaining, results[i].Remaining)
+				assert.Zero(t, results[i].RetryAfter)
+			}
+
+			assert.False(t, results[3].Allowed)
+			assert.Equal(t, 0, results[3].Remaining)
+
+			// One token comes back every 20 seconds
+			assert.InDelta(t, 20*time.Second, results[3].RetryAfter, float64(time.Second))
+			assert.InDelta(t, time.Minute, results[3].Reset, float64(time.Second))
+		})
+	}
+}
+
+func TestAllowSeparatesKeys(t *testing.T) {
+	for name, limiter := range setupLimiters(t) {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			limit := ratelimit.Limit{Requests: 1, Period: time.Minute}
+
+			_, err := limiter.Allow(context.Background(), "key_1", limit)
+			helper.PanicIfError(err)
+
+			// ---SUT (Subject Under Test)
+			result, err := limiter.Allow(context.Background(), "key_2", limit)
+			// ---------------------------
+			helper.PanicIfError(err)
+
+			// Assert
+			assert.True(t, result.Allowed)
+		})
+	}
+}
+
+func TestAllowRefills(t *testing.T) {
+	for name, limiter := range setupLimiters(t) {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			limit := ratelimit.Limit{Requests: 2, Period: 100 * time.Millisecond}
+
+			for range 2 {
+				_, err := limiter.Allow(context.Background(), "key_1", limit)
+				helper.PanicIfError(err)
+			}
+
+			result, err := limiter.Allow(context.Background(), "key_1", limit)
+			helper.PanicIfError(err)
+
+			assert.False(t, result.Allowed)
+
+			time.Sleep(result.RetryAfter + 10*time.Millisecond)
+
+			// ---SUT (Subject Under Test)
+			result, err = limiter.Allow(context.Background(), "key_1", limit)
+			// ---------------------------
+			helper.PanicIfError(err)
+
+			// Assert
+			assert.True(t, result.Allowed)
+		})
+	}
+}
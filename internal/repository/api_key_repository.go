@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type ApiKeyRepository interface {
+	Save(ctx context.Context, tx pgx.Tx, apiKey *entity.ApiKey) *entity.ApiKey
+	UpdateKey(ctx context.Context, tx pgx.Tx, apiKey *entity.ApiKey) *entity.ApiKey
+	Revoke(ctx context.Context, tx pgx.Tx, apiKeyId string)
+	TouchLastUsed(ctx context.Context, tx pgx.Tx, apiKeyId string)
+	FindById(ctx context.Context, tx pgx.Tx, apiKeyId string) *entity.ApiKey
+	FindByHash(ctx context.Context, tx pgx.Tx, keyHash string) *entity.ApiKey
+	FindAll(ctx context.Context, tx pgx.Tx, tenantId string) []entity.ApiKey
+}
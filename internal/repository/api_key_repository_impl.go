@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const apiKeyColumns = "id, tenant_id, name, key_prefix, key_hash, scopes, signing_secret, expires_at, last_used_at, revoked_at, rotated_at, created_at"
+
+type apiKeyRepositoryImpl struct {
+	IdGenerator security.IdGenerator
+}
+
+func NewApiKeyRepositoryImpl(idGenerator security.IdGenerator) ApiKeyRepository {
+	return &apiKeyRepositoryImpl{
+		IdGenerator: idGenerator,
+	}
+}
+
+func (r *apiKeyRepositoryImpl) Save(ctx context.Context, tx pgx.Tx, apiKey *entity.ApiKey) *entity.ApiKey {
+	for {
+		generatedId, err := r.IdGenerator.Generate(36)
+		helper.InternalServerPanicIfError(err, "api key > repository > Save")
+
+		rows, err := tx.Query(
+			ctx,
+			`INSERT INTO api_keys (id, tenant_id, name, key_prefix, key_hash, scopes, signing_secret, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING created_at`,
+			generatedId, apiKey.TenantId, apiKey.Name, apiKey.KeyPrefix, apiKey.KeyHash, apiKey.Scopes, apiKey.SigningSecret, apiKey.ExpiresAt,
+		)
+		helper.InternalServerPanicIfError(err, "api key > repository > Save")
+
+		createdAts, err := pgx.CollectRows(rows, pgx.RowTo[time.Time])
+		helper.InternalServerPanicIfError(err, "api key > repository > Save")
+
+		if len(createdAts) == 1 {
+			apiKey.Id = generatedId
+			apiKey.CreatedAt = createdAts[0]
+			break
+		}
+	}
+
+	return apiKey
+}
+
+// UpdateKey replaces the hash and the signing secret of a rotated key, the old
+// key stops working once the transaction commits
+func (r *apiKeyRepositoryImpl) UpdateKey(ctx context.Context, tx pgx.Tx, apiKey *entity.ApiKey) *entity.ApiKey {
+	rows, err := tx.Query(
+		ctx,
+		`UPDATE api_keys SET key_prefix = $2, key_hash = $3, signing_secret = $4, rotated_at = now() WHERE id = $1
+		RETURNING `+apiKeyColumns,
+		apiKey.Id, apiKey.KeyPrefix, apiKey.KeyHash, apiKey.SigningSecret,
+	)
+	helper.InternalServerPanicIfError(err, "api key > repository > UpdateKey")
+
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[entity.ApiKey])
+	helper.InternalServerPanicIfError(err, "api key > repository > UpdateKey")
+
+	return result
+}
+
+// Revoke keeps the row, so the revoked keys still show in the list
+func (r *apiKeyRepositoryImpl) Revoke(ctx context.Context, tx pgx.Tx, apiKeyId string) {
+	_, err := tx.Exec(ctx, "UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL", apiKeyId)
+	helper.InternalServerPanicIfError(err, "api key > repository > Revoke")
+}
+
+// TouchLastUsed writes at most once a minute per key, so the keys used on every
+// request do not turn each of them into a write
+func (r *apiKeyRepositoryImpl) TouchLastUsed(ctx context.Context, tx pgx.Tx, apiKeyId string) {
+	_, err := tx.Exec(
+		ctx,
+		`UPDATE api_keys SET last_used_at = now()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')`,
+		apiKeyId,
+	)
+	helper.InternalServerPanicIfError(err, "api key > repository > TouchLastUsed")
+}
+
+func (r *apiKeyRepositoryImpl) FindById(ctx context.Context, tx pgx.Tx, apiKeyId string) *entity.ApiKey {
+	rows, err := tx.Query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE id = $1", apiKeyId)
+	helper.InternalServerPanicIfError(err, "api key > repository > FindById")
+
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[entity.ApiKey])
+	helper.ClientPanicIfError(err, exception.NewErrorClientRequest(err, http.StatusNotFound, "api key is not found"))
+
+	return result
+}
+
+// FindByHash returns nil when no key has the hash
+func (r *apiKeyRepositoryImpl) FindByHash(ctx context.Context, tx pgx.Tx, keyHash string) *entity.ApiKey {
+	rows, err := tx.Query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = $1", keyHash)
+	helper.InternalServerPanicIfError(err, "api key > repository > FindByHash")
+
+	result, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[entity.ApiKey])
+	helper.InternalServerPanicIfError(err, "api key > repository > FindByHash")
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result[0]
+}
+
+// FindAll lists the keys of the tenant, or of every tenant for an empty one
+func (r *apiKeyRepositoryImpl) FindAll(ctx context.Context, tx pgx.Tx, tenantId string) []entity.ApiKey {
+	rows, err := tx.Query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE $1 = '' OR tenant_id = $1 ORDER BY created_at, id", tenantId)
+	helper.InternalServerPanicIfError(err, "api key > repository > FindAll")
+
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.ApiKey])
+	helper.InternalServerPanicIfError(err, "api key > repository > FindAll")
+
+	return result
+}
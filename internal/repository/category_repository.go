@@ -14,4 +14,8 @@ type CategoryRepository interface {
 	Delete(ctx context.Context, tx pgx.Tx, categoryId string)
 	FindById(ctx context.Context, tx pgx.Tx, categoryId string) *entity.Category
 	FindAll(ctx context.Context, tx pgx.Tx) []entity.Category
+	FindByIds(ctx context.Context, tx pgx.Tx, categoryIds []string) []entity.Category
+	FindPage(ctx context.Context, tx pgx.Tx, afterId string, limit int) []entity.Category
+	Count(ctx context.Context, tx pgx.Tx) int
+	FindVersion(ctx context.Context, tx pgx.Tx) *entity.TableVersion
 }
@@ -77,3 +77,52 @@ func (r *categoryRepositoryImpl) FindAll(ctx context.Context, tx pgx.Tx) []entit
 
 	return result
 }
+
+func (r *categoryRepositoryImpl) FindByIds(ctx context.Context, tx pgx.Tx, categoryIds []string) []entity.Category {
+	rows, err := tx.Query(ctx, "SELECT id, name FROM categories WHERE id = ANY($1)", categoryIds)
+	helper.InternalServerPanicIfError(err, "category > repository > FindByIds")
+
+	defer rows.Close()
+
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Category])
+	helper.InternalServerPanicIfError(err, "category > repository > FindByIds")
+
+	return result
+}
+
+func (r *categoryRepositoryImpl) FindPage(ctx context.Context, tx pgx.Tx, afterId string, limit int) []entity.Category {
+	rows, err := tx.Query(ctx, "SELECT id, name FROM categories WHERE id > $1 ORDER BY id LIMIT $2", afterId, limit)
+	helper.InternalServerPanicIfError(err, "category > repository > FindPage")
+
+	defer rows.Close()
+
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Category])
+	helper.InternalServerPanicIfError(err, "category > repository > FindPage")
+
+	return result
+}
+
+func (r *categoryRepositoryImpl) Count(ctx context.Context, tx pgx.Tx) int {
+	var result int
+
+	err := tx.QueryRow(ctx, "SELECT count(*) FROM categories").Scan(&result)
+	helper.InternalServerPanicIfError(err, "category > repository > Count")
+
+	return result
+}
+
+func (r *categoryRepositoryImpl) FindVersion(ctx context.Context, tx pgx.Tx) *entity.TableVersion {
+	result := new(entity.TableVersion)
+
+	// A tenant without a write yet has no row, it reads the version 0
+	err := tx.QueryRow(
+		ctx,
+		`WITH tenant AS (SELECT coalesce(nullif(current_setting('app.tenant_id', true), ''), 'default') AS id)
+		SELECT 'categories', tenant.id, coalesce(max(version), 0), coalesce(max(updated_at), to_timestamp(0))
+		FROM tenant LEFT JOIN table_versions ON table_name = 'categories' AND tenant_id = tenant.id
+		GROUP BY tenant.id`,
+	).Scan(&result.TableName, &result.TenantId, &result.Version, &result.UpdatedAt)
+	helper.InternalServerPanicIfError(err, "category > repository > FindVersion")
+
+	return result
+}
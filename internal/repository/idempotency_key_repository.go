@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type IdempotencyKeyRepository interface {
+	Lock(ctx context.Context, tx pgx.Tx, key string)
+	FindByKey(ctx context.Context, tx pgx.Tx, key string) *entity.IdempotencyKey
+	Save(ctx context.Context, tx pgx.Tx, idempotencyKey *entity.IdempotencyKey) *entity.IdempotencyKey
+	DeleteExpired(ctx context.Context, tx pgx.Tx)
+}
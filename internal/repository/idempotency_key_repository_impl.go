@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type idempotencyKeyRepositoryImpl struct {
+	AppConfig *config.AppConfig
+}
+
+func NewIdempotencyKeyRepositoryImpl(appConfig *config.AppConfig) IdempotencyKeyRepository {
+	return &idempotencyKeyRepositoryImpl{
+		AppConfig: appConfig,
+	}
+}
+
+// Lock holds the key until the transaction ends, so the concurrent retries of
+// a request wait for the first one instead of inserting twice
+func (r *idempotencyKeyRepositoryImpl) Lock(ctx context.Context, tx pgx.Tx, key string) {
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtextextended($1, 0))", key)
+	helper.InternalServerPanicIfError(err, "idempotency key > repository > Lock")
+}
+
+func (r *idempotencyKeyRepositoryImpl) FindByKey(ctx context.Context, tx pgx.Tx, key string) *entity.IdempotencyKey {
+	rows, err := tx.Query(ctx, "SELECT key, request_hash, response_body, created_at, expires_at FROM idempotency_keys WHERE key = $1 AND expires_at > now()", key)
+	helper.InternalServerPanicIfError(err, "idempotency key > repository > FindByKey")
+
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[entity.IdempotencyKey])
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	helper.InternalServerPanicIfError(err, "idempotency key > repository > FindByKey")
+
+	return result
+}
+
+func (r *idempotencyKeyRepositoryImpl) Save(ctx context.Context, tx pgx.Tx, idempotencyKey *entity.IdempotencyKey) *entity.IdempotencyKey {
+	ttl := r.AppConfig.Idempotency.TTL * time.Hour
+
+	// An expired key which has not been deleted yet is taken over
+	err := tx.QueryRow(
+		ctx,
+		`INSERT INTO idempotency_keys (key, request_hash, response_body, expires_at)
+		VALUES ($1, $2, $3, now() + $4 * interval '1 second')
+		ON CONFLICT (tenant_id, key) DO UPDATE SET
+			request_hash = excluded.request_hash,
+			response_body = excluded.response_body,
+			created_at = excluded.created_at,
+			expires_at = excluded.expires_at
+		WHERE idempotency_keys.expires_at <= now()
+		RETURNING created_at, expires_at`,
+		idempotencyKey.Key, idempotencyKey.RequestHash, idempotencyKey.ResponseBody, ttl.Seconds(),
+	).Scan(&idempotencyKey.CreatedAt, &idempotencyKey.ExpiresAt)
+	helper.InternalServerPanicIfError(err, "idempotency key > repository > Save")
+
+	return idempotencyKey
+}
+
+func (r *idempotencyKeyRepositoryImpl) DeleteExpired(ctx context.Context, tx pgx.Tx) {
+	_, err := tx.Exec(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= now()")
+	helper.InternalServerPanicIfError(err, "idempotency key > repository > DeleteExpired")
+}
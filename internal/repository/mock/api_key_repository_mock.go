@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+type apiKeyRepositoryMock struct {
+	Mock *mock.Mock
+}
+
+func NewApiKeyRepositoryMock() *apiKeyRepositoryMock {
+	return &apiKeyRepositoryMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (r *apiKeyRepositoryMock) Save(ctx context.Context, tx pgx.Tx, apiKey *entity.ApiKey) *entity.ApiKey {
+	args := r.Mock.Called(ctx, tx, apiKey)
+	return args.Get(0).(*entity.ApiKey)
+}
+
+func (r *apiKeyRepositoryMock) UpdateKey(ctx context.Context, tx pgx.Tx, apiKey *entity.ApiKey) *entity.ApiKey {
+	args := r.Mock.Called(ctx, tx, apiKey)
+	return args.Get(0).(*entity.ApiKey)
+}
+
+func (r *apiKeyRepositoryMock) Revoke(ctx context.Context, tx pgx.Tx, apiKeyId string) {
+	r.Mock.Called(ctx, tx, apiKeyId)
+}
+
+func (r *apiKeyRepositoryMock) TouchLastUsed(ctx context.Context, tx pgx.Tx, apiKeyId string) {
+	r.Mock.Called(ctx, tx, apiKeyId)
+}
+
+func (r *apiKeyRepositoryMock) FindById(ctx context.Context, tx pgx.Tx, apiKeyId string) *entity.ApiKey {
+	args := r.Mock.Called(ctx, tx, apiKeyId)
+	return args.Get(0).(*entity.ApiKey)
+}
+
+func (r *apiKeyRepositoryMock) FindByHash(ctx context.Context, tx pgx.Tx, keyHash string) *entity.ApiKey {
+	args := r.Mock.Called(ctx, tx, keyHash)
+	apiKey, _ := args.Get(0).(*entity.ApiKey)
+	return apiKey
+}
+
+func (r *apiKeyRepositoryMock) FindAll(ctx context.Context, tx pgx.Tx, tenantId string) []entity.ApiKey {
+	args := r.Mock.Called(ctx, tx, tenantId)
+	return args.Get(0).([]entity.ApiKey)
+}
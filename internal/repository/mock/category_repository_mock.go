@@ -42,3 +42,23 @@ func (r *categoryRepositoryMock) FindAll(ctx context.Context, tx pgx.Tx) []entit
 	args := r.Mock.Called(ctx, tx)
 	return args.Get(0).([]entity.Category)
 }
+
+func (r *categoryRepositoryMock) FindByIds(ctx context.Context, tx pgx.Tx, categoryIds []string) []entity.Category {
+	args := r.Mock.Called(ctx, tx, categoryIds)
+	return args.Get(0).([]entity.Category)
+}
+
+func (r *categoryRepositoryMock) FindPage(ctx context.Context, tx pgx.Tx, afterId string, limit int) []entity.Category {
+	args := r.Mock.Called(ctx, tx, afterId, limit)
+	return args.Get(0).([]entity.Category)
+}
+
+func (r *categoryRepositoryMock) Count(ctx context.Context, tx pgx.Tx) int {
+	args := r.Mock.Called(ctx, tx)
+	return args.Int(0)
+}
+
+func (r *categoryRepositoryMock) FindVersion(ctx context.Context, tx pgx.Tx) *entity.TableVersion {
+	args := r.Mock.Called(ctx, tx)
+	return args.Get(0).(*entity.TableVersion)
+}
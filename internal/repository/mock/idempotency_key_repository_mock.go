@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+type idempotencyKeyRepositoryMock struct {
+	Mock *mock.Mock
+}
+
+func NewIdempotencyKeyRepositoryMock() *idempotencyKeyRepositoryMock {
+	return &idempotencyKeyRepositoryMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (r *idempotencyKeyRepositoryMock) Lock(ctx context.Context, tx pgx.Tx, key string) {
+	r.Mock.Called(ctx, tx, key)
+}
+
+func (r *idempotencyKeyRepositoryMock) FindByKey(ctx context.Context, tx pgx.Tx, key string) *entity.IdempotencyKey {
+	args := r.Mock.Called(ctx, tx, key)
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*entity.IdempotencyKey)
+}
+
+func (r *idempotencyKeyRepositoryMock) Save(ctx context.Context, tx pgx.Tx, idempotencyKey *entity.IdempotencyKey) *entity.IdempotencyKey {
+	args := r.Mock.Called(ctx, tx, idempotencyKey)
+	return args.Get(0).(*entity.IdempotencyKey)
+}
+
+func (r *idempotencyKeyRepositoryMock) DeleteExpired(ctx context.Context, tx pgx.Tx) {
+	r.Mock.Called(ctx, tx)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+type outboxEventRepositoryMock struct {
+	Mock *mock.Mock
+}
+
+func NewOutboxEventRepositoryMock() *outboxEventRepositoryMock {
+	return &outboxEventRepositoryMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (r *outboxEventRepositoryMock) Save(ctx context.Context, tx pgx.Tx, outboxEvent *entity.OutboxEvent) *entity.OutboxEvent {
+	args := r.Mock.Called(ctx, tx, outboxEvent)
+	return args.Get(0).(*entity.OutboxEvent)
+}
+
+func (r *outboxEventRepositoryMock) FanOut(ctx context.Context, tx pgx.Tx, limit int) int {
+	args := r.Mock.Called(ctx, tx, limit)
+	return args.Int(0)
+}
+
+func (r *outboxEventRepositoryMock) FindLatest(ctx context.Context, tx pgx.Tx, eventTypes []string, limit int) []entity.OutboxEvent {
+	args := r.Mock.Called(ctx, tx, eventTypes, limit)
+	return args.Get(0).([]entity.OutboxEvent)
+}
+
+func (r *outboxEventRepositoryMock) Prune(ctx context.Context, tx pgx.Tx, retention time.Duration, limit int) int {
+	args := r.Mock.Called(ctx, tx, retention, limit)
+	return args.Int(0)
+}
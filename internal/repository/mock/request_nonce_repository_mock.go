@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+type requestNonceRepositoryMock struct {
+	Mock *mock.Mock
+}
+
+func NewRequestNonceRepositoryMock() *requestNonceRepositoryMock {
+	return &requestNonceRepositoryMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (r *requestNonceRepositoryMock) Save(ctx context.Context, tx pgx.Tx, apiKeyId string, nonce string) bool {
+	args := r.Mock.Called(ctx, tx, apiKeyId, nonce)
+
+	return args.Bool(0)
+}
+
+func (r *requestNonceRepositoryMock) DeleteExpired(ctx context.Context, tx pgx.Tx) {
+	r.Mock.Called(ctx, tx)
+}
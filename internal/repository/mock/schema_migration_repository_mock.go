@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+type schemaMigrationRepositoryMock struct {
+	Mock *mock.Mock
+}
+
+func NewSchemaMigrationRepositoryMock() *schemaMigrationRepositoryMock {
+	return &schemaMigrationRepositoryMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (r *schemaMigrationRepositoryMock) FindVersion(ctx context.Context, tx pgx.Tx) *entity.SchemaMigration {
+	args := r.Mock.Called(ctx, tx)
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*entity.SchemaMigration)
+}
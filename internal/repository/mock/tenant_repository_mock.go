@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+type tenantRepositoryMock struct {
+	Mock *mock.Mock
+}
+
+func NewTenantRepositoryMock() *tenantRepositoryMock {
+	return &tenantRepositoryMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (r *tenantRepositoryMock) Save(ctx context.Context, tx pgx.Tx, tenant *entity.Tenant) *entity.Tenant {
+	args := r.Mock.Called(ctx, tx, tenant)
+	return args.Get(0).(*entity.Tenant)
+}
+
+func (r *tenantRepositoryMock) FindById(ctx context.Context, tx pgx.Tx, tenantId string) *entity.Tenant {
+	args := r.Mock.Called(ctx, tx, tenantId)
+	return args.Get(0).(*entity.Tenant)
+}
+
+func (r *tenantRepositoryMock) FindAll(ctx context.Context, tx pgx.Tx) []entity.Tenant {
+	args := r.Mock.Called(ctx, tx)
+	return args.Get(0).([]entity.Tenant)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+type webhookDeliveryRepositoryMock struct {
+	Mock *mock.Mock
+}
+
+func NewWebhookDeliveryRepositoryMock() *webhookDeliveryRepositoryMock {
+	return &webhookDeliveryRepositoryMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (r *webhookDeliveryRepositoryMock) ClaimDue(ctx context.Context, tx pgx.Tx, limit int, lease time.Duration) []entity.WebhookDeliveryJob {
+	args := r.Mock.Called(ctx, tx, limit, lease)
+	return args.Get(0).([]entity.WebhookDeliveryJob)
+}
+
+func (r *webhookDeliveryRepositoryMock) Update(ctx context.Context, tx pgx.Tx, delivery *entity.WebhookDelivery) *entity.WebhookDelivery {
+	args := r.Mock.Called(ctx, tx, delivery)
+	return args.Get(0).(*entity.WebhookDelivery)
+}
+
+func (r *webhookDeliveryRepositoryMock) FindById(ctx context.Context, tx pgx.Tx, subscriptionId string, deliveryId int64) *entity.WebhookDelivery {
+	args := r.Mock.Called(ctx, tx, subscriptionId, deliveryId)
+	return args.Get(0).(*entity.WebhookDelivery)
+}
+
+func (r *webhookDeliveryRepositoryMock) FindBySubscriptionId(ctx context.Context, tx pgx.Tx, subscriptionId string, limit int) []entity.WebhookDelivery {
+	args := r.Mock.Called(ctx, tx, subscriptionId, limit)
+	return args.Get(0).([]entity.WebhookDelivery)
+}
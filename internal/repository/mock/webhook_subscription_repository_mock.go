@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+type webhookSubscriptionRepositoryMock struct {
+	Mock *mock.Mock
+}
+
+func NewWebhookSubscriptionRepositoryMock() *webhookSubscriptionRepositoryMock {
+	return &webhookSubscriptionRepositoryMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (r *webhookSubscriptionRepositoryMock) Save(ctx context.Context, tx pgx.Tx, subscription *entity.WebhookSubscription) *entity.WebhookSubscription {
+	args := r.Mock.Called(ctx, tx, subscription)
+	return args.Get(0).(*entity.WebhookSubscription)
+}
+
+func (r *webhookSubscriptionRepositoryMock) Delete(ctx context.Context, tx pgx.Tx, subscriptionId string) {
+	r.Mock.Called(ctx, tx, subscriptionId)
+}
+
+func (r *webhookSubscriptionRepositoryMock) FindById(ctx context.Context, tx pgx.Tx, subscriptionId string) *entity.WebhookSubscription {
+	args := r.Mock.Called(ctx, tx, subscriptionId)
+	return args.Get(0).(*entity.WebhookSubscription)
+}
+
+func (r *webhookSubscriptionRepositoryMock) FindAll(ctx context.Context, tx pgx.Tx) []entity.WebhookSubscription {
+	args := r.Mock.Called(ctx, tx)
+	return args.Get(0).([]entity.WebhookSubscription)
+}
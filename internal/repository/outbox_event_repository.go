@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type OutboxEventRepository interface {
+	Save(ctx context.Context, tx pgx.Tx, outboxEvent *entity.OutboxEvent) *entity.OutboxEvent
+	FanOut(ctx context.Context, tx pgx.Tx, limit int) int
+	FindLatest(ctx context.Context, tx pgx.Tx, eventTypes []string, limit int) []entity.OutboxEvent
+	Prune(ctx context.Context, tx pgx.Tx, retention time.Duration, limit int) int
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type outboxEventRepositoryImpl struct{}
+
+func NewOutboxEventRepositoryImpl() OutboxEventRepository {
+	return new(outboxEventRepositoryImpl)
+}
+
+func (r *outboxEventRepositoryImpl) Save(ctx context.Context, tx pgx.Tx, outboxEvent *entity.OutboxEvent) *entity.OutboxEvent {
+	err := tx.QueryRow(
+		ctx,
+		"INSERT INTO outbox_events (event_type, aggregate_id, payload) VALUES ($1, $2, $3) RETURNING id, created_at",
+		outboxEvent.EventType, outboxEvent.AggregateId, outboxEvent.Payload,
+	).Scan(&outboxEvent.Id, &outboxEvent.CreatedAt)
+	helper.InternalServerPanicIfError(err, "outbox event > repository > Save")
+
+	return outboxEvent
+}
+
+// FanOut queues a delivery of the oldest undispatched events for every
+// subscription of their tenant and type, and reports how many events it
+// dispatched. The events locked by another dispatcher are skipped.
+func (r *outboxEventRepositoryImpl) FanOut(ctx context.Context, tx pgx.Tx, limit int) int {
+	commandTag, err := tx.Exec(
+		ctx,
+		`WITH events AS (
+			SELECT id, tenant_id, event_type FROM outbox_events
+			WHERE dispatched_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), deliveries AS (
+			INSERT INTO webhook_deliveries (event_id, subscription_id)
+			SELECT events.id, webhook_subscriptions.id FROM events
+			JOIN webhook_subscriptions ON events.tenant_id = webhook_subscriptions.tenant_id
+				AND events.event_type = ANY(webhook_subscriptions.event_types)
+			ON CONFLICT (event_id, subscription_id) DO NOTHING
+		)
+		UPDATE outbox_events SET dispatched_at = now()
+		WHERE id IN (SELECT id FROM events)`,
+		limit,
+	)
+	helper.InternalServerPanicIfError(err, "outbox event > repository > FanOut")
+
+	return int(commandTag.RowsAffected())
+}
+
+// FindLatest lists the latest events of the types, the oldest of them first
+func (r *outboxEventRepositoryImpl) FindLatest(ctx context.Context, tx pgx.Tx, eventTypes []string, limit int) []entity.OutboxEvent {
+	rows, err := tx.Query(
+		ctx,
+		`SELECT id, tenant_id, event_type, aggregate_id, payload, created_at, dispatched_at FROM (
+			SELECT id, tenant_id, event_type, aggregate_id, payload, created_at, dispatched_at FROM outbox_events
+			WHERE event_type = ANY($1)
+			ORDER BY id DESC
+			LIMIT $2
+		) AS latest_events ORDER BY id`,
+		eventTypes, limit,
+	)
+	helper.InternalServerPanicIfError(err, "outbox event > repository > FindLatest")
+
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.OutboxEvent])
+	helper.InternalServerPanicIfError(err, "outbox event > repository > FindLatest")
+
+	return result
+}
+
+// Prune deletes the oldest events dispatched longer than the retention ago
+// whose deliveries are all delivered or dead, along with their deliveries, and
+// reports how many events it deleted
+func (r *outboxEventRepositoryImpl) Prune(ctx context.Context, tx pgx.Tx, retention time.Duration, limit int) int {
+	commandTag, err := tx.Exec(
+		ctx,
+		`DELETE FROM outbox_events WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE dispatched_at <= now() - $1 * interval '1 second'
+				AND NOT EXISTS (
+					SELECT 1 FROM webhook_deliveries
+					WHERE webhook_deliveries.event_id = outbox_events.id
+						AND webhook_deliveries.status NOT IN ('delivered', 'dead')
+				)
+			ORDER BY id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`,
+		retention.Seconds(), limit,
+	)
+	helper.InternalServerPanicIfError(err, "outbox event > repository > Prune")
+
+	return int(commandTag.RowsAffected())
+}
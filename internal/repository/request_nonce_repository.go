@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type RequestNonceRepository interface {
+	Save(ctx context.Context, tx pgx.Tx, apiKeyId string, nonce string) bool
+	DeleteExpired(ctx context.Context, tx pgx.Tx)
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type requestNonceRepositoryImpl struct {
+	AppConfig *config.AppConfig
+}
+
+func NewRequestNonceRepositoryImpl(appConfig *config.AppConfig) RequestNonceRepository {
+	return &requestNonceRepositoryImpl{
+		AppConfig: appConfig,
+	}
+}
+
+// Save returns false when the API key has used the nonce already. A nonce is
+// kept for twice the clock skew, a signature older than that is refused by its
+// timestamp, and an expired nonce which has not been deleted yet is taken over.
+func (r *requestNonceRepositoryImpl) Save(ctx context.Context, tx pgx.Tx, apiKeyId string, nonce string) bool {
+	ttl := 2 * r.AppConfig.Signing.ClockSkew * time.Second
+
+	rows, err := tx.Query(
+		ctx,
+		`INSERT INTO request_nonces (api_key_id, nonce, expires_at)
+		VALUES ($1, $2, now() + $3 * interval '1 second')
+		ON CONFLICT (api_key_id, nonce) DO UPDATE SET expires_at = excluded.expires_at
+		WHERE request_nonces.expires_at <= now()
+		RETURNING expires_at`,
+		apiKeyId, nonce, ttl.Seconds(),
+	)
+	helper.InternalServerPanicIfError(err, "request nonce > repository > Save")
+
+	expiresAts, err := pgx.CollectRows(rows, pgx.RowTo[time.Time])
+	helper.InternalServerPanicIfError(err, "request nonce > repository > Save")
+
+	return len(expiresAts) == 1
+}
+
+func (r *requestNonceRepositoryImpl) DeleteExpired(ctx context.Context, tx pgx.Tx) {
+	_, err := tx.Exec(ctx, "DELETE FROM request_nonces WHERE expires_at <= now()")
+	helper.InternalServerPanicIfError(err, "request nonce > repository > DeleteExpired")
+}
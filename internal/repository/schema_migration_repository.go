@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type SchemaMigrationRepository interface {
+	FindVersion(ctx context.Context, tx pgx.Tx) *entity.SchemaMigration
+}
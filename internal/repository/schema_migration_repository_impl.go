@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type schemaMigrationRepositoryImpl struct{}
+
+func NewSchemaMigrationRepositoryImpl() SchemaMigrationRepository {
+	return new(schemaMigrationRepositoryImpl)
+}
+
+// FindVersion reads the version golang-migrate records, nil when the database
+// is not migrated yet
+func (r *schemaMigrationRepositoryImpl) FindVersion(ctx context.Context, tx pgx.Tx) *entity.SchemaMigration {
+	result := new(entity.SchemaMigration)
+
+	err := tx.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&result.Version, &result.Dirty)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	helper.InternalServerPanicIfError(err, "schema migration > repository > FindVersion")
+
+	return result
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type TenantRepository interface {
+	Save(ctx context.Context, tx pgx.Tx, tenant *entity.Tenant) *entity.Tenant
+	FindById(ctx context.Context, tx pgx.Tx, tenantId string) *entity.Tenant
+	FindAll(ctx context.Context, tx pgx.Tx) []entity.Tenant
+}
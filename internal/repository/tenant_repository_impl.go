@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type tenantRepositoryImpl struct {
+	IdGenerator security.IdGenerator
+}
+
+func NewTenantRepositoryImpl(idGenerator security.IdGenerator) TenantRepository {
+	return &tenantRepositoryImpl{
+		IdGenerator: idGenerator,
+	}
+}
+
+// Save panics with 409 when the name is taken, a conflict on the generated id
+// is retried with another one
+func (r *tenantRepositoryImpl) Save(ctx context.Context, tx pgx.Tx, tenant *entity.Tenant) *entity.Tenant {
+	for {
+		generatedId, err := r.IdGenerator.Generate(36)
+		helper.InternalServerPanicIfError(err, "tenant > repository > Save")
+
+		rows, err := tx.Query(
+			ctx,
+			`INSERT INTO tenants (id, name) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+			RETURNING created_at`,
+			generatedId, tenant.Name,
+		)
+		helper.InternalServerPanicIfError(err, "tenant > repository > Save")
+
+		createdAts, err := pgx.CollectRows(rows, pgx.RowTo[time.Time])
+		helper.InternalServerPanicIfError(err, "tenant > repository > Save")
+
+		if len(createdAts) == 1 {
+			tenant.Id = generatedId
+			tenant.CreatedAt = createdAts[0]
+			break
+		}
+
+		var nameTaken bool
+
+		err = tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM tenants WHERE name = $1)", tenant.Name).Scan(&nameTaken)
+		helper.InternalServerPanicIfError(err, "tenant > repository > Save")
+
+		if nameTaken {
+			panic(exception.NewErrorClientRequest(errors.New("tenant name is taken"), http.StatusConflict, "tenant name is already taken"))
+		}
+	}
+
+	return tenant
+}
+
+func (r *tenantRepositoryImpl) FindById(ctx context.Context, tx pgx.Tx, tenantId string) *entity.Tenant {
+	rows, err := tx.Query(ctx, "SELECT id, name, created_at FROM tenants WHERE id = $1", tenantId)
+	helper.InternalServerPanicIfError(err, "tenant > repository > FindById")
+
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[entity.Tenant])
+	helper.ClientPanicIfError(err, exception.NewErrorClientRequest(err, http.StatusNotFound, "tenant is not found"))
+
+	return result
+}
+
+func (r *tenantRepositoryImpl) FindAll(ctx context.Context, tx pgx.Tx) []entity.Tenant {
+	rows, err := tx.Query(ctx, "SELECT id, name, created_at FROM tenants ORDER BY created_at, id")
+	helper.InternalServerPanicIfError(err, "tenant > repository > FindAll")
+
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Tenant])
+	helper.InternalServerPanicIfError(err, "tenant > repository > FindAll")
+
+	return result
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	test_helper "github.com/syahdaromansyah/pzn-golang-restful-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKeyLifecycle(t *testing.T) {
+	// Arrange
+	dbHelper := test_helper.NewApiKeysDbTable(appConfig)
+
+	dbHelper.DeleteAll()
+	defer dbHelper.DeleteAll()
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	apiKeyRepository := repository.NewApiKeyRepositoryImpl(security.NewIdGenImpl())
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	savedKey := apiKeyRepository.Save(ctx, tx, &entity.ApiKey{
+		TenantId:  security.DefaultTenantId,
+		Name:      "reader",
+		KeyPrefix: "pzn_abcdefgh",
+		KeyHash:   "hash-1",
+		Scopes:    []string{security.ScopeCategoriesRead},
+	})
+
+	apiKeyRepository.TouchLastUsed(ctx, tx, savedKey.Id)
+	touchedKey := apiKeyRepository.FindByHash(ctx, tx, "hash-1")
+
+	rotatedKey := apiKeyRepository.UpdateKey(ctx, tx, &entity.ApiKey{Id: savedKey.Id, KeyPrefix: "pzn_ijklmnop", KeyHash: "hash-2"})
+	oldKey := apiKeyRepository.FindByHash(ctx, tx, "hash-1")
+
+	apiKeyRepository.Revoke(ctx, tx, savedKey.Id)
+	revokedKey := apiKeyRepository.FindById(ctx, tx, savedKey.Id)
+
+	apiKeys := apiKeyRepository.FindAll(ctx, tx, security.DefaultTenantId)
+	everyApiKeys := apiKeyRepository.FindAll(ctx, tx, "")
+	otherTenantApiKeys := apiKeyRepository.FindAll(ctx, tx, "other-tenant")
+	// ---------------------------
+
+	helper.TxCommit(ctx, tx)
+
+	// Assert
+	assert.NotEmpty(t, savedKey.Id)
+	assert.False(t, savedKey.CreatedAt.IsZero())
+
+	assert.NotNil(t, touchedKey.LastUsedAt)
+	assert.Equal(t, []string{security.ScopeCategoriesRead}, touchedKey.Scopes)
+
+	assert.Equal(t, "pzn_ijklmnop", rotatedKey.KeyPrefix)
+	assert.NotNil(t, rotatedKey.RotatedAt)
+	assert.Nil(t, oldKey)
+
+	assert.NotNil(t, revokedKey.RevokedAt)
+
+	if assert.Len(t, apiKeys, 1) {
+		assert.Equal(t, "hash-2", apiKeys[0].KeyHash)
+		assert.Equal(t, security.DefaultTenantId, apiKeys[0].TenantId)
+	}
+
+	assert.Len(t, everyApiKeys, 1)
+	assert.Empty(t, otherTenantApiKeys)
+}
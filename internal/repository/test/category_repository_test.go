@@ -399,3 +399,145 @@ func TestFindAllSuccess(t *testing.T) {
 		{Id: "C-3", Name: "Toys"},
 	}, result)
 }
+
+func TestFindByIdsSuccess(t *testing.T) {
+	// Arrange
+
+	// --- Insert dummy data to DB
+	dbHelper := test_helper.NewCategoriesDbTable(appConfig)
+	defer dbHelper.DeleteAll()
+
+	dbHelper.AddMany([]entity.Category{
+		{Id: "CAT-1", Name: "Medicines"},
+		{Id: "CAT-2", Name: "Fashions"},
+		{Id: "CAT-3", Name: "Toys"},
+	})
+	// --- END
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	defer helper.TxRollbackIfPanic(ctx, tx)
+
+	var result []entity.Category
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = repository.NewCategoryRepositoryImpl(nil).FindByIds(ctx, tx, []string{"CAT-1", "CAT-3", "CAT-9"})
+		// ---------------------------
+	})
+
+	helper.TxCommit(ctx, tx)
+
+	assert.ElementsMatch(t, []entity.Category{
+		{Id: "CAT-1", Name: "Medicines"},
+		{Id: "CAT-3", Name: "Toys"},
+	}, result)
+}
+
+func TestFindPageSuccess(t *testing.T) {
+	// Arrange
+
+	// --- Insert dummy data to DB
+	dbHelper := test_helper.NewCategoriesDbTable(appConfig)
+	defer dbHelper.DeleteAll()
+
+	dbHelper.AddMany([]entity.Category{
+		{Id: "CAT-3", Name: "Toys"},
+		{Id: "CAT-1", Name: "Medicines"},
+		{Id: "CAT-2", Name: "Fashions"},
+	})
+	// --- END
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	defer helper.TxRollbackIfPanic(ctx, tx)
+
+	var firstPage, secondPage []entity.Category
+	var count int
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		categoryRepository := repository.NewCategoryRepositoryImpl(nil)
+
+		firstPage = categoryRepository.FindPage(ctx, tx, "", 2)
+		secondPage = categoryRepository.FindPage(ctx, tx, "CAT-2", 2)
+		count = categoryRepository.Count(ctx, tx)
+		// ---------------------------
+	})
+
+	helper.TxCommit(ctx, tx)
+
+	assert.Equal(t, []entity.Category{
+		{Id: "CAT-1", Name: "Medicines"},
+		{Id: "CAT-2", Name: "Fashions"},
+	}, firstPage)
+
+	assert.Equal(t, []entity.Category{
+		{Id: "CAT-3", Name: "Toys"},
+	}, secondPage)
+
+	assert.Equal(t, 3, count)
+}
+
+func TestFindVersionSuccess(t *testing.T) {
+	// Arrange
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	findVersion := func() *entity.TableVersion {
+		tx, err := pool.Begin(ctx)
+		helper.PanicIfError(err)
+
+		defer helper.TxRollbackIfPanic(ctx, tx)
+
+		result := repository.NewCategoryRepositoryImpl(nil).FindVersion(ctx, tx)
+
+		helper.TxCommit(ctx, tx)
+
+		return result
+	}
+
+	before := findVersion()
+
+	// --- Insert dummy data to DB
+	dbHelper := test_helper.NewCategoriesDbTable(appConfig)
+	defer dbHelper.DeleteAll()
+
+	dbHelper.AddMany([]entity.Category{
+		{Id: "CAT-1", Name: "Medicines"},
+	})
+	// --- END
+
+	var result *entity.TableVersion
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = findVersion()
+		// ---------------------------
+	})
+
+	assert.Equal(t, "categories", result.TableName)
+	assert.Equal(t, "default", result.TenantId)
+	assert.Greater(t, result.Version, before.Version)
+	assert.False(t, result.UpdatedAt.Before(before.UpdatedAt))
+}
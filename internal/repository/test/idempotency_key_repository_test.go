@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+	test_helper "github.com/syahdaromansyah/pzn-golang-restful-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeySaveAndFind(t *testing.T) {
+	// Arrange
+	dbHelper := test_helper.NewIdempotencyKeysDbTable(appConfig)
+	defer dbHelper.DeleteAll()
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	defer helper.TxRollbackIfPanic(ctx, tx)
+
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepositoryImpl(appConfig)
+
+	var saved *entity.IdempotencyKey
+	var found *entity.IdempotencyKey
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		idempotencyKeyRepository.Lock(ctx, tx, "key-1")
+
+		saved = idempotencyKeyRepository.Save(ctx, tx, &entity.IdempotencyKey{
+			Key:          "key-1",
+			RequestHash:  "hash",
+			ResponseBody: []byte(`{"id": "CAT-1", "name": "Drinks"}`),
+		})
+
+		found = idempotencyKeyRepository.FindByKey(ctx, tx, "key-1")
+		// ---------------------------
+	})
+
+	helper.TxCommit(ctx, tx)
+
+	assert.Equal(t, appConfig.Idempotency.TTL*time.Hour, saved.ExpiresAt.Sub(saved.CreatedAt))
+	assert.Equal(t, "hash", found.RequestHash)
+	assert.JSONEq(t, `{"id": "CAT-1", "name": "Drinks"}`, string(found.ResponseBody))
+}
+
+func TestIdempotencyKeyExpired(t *testing.T) {
+	// Arrange
+	dbHelper := test_helper.NewIdempotencyKeysDbTable(appConfig)
+	defer dbHelper.DeleteAll()
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepositoryImpl(appConfig)
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	idempotencyKeyRepository.Save(ctx, tx, &entity.IdempotencyKey{
+		Key:          "key-1",
+		RequestHash:  "old hash",
+		ResponseBody: []byte(`{}`),
+	})
+
+	helper.TxCommit(ctx, tx)
+
+	dbHelper.Expire("key-1")
+
+	tx, err = pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	defer helper.TxRollbackIfPanic(ctx, tx)
+
+	var found *entity.IdempotencyKey
+	var taken *entity.IdempotencyKey
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		found = idempotencyKeyRepository.FindByKey(ctx, tx, "key-1")
+
+		taken = idempotencyKeyRepository.Save(ctx, tx, &entity.IdempotencyKey{
+			Key:          "key-1",
+			RequestHash:  "new hash",
+			ResponseBody: []byte(`{}`),
+		})
+
+		idempotencyKeyRepository.DeleteExpired(ctx, tx)
+		// ---------------------------
+	})
+
+	helper.TxCommit(ctx, tx)
+
+	assert.Nil(t, found)
+	assert.True(t, taken.ExpiresAt.After(time.Now()))
+}
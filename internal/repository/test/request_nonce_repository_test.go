@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+	test_helper "github.com/syahdaromansyah/pzn-golang-restful-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestNonceSave(t *testing.T) {
+	// Arrange
+	dbHelper := test_helper.NewApiKeysDbTable(appConfig)
+
+	dbHelper.DeleteAll()
+	defer dbHelper.DeleteAll()
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	nonceRepository := repository.NewRequestNonceRepositoryImpl(appConfig)
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	firstUse := nonceRepository.Save(ctx, tx, "KEY-1", "nonce-1")
+	replay := nonceRepository.Save(ctx, tx, "KEY-1", "nonce-1")
+	otherKey := nonceRepository.Save(ctx, tx, "KEY-2", "nonce-1")
+
+	nonceRepository.DeleteExpired(ctx, tx)
+
+	replayAfterCleanup := nonceRepository.Save(ctx, tx, "KEY-1", "nonce-1")
+	// ---------------------------
+
+	helper.TxCommit(ctx, tx)
+
+	// Assert
+	assert.True(t, firstUse)
+	assert.False(t, replay)
+	assert.True(t, otherKey)
+	assert.False(t, replayAfterCleanup)
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db_migration "github.com/syahdaromansyah/pzn-golang-restful-api/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaMigrationFindVersion(t *testing.T) {
+	// Arrange
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	defer helper.TxRollbackIfPanic(ctx, tx)
+
+	var schemaMigration *entity.SchemaMigration
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		schemaMigration = repository.NewSchemaMigrationRepositoryImpl().FindVersion(ctx, tx)
+		// ---------------------------
+	})
+
+	helper.TxCommit(ctx, tx)
+
+	assert.Equal(t, &entity.SchemaMigration{
+		Version: db_migration.MigrationVersion(),
+		Dirty:   false,
+	}, schemaMigration)
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	internal_security_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/security/mock"
+	test_helper "github.com/syahdaromansyah/pzn-golang-restful-api/test/helper"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// beginTenantTx begins a transaction the way the use cases do, the row level
+// security applies to it even when the tests connect as a superuser
+func beginTenantTx(ctx context.Context, pool db.PgxPool, tenantId string) pgx.Tx {
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	err = helper.TxSetLocalTenant(ctx, tx, tenantId)
+	helper.PanicIfError(err)
+
+	return tx
+}
+
+func TestTenantSaveAndFind(t *testing.T) {
+	// Arrange
+	dbHelper := test_helper.NewTenantsDbTable(appConfig)
+
+	dbHelper.DeleteAll()
+	defer dbHelper.DeleteAll()
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tenantRepository := repository.NewTenantRepositoryImpl(security.NewIdGenImpl())
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	savedTenant := tenantRepository.Save(ctx, tx, &entity.Tenant{Name: "brand-a"})
+	foundTenant := tenantRepository.FindById(ctx, tx, savedTenant.Id)
+	tenants := tenantRepository.FindAll(ctx, tx)
+	// ---------------------------
+
+	// Assert
+	assert.Len(t, savedTenant.Id, 36)
+	assert.Equal(t, "brand-a", foundTenant.Name)
+
+	if assert.Len(t, tenants, 2) {
+		assert.Equal(t, security.DefaultTenantId, tenants[0].Id)
+		assert.Equal(t, savedTenant.Id, tenants[1].Id)
+	}
+
+	defer func() {
+		errRecover := recover()
+
+		if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+			assert.Equal(t, http.StatusConflict, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+		}
+	}()
+
+	tenantRepository.Save(ctx, tx, &entity.Tenant{Name: "brand-a"})
+}
+
+func TestTenantIsolation(t *testing.T) {
+	// Arrange
+	tenantsDbHelper := test_helper.NewTenantsDbTable(appConfig)
+	categoriesDbHelper := test_helper.NewCategoriesDbTable(appConfig)
+
+	tenantsDbHelper.DeleteAll()
+	categoriesDbHelper.DeleteAll()
+
+	defer tenantsDbHelper.DeleteAll()
+	defer categoriesDbHelper.DeleteAll()
+
+	tenantsDbHelper.Add(&entity.Tenant{Id: "TENANT-A", Name: "brand-a"})
+	tenantsDbHelper.Add(&entity.Tenant{Id: "TENANT-B", Name: "brand-b"})
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	idGen := internal_security_mock.NewIdGenMock()
+
+	idGen.Mock.On("Generate", 36).Return("CAT-1", nil)
+
+	categoryRepository := repository.NewCategoryRepositoryImpl(idGen)
+	outboxEventRepository := repository.NewOutboxEventRepositoryImpl()
+
+	txA := beginTenantTx(ctx, pool, "TENANT-A")
+
+	categoryRepository.Save(ctx, txA, &entity.Category{Name: "Fashions"})
+	outboxEventRepository.Save(ctx, txA, &entity.OutboxEvent{EventType: entity.EventCategoryCreated, AggregateId: "CAT-1", Payload: []byte(`{}`)})
+
+	helper.TxCommit(ctx, txA)
+
+	t.Run("Another Tenant Never Reads Nor Writes The Rows", func(t *testing.T) {
+		txB := beginTenantTx(ctx, pool, "TENANT-B")
+		defer helper.TxCommitRollback(ctx, txB)
+
+		// Action & Assert
+		// ---SUT (Subject Under Test)
+		assert.Empty(t, categoryRepository.FindAll(ctx, txB))
+		assert.Empty(t, categoryRepository.FindByIds(ctx, txB, []string{"CAT-1"}))
+		assert.Zero(t, categoryRepository.Count(ctx, txB))
+		assert.Empty(t, outboxEventRepository.FindLatest(ctx, txB, []string{entity.EventCategoryCreated}, 10))
+
+		assert.Panics(t, func() {
+			categoryRepository.FindById(ctx, txB, "CAT-1")
+		})
+
+		categoryRepository.Update(ctx, txB, &entity.Category{Id: "CAT-1", Name: "Gadgets"})
+		categoryRepository.Delete(ctx, txB, "CAT-1")
+		// ---------------------------
+	})
+
+	t.Run("Another Tenant Reuses The Id", func(t *testing.T) {
+		txB := beginTenantTx(ctx, pool, "TENANT-B")
+		defer helper.TxCommitRollback(ctx, txB)
+
+		// Action
+		// ---SUT (Subject Under Test)
+		category := categoryRepository.Save(ctx, txB, &entity.Category{Name: "Drinks"})
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, "CAT-1", category.Id)
+		assert.Equal(t, []entity.Category{{Id: "CAT-1", Name: "Drinks"}}, categoryRepository.FindAll(ctx, txB))
+	})
+
+	t.Run("A Tenant Never Writes A Row Of Another", func(t *testing.T) {
+		txB := beginTenantTx(ctx, pool, "TENANT-B")
+		defer txB.Rollback(ctx)
+
+		// Action
+		// ---SUT (Subject Under Test)
+		_, err := txB.Exec(ctx, "INSERT INTO categories (tenant_id, id, name) VALUES ('TENANT-A', 'CAT-2', 'Smuggled')")
+		// ---------------------------
+
+		// Assert
+		assert.ErrorContains(t, err, "row-level security")
+	})
+
+	t.Run("A Transaction Without A Tenant Reads Nothing", func(t *testing.T) {
+		tx := beginTenantTx(ctx, pool, "")
+		defer helper.TxCommitRollback(ctx, tx)
+
+		// Action & Assert
+		// ---SUT (Subject Under Test)
+		assert.Empty(t, categoryRepository.FindAll(ctx, tx))
+		// ---------------------------
+	})
+
+	// The rows of the tenant are untouched
+	txA = beginTenantTx(ctx, pool, "TENANT-A")
+	defer helper.TxCommitRollback(ctx, txA)
+
+	assert.Equal(t, []entity.Category{{Id: "CAT-1", Name: "Fashions"}}, categoryRepository.FindAll(ctx, txA))
+	assert.Len(t, outboxEventRepository.FindLatest(ctx, txA, []string{entity.EventCategoryCreated}, 10), 1)
+}
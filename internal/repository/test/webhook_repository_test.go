@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	test_helper "github.com/syahdaromansyah/pzn-golang-restful-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookOutboxFanOutAndClaim(t *testing.T) {
+	// Arrange
+	dbHelper := test_helper.NewWebhooksDbTable(appConfig)
+
+	dbHelper.DeleteAll()
+	defer dbHelper.DeleteAll()
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	outboxEventRepository := repository.NewOutboxEventRepositoryImpl()
+	webhookSubscriptionRepository := repository.NewWebhookSubscriptionRepositoryImpl(security.NewIdGenImpl())
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepositoryImpl()
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	subscription := webhookSubscriptionRepository.Save(ctx, tx, &entity.WebhookSubscription{
+		Url:        "https://example.com/hook",
+		Secret:     "whsec_secret",
+		EventTypes: []string{entity.EventCategoryCreated},
+	})
+
+	createdEvent := outboxEventRepository.Save(ctx, tx, &entity.OutboxEvent{
+		EventType:   entity.EventCategoryCreated,
+		AggregateId: "CAT-1",
+		Payload:     []byte(`{"id":"CAT-1","name":"Drinks"}`),
+	})
+
+	// Nobody subscribes to the updates, the event is dispatched without deliveries
+	outboxEventRepository.Save(ctx, tx, &entity.OutboxEvent{
+		EventType:   entity.EventCategoryUpdated,
+		AggregateId: "CAT-1",
+		Payload:     []byte(`{"id":"CAT-1","name":"Foods"}`),
+	})
+
+	helper.TxCommit(ctx, tx)
+
+	var dispatched, dispatchedAgain int
+	var jobs, claimedAgain []entity.WebhookDeliveryJob
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		tx, err := pool.Begin(ctx)
+		helper.PanicIfError(err)
+
+		dispatched = outboxEventRepository.FanOut(ctx, tx, 10)
+		dispatchedAgain = outboxEventRepository.FanOut(ctx, tx, 10)
+
+		jobs = webhookDeliveryRepository.ClaimDue(ctx, tx, 10, time.Minute)
+		claimedAgain = webhookDeliveryRepository.ClaimDue(ctx, tx, 10, time.Minute)
+
+		helper.TxCommit(ctx, tx)
+		// ---------------------------
+	})
+
+	assert.Equal(t, 2, dispatched)
+	assert.Equal(t, 0, dispatchedAgain)
+	assert.Empty(t, claimedAgain, "the leased deliveries are not due")
+
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, createdEvent.Id, jobs[0].EventId)
+		assert.Equal(t, subscription.Id, jobs[0].SubscriptionId)
+		assert.Equal(t, entity.WebhookDeliveryPending, jobs[0].Status)
+		assert.Equal(t, entity.EventCategoryCreated, jobs[0].EventType)
+		assert.JSONEq(t, `{"id":"CAT-1","name":"Drinks"}`, string(jobs[0].Payload))
+		assert.Equal(t, "https://example.com/hook", jobs[0].Url)
+		assert.Equal(t, "whsec_secret", jobs[0].Secret)
+	}
+}
+
+func TestOutboxEventFindLatest(t *testing.T) {
+	// Arrange
+	dbHelper := test_helper.NewWebhooksDbTable(appConfig)
+
+	dbHelper.DeleteAll()
+	defer dbHelper.DeleteAll()
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	outboxEventRepository := repository.NewOutboxEventRepositoryImpl()
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	savedIds := []int64{}
+
+	for _, eventType := range []string{entity.EventCategoryCreated, "tag.created", entity.EventCategoryUpdated, entity.EventCategoryDeleted} {
+		savedEvent := outboxEventRepository.Save(ctx, tx, &entity.OutboxEvent{
+			EventType:   eventType,
+			AggregateId: "CAT-1",
+			Payload:     []byte(`{"id":"CAT-1","name":"Drinks"}`),
+		})
+
+		savedIds = append(savedIds, savedEvent.Id)
+	}
+
+	helper.TxCommit(ctx, tx)
+
+	var outboxEvents []entity.OutboxEvent
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		tx, err := pool.Begin(ctx)
+		helper.PanicIfError(err)
+
+		outboxEvents = outboxEventRepository.FindLatest(ctx, tx, []string{entity.EventCategoryCreated, entity.EventCategoryUpdated, entity.EventCategoryDeleted}, 2)
+
+		helper.TxCommit(ctx, tx)
+		// ---------------------------
+	})
+
+	if assert.Len(t, outboxEvents, 2) {
+		assert.Equal(t, savedIds[2], outboxEvents[0].Id, "the latest events are listed oldest first")
+		assert.Equal(t, savedIds[3], outboxEvents[1].Id)
+		assert.JSONEq(t, `{"id":"CAT-1","name":"Drinks"}`, string(outboxEvents[1].Payload))
+	}
+}
+
+func TestOutboxEventPrune(t *testing.T) {
+	// Arrange
+	dbHelper := test_helper.NewWebhooksDbTable(appConfig)
+
+	dbHelper.DeleteAll()
+	defer dbHelper.DeleteAll()
+
+	pool := config.NewPgxPool(appConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	outboxEventRepository := repository.NewOutboxEventRepositoryImpl()
+	webhookSubscriptionRepository := repository.NewWebhookSubscriptionRepositoryImpl(security.NewIdGenImpl())
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepositoryImpl()
+
+	tx, err := pool.Begin(ctx)
+	helper.PanicIfError(err)
+
+	webhookSubscriptionRepository.Save(ctx, tx, &entity.WebhookSubscription{
+		Url:        "https://example.com/hook",
+		Secret:     "whsec_secret",
+		EventTypes: []string{entity.EventCategoryCreated},
+	})
+
+	for _, eventType := range []string{entity.EventCategoryCreated, entity.EventCategoryUpdated} {
+		outboxEventRepository.Save(ctx, tx, &entity.OutboxEvent{
+			EventType:   eventType,
+			AggregateId: "CAT-1",
+			Payload:     []byte(`{"id":"CAT-1","name":"Drinks"}`),
+		})
+	}
+
+	helper.TxCommit(ctx, tx)
+
+	var prunedRecent, prunedUndelivered, prunedDelivered int
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		tx, err := pool.Begin(ctx)
+		helper.PanicIfError(err)
+
+		outboxEventRepository.FanOut(ctx, tx, 10)
+
+		prunedRecent = outboxEventRepository.Prune(ctx, tx, time.Hour, 10)
+
+		// The update has no deliveries, the creation waits for its delivery
+		prunedUndelivered = outboxEventRepository.Prune(ctx, tx, 0, 10)
+
+		jobs := webhookDeliveryRepository.ClaimDue(ctx, tx, 10, time.Minute)
+
+		for i := range jobs {
+			jobs[i].Status = entity.WebhookDeliveryDelivered
+			webhookDeliveryRepository.Update(ctx, tx, &jobs[i].WebhookDelivery)
+		}
+
+		prunedDelivered = outboxEventRepository.Prune(ctx, tx, 0, 10)
+
+		helper.TxCommit(ctx, tx)
+		// ---------------------------
+	})
+
+	assert.Equal(t, 0, prunedRecent, "the events within the retention are kept")
+	assert.Equal(t, 1, prunedUndelivered)
+	assert.Equal(t, 1, prunedDelivered)
+}
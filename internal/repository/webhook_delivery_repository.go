@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type WebhookDeliveryRepository interface {
+	ClaimDue(ctx context.Context, tx pgx.Tx, limit int, lease time.Duration) []entity.WebhookDeliveryJob
+	Update(ctx context.Context, tx pgx.Tx, delivery *entity.WebhookDelivery) *entity.WebhookDelivery
+	FindById(ctx context.Context, tx pgx.Tx, subscriptionId string, deliveryId int64) *entity.WebhookDelivery
+	FindBySubscriptionId(ctx context.Context, tx pgx.Tx, subscriptionId string, limit int) []entity.WebhookDelivery
+}
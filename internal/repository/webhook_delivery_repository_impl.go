@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type webhookDeliveryRepositoryImpl struct{}
+
+func NewWebhookDeliveryRepositoryImpl() WebhookDeliveryRepository {
+	return new(webhookDeliveryRepositoryImpl)
+}
+
+// ClaimDue leases the due pending deliveries by pushing their next attempt
+// past the lease, so the deliveries are sent outside of the transaction and a
+// crashed dispatcher only delays them until the lease runs out
+func (r *webhookDeliveryRepositoryImpl) ClaimDue(ctx context.Context, tx pgx.Tx, limit int, lease time.Duration) []entity.WebhookDeliveryJob {
+	rows, err := tx.Query(
+		ctx,
+		`WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries SET next_attempt_at = now() + $2 * interval '1 second'
+		FROM due, outbox_events, webhook_subscriptions
+		WHERE webhook_deliveries.id = due.id
+			AND outbox_events.id = webhook_deliveries.event_id
+			AND webhook_subscriptions.id = webhook_deliveries.subscription_id
+		RETURNING webhook_deliveries.id, webhook_deliveries.event_id, webhook_deliveries.subscription_id,
+			webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at,
+			webhook_deliveries.last_status_code, webhook_deliveries.last_error, webhook_deliveries.created_at,
+			webhook_deliveries.delivered_at, outbox_events.event_type, outbox_events.payload,
+			outbox_events.created_at AS event_at, webhook_subscriptions.url, webhook_subscriptions.secret`,
+		limit, lease.Seconds(),
+	)
+	helper.InternalServerPanicIfError(err, "webhook delivery > repository > ClaimDue")
+
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.WebhookDeliveryJob])
+	helper.InternalServerPanicIfError(err, "webhook delivery > repository > ClaimDue")
+
+	return result
+}
+
+func (r *webhookDeliveryRepositoryImpl) Update(ctx context.Context, tx pgx.Tx, delivery *entity.WebhookDelivery) *entity.WebhookDelivery {
+	_, err := tx.Exec(
+		ctx,
+		`UPDATE webhook_deliveries SET status = $1, attempts = $2, next_attempt_at = $3,
+			last_status_code = $4, last_error = $5, delivered_at = $6
+		WHERE id = $7`,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		delivery.LastStatusCode, delivery.LastError, delivery.DeliveredAt, delivery.Id,
+	)
+	helper.InternalServerPanicIfError(err, "webhook delivery > repository > Update")
+
+	return delivery
+}
+
+func (r *webhookDeliveryRepositoryImpl) FindById(ctx context.Context, tx pgx.Tx, subscriptionId string, deliveryId int64) *entity.WebhookDelivery {
+	rows, err := tx.Query(
+		ctx,
+		`SELECT id, event_id, subscription_id, status, attempts, next_attempt_at, last_status_code,
+			last_error, created_at, delivered_at
+		FROM webhook_deliveries WHERE id = $1 AND subscription_id = $2`,
+		deliveryId, subscriptionId,
+	)
+	helper.InternalServerPanicIfError(err, "webhook delivery > repository > FindById")
+
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[entity.WebhookDelivery])
+	helper.ClientPanicIfError(err, exception.NewErrorClientRequest(err, http.StatusNotFound, "webhook delivery is not found"))
+
+	return result
+}
+
+// FindBySubscriptionId lists the latest deliveries of the subscription first
+func (r *webhookDeliveryRepositoryImpl) FindBySubscriptionId(ctx context.Context, tx pgx.Tx, subscriptionId string, limit int) []entity.WebhookDelivery {
+	rows, err := tx.Query(
+		ctx,
+		`SELECT id, event_id, subscription_id, status, attempts, next_attempt_at, last_status_code,
+			last_error, created_at, delivered_at
+		FROM webhook_deliveries WHERE subscription_id = $1
+		ORDER BY id DESC
+		LIMIT $2`,
+		subscriptionId, limit,
+	)
+	helper.InternalServerPanicIfError(err, "webhook delivery > repository > FindBySubscriptionId")
+
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.WebhookDelivery])
+	helper.InternalServerPanicIfError(err, "webhook delivery > repository > FindBySubscriptionId")
+
+	return result
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type WebhookSubscriptionRepository interface {
+	Save(ctx context.Context, tx pgx.Tx, subscription *entity.WebhookSubscription) *entity.WebhookSubscription
+	Delete(ctx context.Context, tx pgx.Tx, subscriptionId string)
+	FindById(ctx context.Context, tx pgx.Tx, subscriptionId string) *entity.WebhookSubscription
+	FindAll(ctx context.Context, tx pgx.Tx) []entity.WebhookSubscription
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type webhookSubscriptionRepositoryImpl struct {
+	IdGenerator security.IdGenerator
+}
+
+func NewWebhookSubscriptionRepositoryImpl(idGenerator security.IdGenerator) WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepositoryImpl{
+		IdGenerator: idGenerator,
+	}
+}
+
+func (r *webhookSubscriptionRepositoryImpl) Save(ctx context.Context, tx pgx.Tx, subscription *entity.WebhookSubscription) *entity.WebhookSubscription {
+	for {
+		generatedId, err := r.IdGenerator.Generate(36)
+		helper.InternalServerPanicIfError(err, "webhook subscription > repository > Save")
+
+		rows, err := tx.Query(
+			ctx,
+			`INSERT INTO webhook_subscriptions (id, url, secret, event_types) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING created_at`,
+			generatedId, subscription.Url, subscription.Secret, subscription.EventTypes,
+		)
+		helper.InternalServerPanicIfError(err, "webhook subscription > repository > Save")
+
+		createdAts, err := pgx.CollectRows(rows, pgx.RowTo[time.Time])
+		helper.InternalServerPanicIfError(err, "webhook subscription > repository > Save")
+
+		if len(createdAts) == 1 {
+			subscription.Id = generatedId
+			subscription.CreatedAt = createdAts[0]
+			break
+		}
+	}
+
+	return subscription
+}
+
+func (r *webhookSubscriptionRepositoryImpl) Delete(ctx context.Context, tx pgx.Tx, subscriptionId string) {
+	_, err := tx.Exec(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", subscriptionId)
+	helper.InternalServerPanicIfError(err, "webhook subscription > repository > Delete")
+}
+
+func (r *webhookSubscriptionRepositoryImpl) FindById(ctx context.Context, tx pgx.Tx, subscriptionId string) *entity.WebhookSubscription {
+	rows, err := tx.Query(ctx, "SELECT id, url, secret, event_types, created_at FROM webhook_subscriptions WHERE id = $1", subscriptionId)
+	helper.InternalServerPanicIfError(err, "webhook subscription > repository > FindById")
+
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[entity.WebhookSubscription])
+	helper.ClientPanicIfError(err, exception.NewErrorClientRequest(err, http.StatusNotFound, "webhook is not found"))
+
+	return result
+}
+
+func (r *webhookSubscriptionRepositoryImpl) FindAll(ctx context.Context, tx pgx.Tx) []entity.WebhookSubscription {
+	rows, err := tx.Query(ctx, "SELECT id, url, secret, event_types, created_at FROM webhook_subscriptions ORDER BY created_at, id")
+	helper.InternalServerPanicIfError(err, "webhook subscription > repository > FindAll")
+
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.WebhookSubscription])
+	helper.InternalServerPanicIfError(err, "webhook subscription > repository > FindAll")
+
+	return result
+}
@@ -0,0 +1,91 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+)
+
+const (
+	AuthModeApiKey = "apikey"
+	AuthModeJwt    = "jwt"
+)
+
+// AuthPolicy is the authentication a route requires. Mode limits the route to
+// the API keys or to the bearer tokens, any of them is accepted when it is
+// empty, and Scope is checked when it is set.
+type AuthPolicy struct {
+	Public bool
+	Mode   string
+	Scope  string
+	// AnonymousTenantId is the tenant a public route answers for the requests
+	// without credentials
+	AnonymousTenantId string
+}
+
+// PublicPolicy serves the requests with or without credentials
+func PublicPolicy() AuthPolicy {
+	return AuthPolicy{Public: true}
+}
+
+// PublicTenantPolicy serves the requests without credentials as an anonymous
+// caller of the tenant, the requests with credentials keep their own tenant
+func PublicTenantPolicy(tenantId string) AuthPolicy {
+	return AuthPolicy{Public: true, AnonymousTenantId: tenantId}
+}
+
+// ScopePolicy accepts an API key or a bearer token, with the scope unless it
+// is empty
+func ScopePolicy(scope string) AuthPolicy {
+	return AuthPolicy{Scope: scope}
+}
+
+func ApiKeyPolicy(scope string) AuthPolicy {
+	return AuthPolicy{Mode: AuthModeApiKey, Scope: scope}
+}
+
+func JwtPolicy(scope string) AuthPolicy {
+	return AuthPolicy{Mode: AuthModeJwt, Scope: scope}
+}
+
+// Authorize panics with 401 for a request without valid credentials, or with
+// 403 when its credentials do not satisfy the policy. It answers the context
+// of the route, which carries the anonymous principal of a public tenant
+// route.
+func Authorize(ctx context.Context, policy AuthPolicy) context.Context {
+	principal := PrincipalFromContext(ctx)
+
+	if policy.Public {
+		if principal == nil && policy.AnonymousTenantId != "" {
+			return ContextWithPrincipal(ctx, &Principal{Id: "anonymous", Name: "anonymous", TenantId: policy.AnonymousTenantId})
+		}
+
+		return ctx
+	}
+
+	if principal == nil {
+		panic(NewUnauthorizedError(errors.New("unauthorized")))
+	}
+
+	if policy.Mode != "" && principal.AuthMode != policy.Mode {
+		panic(NewForbiddenError("the endpoint accepts the " + policy.Mode + " credentials only"))
+	}
+
+	if policy.Scope != "" {
+		RequireScope(ctx, policy.Scope)
+	}
+
+	return ctx
+}
+
+// NewUnauthorizedError is the 401 of every request without valid credentials,
+// so the failures do not tell a missing key from a wrong one
+func NewUnauthorizedError(err error) *exception.ErrorClientRequest {
+	return exception.NewErrorClientRequest(err, http.StatusUnauthorized, "unauthorized")
+}
+
+func NewForbiddenError(detail string) *exception.ErrorClientRequest {
+	return exception.NewErrorClientRequest(errors.New("forbidden"), http.StatusForbidden, detail)
+}
@@ -0,0 +1,17 @@
+package security
+
+import "context"
+
+// Jwk is a verification key of a JWKS, Key holds the []byte of an oct key,
+// the *rsa.PublicKey of an RSA key or the *ecdsa.PublicKey of an EC key
+type Jwk struct {
+	Kid string
+	Kty string
+	Alg string
+	Key any
+}
+
+type JwkSet interface {
+	Refresh(ctx context.Context) error
+	Find(ctx context.Context, kid string, alg string) (*Jwk, error)
+}
@@ -0,0 +1,339 @@
+package security
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+)
+
+const (
+	// jwksMinRefresh limits the refetches for the unknown kids, a token with
+	// a made up kid must not make every request fetch the JWKS
+	jwksMinRefresh = 30 * time.Second
+	jwksTimeout    = 10 * time.Second
+	jwksMaxSize    = 1 << 20
+	rsaMinBits     = 2048
+)
+
+var errJwkUnknown = errors.New("jwt: no key of the JWKS matches the token")
+
+// jwkJson is a key of the JWKS as published, only the members of the keys
+// verifying HS256, RS256 and ES256 are read
+type jwkJson struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwkSetImpl struct {
+	File            string
+	Url             string
+	RefreshInterval time.Duration
+	Client          *http.Client
+	refreshMutex    sync.Mutex
+	mutex           sync.RWMutex
+	keys            []*Jwk
+	fetchedAt       time.Time
+	attemptedAt     time.Time
+}
+
+func NewJwkSetImpl(appConfig *config.AppConfig) JwkSet {
+	return &jwkSetImpl{
+		File:            appConfig.Server.Jwt.JwksFile,
+		Url:             appConfig.Server.Jwt.JwksUrl,
+		RefreshInterval: appConfig.Server.Jwt.JwksRefresh * time.Second,
+		Client:          &http.Client{Timeout: jwksTimeout},
+	}
+}
+
+// Refresh replaces the keys with the ones of the JWKS, the old keys are kept
+// when it fails
+func (s *jwkSetImpl) Refresh(ctx context.Context) error {
+	s.refreshMutex.Lock()
+	defer s.refreshMutex.Unlock()
+
+	return s.refresh(ctx)
+}
+
+func (s *jwkSetImpl) refresh(ctx context.Context) error {
+	s.mutex.Lock()
+	s.attemptedAt = time.Now()
+	s.mutex.Unlock()
+
+	keys, err := s.load(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// refreshIfAttemptedBefore refetches unless a refresh has started since the
+// given time, the callers waiting on the same refresh fetch once
+func (s *jwkSetImpl) refreshIfAttemptedBefore(ctx context.Context, attemptedAt time.Time) error {
+	s.refreshMutex.Lock()
+	defer s.refreshMutex.Unlock()
+
+	s.mutex.RLock()
+	refreshed := s.attemptedAt.After(attemptedAt)
+	s.mutex.RUnlock()
+
+	if refreshed {
+		return nil
+	}
+
+	return s.refresh(ctx)
+}
+
+// Find answers from the cached keys. Stale keys are refetched in the
+// background while they keep answering, and an unknown kid refetches at once,
+// since the SSO may have rotated its keys
+func (s *jwkSetImpl) Find(ctx context.Context, kid string, alg string) (*Jwk, error) {
+	s.mutex.RLock()
+	keys, fetchedAt, attemptedAt := s.keys, s.fetchedAt, s.attemptedAt
+	s.mutex.RUnlock()
+
+	if keys == nil {
+		if err := s.refreshIfAttemptedBefore(ctx, attemptedAt); err != nil {
+			return nil, err
+		}
+	} else if time.Since(fetchedAt) > s.RefreshInterval && time.Since(attemptedAt) > jwksMinRefresh {
+		go s.refreshIfAttemptedBefore(context.Background(), attemptedAt)
+	}
+
+	if jwk := s.find(kid, alg); jwk != nil {
+		return jwk, nil
+	}
+
+	if kid == "" || time.Since(attemptedAt) < jwksMinRefresh {
+		return nil, errJwkUnknown
+	}
+
+	if err := s.refreshIfAttemptedBefore(ctx, attemptedAt); err != nil {
+		return nil, err
+	}
+
+	if jwk := s.find(kid, alg); jwk != nil {
+		return jwk, nil
+	}
+
+	return nil, errJwkUnknown
+}
+
+// find matches the kid, a token without a kid matches only when a single key
+// of the JWKS fits its alg
+func (s *jwkSetImpl) find(kid string, alg string) *Jwk {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var found *Jwk
+
+	for _, jwk := range s.keys {
+		if (kid != "" && jwk.Kid != kid) || !jwkFitsAlg(jwk, alg) {
+			continue
+		}
+
+		if kid != "" {
+			return jwk
+		}
+
+		if found != nil {
+			return nil
+		}
+
+		found = jwk
+	}
+
+	return found
+}
+
+func jwkFitsAlg(jwk *Jwk, alg string) bool {
+	if jwk.Alg != "" && jwk.Alg != alg {
+		return false
+	}
+
+	switch alg {
+	case "HS256":
+		return jwk.Kty == "oct"
+	case "RS256":
+		return jwk.Kty == "RSA"
+	case "ES256":
+		return jwk.Kty == "EC"
+	default:
+		return false
+	}
+}
+
+func (s *jwkSetImpl) load(ctx context.Context) ([]*Jwk, error) {
+	var body []byte
+	var err error
+
+	if s.File != "" {
+		body, err = os.ReadFile(s.File)
+	} else if s.Url != "" {
+		body, err = s.fetch(ctx)
+	} else {
+		err = errors.New("neither a JWKS file nor a JWKS URL is set")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("jwt: failed to load the JWKS: %w", err)
+	}
+
+	return parseJwks(body)
+}
+
+func (s *jwkSetImpl) fetch(ctx context.Context) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.Url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.Client.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the JWKS URL answered %d", response.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, jwksMaxSize))
+}
+
+// parseJwks skips the keys it cannot verify with, e.g. the encryption keys,
+// the JWKS of an SSO often carries more than the signing keys
+func parseJwks(body []byte) ([]*Jwk, error) {
+	jwks := new(struct {
+		Keys []jwkJson `json:"keys"`
+	})
+
+	if err := json.Unmarshal(body, jwks); err != nil {
+		return nil, fmt.Errorf("jwt: malformed JWKS: %w", err)
+	}
+
+	keys := []*Jwk{}
+
+	for _, jwkJson := range jwks.Keys {
+		if jwkJson.Use != "" && jwkJson.Use != "sig" {
+			continue
+		}
+
+		key, err := parseJwkKey(&jwkJson)
+
+		if err != nil {
+			continue
+		}
+
+		keys = append(keys, &Jwk{
+			Kid: jwkJson.Kid,
+			Kty: jwkJson.Kty,
+			Alg: jwkJson.Alg,
+			Key: key,
+		})
+	}
+
+	return keys, nil
+}
+
+func parseJwkKey(jwkJson *jwkJson) (any, error) {
+	switch jwkJson.Kty {
+	case "oct":
+		return decodeJwkMember(jwkJson.K)
+	case "RSA":
+		n, err := decodeJwkMember(jwkJson.N)
+
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeJwkMember(jwkJson.E)
+
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+
+		if publicKey.N.BitLen() < rsaMinBits || publicKey.E < 3 {
+			return nil, errors.New("weak RSA key")
+		}
+
+		return publicKey, nil
+	case "EC":
+		if jwkJson.Crv != "P-256" {
+			return nil, errors.New("unsupported curve")
+		}
+
+		x, err := decodeJwkMember(jwkJson.X)
+
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeJwkMember(jwkJson.Y)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("malformed P-256 point")
+		}
+
+		// ecdh rejects the points off the curve
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, errors.New("unsupported key type")
+	}
+}
+
+func decodeJwkMember(member string) ([]byte, error) {
+	if member == "" {
+		return nil, errors.New("missing key member")
+	}
+
+	return base64.RawURLEncoding.DecodeString(member)
+}
@@ -0,0 +1,7 @@
+package security
+
+import "context"
+
+type JwtVerifier interface {
+	Verify(ctx context.Context, token string) (*Principal, error)
+}
@@ -0,0 +1,268 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+)
+
+const defaultScopeClaim = "scope"
+
+var apiScopes = []string{ScopeCategoriesRead, ScopeCategoriesWrite, ScopeAdmin}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtVerifierImpl struct {
+	Jwt    *config.Jwt
+	JwkSet JwkSet
+}
+
+func NewJwtVerifierImpl(appConfig *config.AppConfig, jwkSet JwkSet) JwtVerifier {
+	return &jwtVerifierImpl{
+		Jwt:    &appConfig.Server.Jwt,
+		JwkSet: jwkSet,
+	}
+}
+
+// Verify answers the principal of a compact JWS signed with HS256, RS256 or
+// ES256 by a key of the JWKS. The subject is required, and so is exp, the
+// iss and aud are checked when they are configured
+func (v *jwtVerifierImpl) Verify(ctx context.Context, token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return nil, errors.New("jwt: malformed token")
+	}
+
+	header := new(jwtHeader)
+
+	if err := decodeJwtPart(parts[0], header); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return nil, errors.New("jwt: malformed signature")
+	}
+
+	jwk, err := v.JwkSet.Find(ctx, header.Kid, header.Alg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !verifyJwtSignature(header.Alg, jwk.Key, parts[0]+"."+parts[1], signature) {
+		return nil, errors.New("jwt: invalid signature")
+	}
+
+	claims := map[string]any{}
+
+	if err := decodeJwtPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	if err := v.verifyClaims(claims); err != nil {
+		return nil, err
+	}
+
+	subject, _ := claims["sub"].(string)
+	tenantId, _ := claims[v.Jwt.TenantClaim].(string)
+
+	return &Principal{
+		Id:       "jwt:" + subject,
+		Name:     subject,
+		TenantId: tenantId,
+		Scopes:   v.scopes(claims),
+	}, nil
+}
+
+func decodeJwtPart(part string, value any) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(part)
+
+	if err != nil {
+		return errors.New("jwt: malformed token")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(decoded))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(value); err != nil {
+		return errors.New("jwt: malformed token")
+	}
+
+	return nil
+}
+
+// verifyJwtSignature never trusts the alg alone, the key of the JWKS has to
+// fit it already, so an RSA public key is never taken as an HMAC secret
+func verifyJwtSignature(alg string, key any, signingInput string, signature []byte) bool {
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch alg {
+	case "HS256":
+		secret, ok := key.([]byte)
+
+		if !ok {
+			return false
+		}
+
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signingInput))
+
+		return hmac.Equal(mac.Sum(nil), signature)
+	case "RS256":
+		publicKey, ok := key.(*rsa.PublicKey)
+
+		return ok && rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature) == nil
+	case "ES256":
+		publicKey, ok := key.(*ecdsa.PublicKey)
+
+		if !ok || len(signature) != 64 {
+			return false
+		}
+
+		return ecdsa.Verify(publicKey, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:]))
+	default:
+		return false
+	}
+}
+
+func (v *jwtVerifierImpl) verifyClaims(claims map[string]any) error {
+	now := time.Now()
+	clockSkew := v.Jwt.ClockSkew * time.Second
+
+	expiresAt, err := jwtNumericDate(claims, "exp")
+
+	if err != nil {
+		return err
+	}
+
+	if expiresAt == nil {
+		return errors.New("jwt: missing exp claim")
+	}
+
+	if !now.Before(expiresAt.Add(clockSkew)) {
+		return errors.New("jwt: token is expired")
+	}
+
+	notBefore, err := jwtNumericDate(claims, "nbf")
+
+	if err != nil {
+		return err
+	}
+
+	if notBefore != nil && now.Before(notBefore.Add(-clockSkew)) {
+		return errors.New("jwt: token is not valid yet")
+	}
+
+	if subject, _ := claims["sub"].(string); subject == "" {
+		return errors.New("jwt: missing sub claim")
+	}
+
+	if issuer, _ := claims["iss"].(string); v.Jwt.Issuer != "" && issuer != v.Jwt.Issuer {
+		return errors.New("jwt: unexpected issuer")
+	}
+
+	if v.Jwt.Audience != "" && !slices.Contains(jwtStrings(claims["aud"]), v.Jwt.Audience) {
+		return errors.New("jwt: unexpected audience")
+	}
+
+	return nil
+}
+
+func jwtNumericDate(claims map[string]any, name string) (*time.Time, error) {
+	value, ok := claims[name]
+
+	if !ok {
+		return nil, nil
+	}
+
+	number, ok := value.(json.Number)
+
+	if !ok {
+		return nil, fmt.Errorf("jwt: malformed %s claim", name)
+	}
+
+	seconds, err := number.Float64()
+
+	if err != nil {
+		return nil, fmt.Errorf("jwt: malformed %s claim", name)
+	}
+
+	date := time.Unix(0, 0).Add(time.Duration(seconds * float64(time.Second)))
+
+	return &date, nil
+}
+
+// jwtStrings reads a claim holding a string or an array of strings
+func jwtStrings(value any) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		values := []string{}
+
+		for _, each := range value {
+			if each, ok := each.(string); ok {
+				values = append(values, each)
+			}
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
+
+// scopes maps the values of the scope claim, a space separated string like
+// the scope of OAuth or an array like the roles of most SSOs. The values named
+// like the scopes of the API pass as they are and the others are dropped
+func (v *jwtVerifierImpl) scopes(claims map[string]any) []string {
+	scopeClaim := v.Jwt.ScopeClaim
+
+	if scopeClaim == "" {
+		scopeClaim = defaultScopeClaim
+	}
+
+	values := jwtStrings(claims[scopeClaim])
+
+	if value, ok := claims[scopeClaim].(string); ok {
+		values = strings.Fields(value)
+	}
+
+	scopes := []string{}
+
+	for _, value := range values {
+		for _, scopeMapping := range v.Jwt.ScopeMappings {
+			if scopeMapping.Claim == value {
+				scopes = append(scopes, scopeMapping.Scopes...)
+			}
+		}
+
+		if slices.Contains(apiScopes, value) {
+			scopes = append(scopes, value)
+		}
+	}
+
+	slices.Sort(scopes)
+
+	return slices.Compact(scopes)
+}
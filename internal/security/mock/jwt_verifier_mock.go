@@ -0,0 +1,24 @@
+package security
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+type jwtVerifierMock struct {
+	Mock *mock.Mock
+}
+
+func NewJwtVerifierMock() *jwtVerifierMock {
+	return &jwtVerifierMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (v *jwtVerifierMock) Verify(ctx context.Context, token string) (*security.Principal, error) {
+	args := v.Mock.Called(ctx, token)
+	principal, _ := args.Get(0).(*security.Principal)
+	return principal, args.Error(1)
+}
@@ -0,0 +1,81 @@
+package security
+
+import (
+	"context"
+	"slices"
+)
+
+const (
+	ScopeCategoriesRead  = "categories:read"
+	ScopeCategoriesWrite = "categories:write"
+	// ScopeAdmin manages the API keys and the webhooks, and holds every other
+	// scope as well
+	ScopeAdmin = "admin"
+)
+
+// DefaultTenantId owns the rows from before the tenants, and is the tenant of
+// the bootstrap key
+const DefaultTenantId = "default"
+
+// Principal is the caller a request is authenticated as, Id is the id of its
+// API key or the subject of its token. AuthMode is the kind of its
+// credentials, empty for an anonymous caller. SigningSecret is set for the API
+// keys which require signed requests.
+type Principal struct {
+	Id            string
+	Name          string
+	TenantId      string
+	Scopes        []string
+	AuthMode      string
+	SigningSecret string
+}
+
+type principalKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, ScopeAdmin) || slices.Contains(p.Scopes, scope)
+}
+
+// RequireScope panics with 403 unless the principal of the context holds the
+// scope, the routes answer 401 to the requests without a principal first
+func RequireScope(ctx context.Context, scope string) {
+	if principal := PrincipalFromContext(ctx); principal == nil || !principal.HasScope(scope) {
+		panic(NewForbiddenError("the credentials do not have the " + scope + " scope"))
+	}
+}
+
+// RequireTenant answers the tenant of the principal of the context, or panics
+// with 403 for a token without the tenant claim
+func RequireTenant(ctx context.Context) string {
+	principal := PrincipalFromContext(ctx)
+
+	if principal == nil || principal.TenantId == "" {
+		panic(NewForbiddenError("the credentials do not belong to a tenant"))
+	}
+
+	return principal.TenantId
+}
+
+// ManagesTenant reports whether the principal manages the keys of the tenant,
+// the default tenant is the operator of the deployment and manages every
+// tenant
+func (p *Principal) ManagesTenant(tenantId string) bool {
+	return p.TenantId == DefaultTenantId || p.TenantId == tenantId
+}
+
+// RequireDefaultTenant panics with 403 unless the principal of the context
+// belongs to the default tenant
+func RequireDefaultTenant(ctx context.Context) {
+	if RequireTenant(ctx) != DefaultTenantId {
+		panic(NewForbiddenError("only the default tenant manages the tenants"))
+	}
+}
@@ -0,0 +1,120 @@
+package security
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+var (
+	apiKeyReader = &security.Principal{Id: "KEY-1", TenantId: "TENANT-1", Scopes: []string{security.ScopeCategoriesRead}, AuthMode: security.AuthModeApiKey}
+	jwtReader    = &security.Principal{Id: "jwt:user-1", TenantId: "TENANT-1", Scopes: []string{security.ScopeCategoriesRead}, AuthMode: security.AuthModeJwt}
+)
+
+func TestAuthorizeSuccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    security.AuthPolicy
+		principal *security.Principal
+	}{
+		{name: "Public Without Principal", policy: security.PublicPolicy(), principal: nil},
+		{name: "Public With Principal", policy: security.PublicPolicy(), principal: apiKeyReader},
+		{name: "Any Credentials", policy: security.ScopePolicy(""), principal: jwtReader},
+		{name: "Granted Scope", policy: security.ScopePolicy(security.ScopeCategoriesRead), principal: apiKeyReader},
+		{name: "API Key", policy: security.ApiKeyPolicy(security.ScopeCategoriesRead), principal: apiKeyReader},
+		{name: "Bearer Token", policy: security.JwtPolicy(""), principal: jwtReader},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ctx := t.Context()
+
+			if test.principal != nil {
+				ctx = security.ContextWithPrincipal(ctx, test.principal)
+			}
+
+			// Action
+			// ---SUT (Subject Under Test)
+			routeCtx := security.Authorize(ctx, test.policy)
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, test.principal, security.PrincipalFromContext(routeCtx))
+		})
+	}
+}
+
+func TestAuthorizePublicTenant(t *testing.T) {
+	t.Run("Anonymous Caller", func(t *testing.T) {
+		// Action
+		// ---SUT (Subject Under Test)
+		routeCtx := security.Authorize(t.Context(), security.PublicTenantPolicy("TENANT-2"))
+		// ---------------------------
+
+		// Assert
+		principal := security.PrincipalFromContext(routeCtx)
+
+		if assert.NotNil(t, principal) {
+			assert.Equal(t, "anonymous", principal.Id)
+			assert.Equal(t, "TENANT-2", principal.TenantId)
+			assert.Empty(t, principal.Scopes)
+		}
+	})
+
+	t.Run("Caller With Credentials Keeps Its Tenant", func(t *testing.T) {
+		// Arrange
+		ctx := security.ContextWithPrincipal(t.Context(), apiKeyReader)
+
+		// Action
+		// ---SUT (Subject Under Test)
+		routeCtx := security.Authorize(ctx, security.PublicTenantPolicy("TENANT-2"))
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, "TENANT-1", security.RequireTenant(routeCtx))
+	})
+}
+
+func TestAuthorizeFailed(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     security.AuthPolicy
+		principal  *security.Principal
+		statusCode int
+		detail     string
+	}{
+		{name: "Without Principal", policy: security.ScopePolicy(""), principal: nil, statusCode: http.StatusUnauthorized, detail: "unauthorized"},
+		{name: "Missing Scope", policy: security.ScopePolicy(security.ScopeAdmin), principal: apiKeyReader, statusCode: http.StatusForbidden, detail: "the credentials do not have the admin scope"},
+		{name: "Bearer Token On An API Key Route", policy: security.ApiKeyPolicy(""), principal: jwtReader, statusCode: http.StatusForbidden, detail: "the endpoint accepts the apikey credentials only"},
+		{name: "API Key On A Bearer Token Route", policy: security.JwtPolicy(""), principal: apiKeyReader, statusCode: http.StatusForbidden, detail: "the endpoint accepts the jwt credentials only"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ctx := t.Context()
+
+			if test.principal != nil {
+				ctx = security.ContextWithPrincipal(ctx, test.principal)
+			}
+
+			// Action & Assert
+			defer func() {
+				errRecover := recover()
+
+				if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+					assert.Equal(t, test.statusCode, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+					assert.Equal(t, test.detail, errRecover.(*exception.ErrorClientRequest).GetDetailError())
+				}
+			}()
+
+			// ---SUT (Subject Under Test)
+			security.Authorize(ctx, test.policy)
+			// ---------------------------
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package security
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+func setupJwksServer(t *testing.T, jwks *atomic.Value, requests *atomic.Int32) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		w.Header().Set("content-type", "application/json")
+		json.NewEncoder(w).Encode(jwks.Load())
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestJwkSetFindFromUrl(t *testing.T) {
+	// Arrange
+	jwks := new(atomic.Value)
+	jwks.Store(testJwks())
+
+	requests := new(atomic.Int32)
+
+	appConfig := &config.AppConfig{
+		Server: &config.Server{
+			Jwt: config.Jwt{
+				JwksUrl:     setupJwksServer(t, jwks, requests),
+				JwksRefresh: 300,
+			},
+		},
+	}
+
+	jwkSet := security.NewJwkSetImpl(appConfig)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	firstJwk, firstErr := jwkSet.Find(t.Context(), "rs", "RS256")
+	secondJwk, secondErr := jwkSet.Find(t.Context(), "es", "ES256")
+	// ---------------------------
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.Equal(t, "rs", firstJwk.Kid)
+
+	assert.NoError(t, secondErr)
+	assert.Equal(t, "es", secondJwk.Kid)
+
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestJwkSetFindWithoutKid(t *testing.T) {
+	// Arrange
+	appConfig := &config.AppConfig{
+		Server: &config.Server{
+			Jwt: config.Jwt{
+				JwksFile:    writeJwksFile(t, testJwks()),
+				JwksRefresh: 300,
+			},
+		},
+	}
+
+	jwkSet := security.NewJwkSetImpl(appConfig)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	jwk, err := jwkSet.Find(t.Context(), "", "ES256")
+	// ---------------------------
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "es", jwk.Kid)
+}
+
+func TestJwkSetRefreshFailed(t *testing.T) {
+	t.Run("Without A Source", func(t *testing.T) {
+		// Arrange
+		jwkSet := security.NewJwkSetImpl(&config.AppConfig{Server: new(config.Server)})
+
+		// Action
+		// ---SUT (Subject Under Test)
+		err := jwkSet.Refresh(t.Context())
+		// ---------------------------
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Keeps The Old Keys", func(t *testing.T) {
+		// Arrange
+		jwks := new(atomic.Value)
+		jwks.Store(testJwks())
+
+		requests := new(atomic.Int32)
+
+		appConfig := &config.AppConfig{
+			Server: &config.Server{
+				Jwt: config.Jwt{
+					JwksUrl:     setupJwksServer(t, jwks, requests),
+					JwksRefresh: 300,
+				},
+			},
+		}
+
+		jwkSet := security.NewJwkSetImpl(appConfig)
+
+		jwkSet.Refresh(t.Context())
+
+		jwks.Store(map[string]any{"keys": "malformed"})
+
+		// Action
+		// ---SUT (Subject Under Test)
+		err := jwkSet.Refresh(t.Context())
+		// ---------------------------
+
+		// Assert
+		assert.Error(t, err)
+
+		jwk, err := jwkSet.Find(t.Context(), "hs", "HS256")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hs", jwk.Kid)
+	})
+}
+
+func TestJwkSetUnknownKidRefetchesOnce(t *testing.T) {
+	// Arrange
+	jwks := new(atomic.Value)
+	jwks.Store(testJwks())
+
+	requests := new(atomic.Int32)
+
+	appConfig := &config.AppConfig{
+		Server: &config.Server{
+			Jwt: config.Jwt{
+				JwksUrl:     setupJwksServer(t, jwks, requests),
+				JwksRefresh: 300,
+			},
+		},
+	}
+
+	jwkSet := security.NewJwkSetImpl(appConfig)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	for range 3 {
+		jwkSet.Find(t.Context(), "unknown", "RS256")
+	}
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, int32(1), requests.Load())
+}
@@ -0,0 +1,255 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+var (
+	hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+	rsaKey     = generateRsaKey()
+	ecdsaKey   = generateEcdsaKey()
+)
+
+func generateRsaKey() *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	helper.PanicIfError(err)
+	return privateKey
+}
+
+func generateEcdsaKey() *ecdsa.PrivateKey {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	helper.PanicIfError(err)
+	return privateKey
+}
+
+func encodeJwkMember(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+func testJwks() map[string]any {
+	return map[string]any{
+		"keys": []map[string]any{
+			{"kid": "hs", "kty": "oct", "alg": "HS256", "k": encodeJwkMember(hmacSecret)},
+			{"kid": "rs", "kty": "RSA", "use": "sig", "n": encodeJwkMember(rsaKey.N.Bytes()), "e": encodeJwkMember(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kid": "es", "kty": "EC", "crv": "P-256", "x": encodeJwkMember(ecdsaKey.X.FillBytes(make([]byte, 32))), "y": encodeJwkMember(ecdsaKey.Y.FillBytes(make([]byte, 32)))},
+			{"kid": "enc", "kty": "RSA", "use": "enc", "n": encodeJwkMember(rsaKey.N.Bytes()), "e": "AQAB"},
+		},
+	}
+}
+
+func writeJwksFile(t *testing.T, jwks map[string]any) string {
+	body, err := json.Marshal(jwks)
+	helper.PanicIfError(err)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+
+	err = os.WriteFile(jwksFile, body, 0644)
+	helper.PanicIfError(err)
+
+	return jwksFile
+}
+
+func signJwt(alg string, kid string, claims map[string]any) string {
+	header, err := json.Marshal(map[string]any{"alg": alg, "kid": kid, "typ": "JWT"})
+	helper.PanicIfError(err)
+
+	payload, err := json.Marshal(claims)
+	helper.PanicIfError(err)
+
+	signingInput := encodeJwkMember(header) + "." + encodeJwkMember(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+
+	switch alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, hmacSecret)
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case "RS256":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+		helper.PanicIfError(err)
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, ecdsaKey, digest[:])
+		helper.PanicIfError(err)
+
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+
+	return signingInput + "." + encodeJwkMember(signature)
+}
+
+func setupJwtTestConfig(t *testing.T) *config.AppConfig {
+	return &config.AppConfig{
+		Server: &config.Server{
+			Jwt: config.Jwt{
+				JwksFile:    writeJwksFile(t, testJwks()),
+				JwksRefresh: 300,
+				Issuer:      "https://sso.example.com",
+				Audience:    "pzn-api",
+				ClockSkew:   30,
+				ScopeClaim:  "scope",
+				ScopeMappings: []config.JwtScopeMapping{
+					{Claim: "pzn.editor", Scopes: []string{security.ScopeCategoriesRead, security.ScopeCategoriesWrite}},
+				},
+				TenantClaim: "tenant",
+			},
+		},
+	}
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":    "user-1",
+		"iss":    "https://sso.example.com",
+		"aud":    []string{"other-api", "pzn-api"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"nbf":    time.Now().Unix(),
+		"scope":  "openid categories:read",
+		"tenant": "TENANT-1",
+	}
+}
+
+func TestJwtVerifySuccess(t *testing.T) {
+	appConfig := setupJwtTestConfig(t)
+
+	for _, test := range []struct{ alg, kid string }{{"HS256", "hs"}, {"RS256", "rs"}, {"ES256", "es"}} {
+		t.Run(test.alg, func(t *testing.T) {
+			// Arrange
+			token := signJwt(test.alg, test.kid, validClaims())
+
+			// Action
+			// ---SUT (Subject Under Test)
+			principal, err := security.NewJwtVerifierImpl(appConfig, security.NewJwkSetImpl(appConfig)).Verify(t.Context(), token)
+			// ---------------------------
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, &security.Principal{
+				Id:       "jwt:user-1",
+				Name:     "user-1",
+				TenantId: "TENANT-1",
+				Scopes:   []string{security.ScopeCategoriesRead},
+			}, principal)
+		})
+	}
+}
+
+func TestJwtVerifyScopeMappings(t *testing.T) {
+	// Arrange
+	appConfig := setupJwtTestConfig(t)
+
+	claims := validClaims()
+	claims["scope"] = []string{"pzn.editor", "categories:read", "unknown"}
+
+	// Action
+	// ---SUT (Subject Under Test)
+	principal, err := security.NewJwtVerifierImpl(appConfig, security.NewJwkSetImpl(appConfig)).Verify(t.Context(), signJwt("ES256", "es", claims))
+	// ---------------------------
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{security.ScopeCategoriesRead, security.ScopeCategoriesWrite}, principal.Scopes)
+}
+
+func TestJwtVerifyWithoutTenant(t *testing.T) {
+	// Arrange
+	appConfig := setupJwtTestConfig(t)
+
+	claims := validClaims()
+	delete(claims, "tenant")
+
+	// Action
+	// ---SUT (Subject Under Test)
+	principal, err := security.NewJwtVerifierImpl(appConfig, security.NewJwkSetImpl(appConfig)).Verify(t.Context(), signJwt("ES256", "es", claims))
+	// ---------------------------
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, principal.TenantId)
+}
+
+func TestJwtVerifyFailed(t *testing.T) {
+	appConfig := setupJwtTestConfig(t)
+
+	withClaim := func(name string, value any) string {
+		claims := validClaims()
+
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+
+		return signJwt("RS256", "rs", claims)
+	}
+
+	tamperedToken := signJwt("HS256", "hs", validClaims())
+	tamperedToken = tamperedToken[:len(tamperedToken)-2] + "AA"
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "Malformed Token", token: "not-a-token"},
+		{name: "Tampered Signature", token: tamperedToken},
+		{name: "Unknown Kid", token: signJwt("RS256", "unknown", validClaims())},
+		{name: "Alg Not Fitting The Key", token: signJwt("HS256", "rs", validClaims())},
+		{name: "Unsupported Alg", token: signJwt("none", "", validClaims())},
+		{name: "Encryption Key", token: signJwt("RS256", "enc", validClaims())},
+		{name: "Expired", token: withClaim("exp", time.Now().Add(-time.Minute).Unix())},
+		{name: "Without Exp", token: withClaim("exp", nil)},
+		{name: "Not Valid Yet", token: withClaim("nbf", time.Now().Add(time.Minute).Unix())},
+		{name: "Without Sub", token: withClaim("sub", nil)},
+		{name: "Unexpected Issuer", token: withClaim("iss", "https://evil.example.com")},
+		{name: "Unexpected Audience", token: withClaim("aud", "other-api")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Action
+			// ---SUT (Subject Under Test)
+			principal, err := security.NewJwtVerifierImpl(appConfig, security.NewJwkSetImpl(appConfig)).Verify(t.Context(), test.token)
+			// ---------------------------
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, principal)
+		})
+	}
+}
+
+func TestJwtVerifyClockSkew(t *testing.T) {
+	// Arrange
+	appConfig := setupJwtTestConfig(t)
+
+	claims := validClaims()
+	claims["exp"] = time.Now().Add(-10 * time.Second).Unix()
+	claims["nbf"] = time.Now().Add(10 * time.Second).Unix()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	_, err := security.NewJwtVerifierImpl(appConfig, security.NewJwkSetImpl(appConfig)).Verify(t.Context(), signJwt("HS256", "hs", claims))
+	// ---------------------------
+
+	// Assert
+	assert.NoError(t, err)
+}
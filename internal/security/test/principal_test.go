@@ -0,0 +1,161 @@
+package security
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+func TestHasScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		scope  string
+		has    bool
+	}{
+		{name: "Granted Scope", scopes: []string{security.ScopeCategoriesRead}, scope: security.ScopeCategoriesRead, has: true},
+		{name: "Missing Scope", scopes: []string{security.ScopeCategoriesRead}, scope: security.ScopeCategoriesWrite, has: false},
+		{name: "Admin Holds Every Scope", scopes: []string{security.ScopeAdmin}, scope: security.ScopeCategoriesWrite, has: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			principal := &security.Principal{Id: "KEY-1", Scopes: test.scopes}
+
+			// Action
+			// ---SUT (Subject Under Test)
+			has := principal.HasScope(test.scope)
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, test.has, has)
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	t.Run("Granted Scope", func(t *testing.T) {
+		// Arrange
+		ctx := security.ContextWithPrincipal(t.Context(), &security.Principal{Id: "KEY-1", Scopes: []string{security.ScopeCategoriesRead}})
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			security.RequireScope(ctx, security.ScopeCategoriesRead)
+			// ---------------------------
+		})
+	})
+
+	tests := []struct {
+		name      string
+		principal *security.Principal
+	}{
+		{name: "Missing Scope", principal: &security.Principal{Id: "KEY-1", Scopes: []string{security.ScopeCategoriesRead}}},
+		{name: "Without Principal", principal: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ctx := t.Context()
+
+			if test.principal != nil {
+				ctx = security.ContextWithPrincipal(ctx, test.principal)
+			}
+
+			// Action & Assert
+			defer func() {
+				errRecover := recover()
+
+				if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+					assert.Equal(t, http.StatusForbidden, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+				}
+			}()
+
+			// ---SUT (Subject Under Test)
+			security.RequireScope(ctx, security.ScopeAdmin)
+			// ---------------------------
+		})
+	}
+}
+
+func TestManagesTenant(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantId string
+		manages  bool
+	}{
+		{name: "Own Tenant", tenantId: "TENANT-1", manages: true},
+		{name: "Another Tenant", tenantId: "TENANT-2", manages: false},
+		{name: "Default Tenant", tenantId: security.DefaultTenantId, manages: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			principal := &security.Principal{Id: "KEY-1", TenantId: test.tenantId}
+
+			// Action
+			// ---SUT (Subject Under Test)
+			manages := principal.ManagesTenant("TENANT-1")
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, test.manages, manages)
+		})
+	}
+}
+
+func TestRequireTenant(t *testing.T) {
+	t.Run("Principal Of A Tenant", func(t *testing.T) {
+		// Arrange
+		ctx := security.ContextWithPrincipal(t.Context(), &security.Principal{Id: "KEY-1", TenantId: "TENANT-1"})
+
+		// Action
+		// ---SUT (Subject Under Test)
+		tenantId := security.RequireTenant(ctx)
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, "TENANT-1", tenantId)
+	})
+
+	tests := []struct {
+		name      string
+		principal *security.Principal
+		require   func(ctx context.Context)
+	}{
+		{name: "Token Without Tenant", principal: &security.Principal{Id: "jwt:user-1"}, require: func(ctx context.Context) { security.RequireTenant(ctx) }},
+		{name: "Without Principal", principal: nil, require: func(ctx context.Context) { security.RequireTenant(ctx) }},
+		{name: "Not The Default Tenant", principal: &security.Principal{Id: "KEY-1", TenantId: "TENANT-1"}, require: security.RequireDefaultTenant},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ctx := t.Context()
+
+			if test.principal != nil {
+				ctx = security.ContextWithPrincipal(ctx, test.principal)
+			}
+
+			// Action & Assert
+			defer func() {
+				errRecover := recover()
+
+				if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+					assert.Equal(t, http.StatusForbidden, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+				}
+			}()
+
+			// ---SUT (Subject Under Test)
+			test.require(ctx)
+			// ---------------------------
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package stream
+
+import "github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+
+// Broker fans the category events out to the subscribed streams and keeps the
+// latest of them, so a stream resumes from its Last-Event-ID. A stream gets
+// the events of its tenant only, an empty tenant gets the events of every
+// tenant.
+type Broker interface {
+	Publish(event *model.CategoryEventResponse)
+	Subscribe(tenantId string, lastEventId string) *Subscription
+	Unsubscribe(subscription *Subscription)
+	Close()
+	IsClosed() bool
+}
+
+type Subscription struct {
+	// Replay holds the events published after the Last-Event-ID
+	Replay []model.CategoryEventResponse
+	// Reset is set when the Last-Event-ID is no longer in the log, the
+	// subscriber has missed events and must reload the categories
+	Reset bool
+	// LastEventId is the latest event of the tenant in the log when the
+	// subscription started
+	LastEventId string
+	// Events is closed when the subscriber falls behind or the broker closes
+	Events <-chan model.CategoryEventResponse
+
+	events   chan model.CategoryEventResponse
+	tenantId string
+}
+
+func (s *Subscription) receives(event *model.CategoryEventResponse) bool {
+	return s.tenantId == "" || s.tenantId == event.TenantId
+}
@@ -0,0 +1,153 @@
+package stream
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+// brokerImpl keeps the log in the order the events arrive, which is the
+// commit order of NOTIFY, so the replay never skips an event committed late
+// with a lower id
+type brokerImpl struct {
+	AppConfig     *config.AppConfig
+	mutex         sync.Mutex
+	log           []model.CategoryEventResponse
+	logIds        map[int64]bool
+	subscriptions map[*Subscription]bool
+	closed        bool
+}
+
+func NewBrokerImpl(appConfig *config.AppConfig) Broker {
+	return &brokerImpl{
+		AppConfig:     appConfig,
+		logIds:        map[int64]bool{},
+		subscriptions: map[*Subscription]bool{},
+	}
+}
+
+// Publish ignores the events in the log already, the listener publishes the
+// events it backfills after a reconnect again
+func (b *brokerImpl) Publish(event *model.CategoryEventResponse) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed || b.logIds[event.Id] {
+		return
+	}
+
+	b.log = append(b.log, *event)
+	b.logIds[event.Id] = true
+
+	if len(b.log) > b.AppConfig.Stream.LogSize {
+		delete(b.logIds, b.log[0].Id)
+		b.log = b.log[1:]
+	}
+
+	for subscription := range b.subscriptions {
+		if !subscription.receives(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- *event:
+		default:
+			// A slow subscriber never holds the others back, it resumes from
+			// its Last-Event-ID once it reconnects
+			b.remove(subscription)
+		}
+	}
+}
+
+func (b *brokerImpl) Subscribe(tenantId string, lastEventId string) *Subscription {
+	events := make(chan model.CategoryEventResponse, b.AppConfig.Stream.BufferSize)
+
+	subscription := &Subscription{
+		Events:   events,
+		events:   events,
+		tenantId: tenantId,
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for i := len(b.log) - 1; i >= 0; i-- {
+		if subscription.receives(&b.log[i]) {
+			subscription.LastEventId = strconv.FormatInt(b.log[i].Id, 10)
+			break
+		}
+	}
+
+	if lastEventId != "" {
+		subscription.Replay, subscription.Reset = b.replay(subscription, lastEventId)
+	}
+
+	if b.closed {
+		close(events)
+	} else {
+		b.subscriptions[subscription] = true
+	}
+
+	return subscription
+}
+
+func (b *brokerImpl) Unsubscribe(subscription *Subscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.subscriptions[subscription] {
+		b.remove(subscription)
+	}
+}
+
+// Close ends every stream, so the server shuts down without waiting for them
+func (b *brokerImpl) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.closed = true
+
+	for subscription := range b.subscriptions {
+		b.remove(subscription)
+	}
+}
+
+func (b *brokerImpl) IsClosed() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.closed
+}
+
+func (b *brokerImpl) replay(subscription *Subscription, lastEventId string) ([]model.CategoryEventResponse, bool) {
+	id, err := strconv.ParseInt(lastEventId, 10, 64)
+
+	if err != nil || !b.logIds[id] {
+		return nil, true
+	}
+
+	for i := range b.log {
+		if b.log[i].Id != id {
+			continue
+		}
+
+		replay := []model.CategoryEventResponse{}
+
+		for _, event := range b.log[i+1:] {
+			if subscription.receives(&event) {
+				replay = append(replay, event)
+			}
+		}
+
+		return replay, false
+	}
+
+	return nil, true
+}
+
+func (b *brokerImpl) remove(subscription *Subscription) {
+	delete(b.subscriptions, subscription)
+	close(subscription.events)
+}
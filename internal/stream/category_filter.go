@@ -0,0 +1,103 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+// CategoryFilter holds the subscriptions of one WebSocket connection. The
+// categories are flat, so a subscription covers either some category ids or
+// all of the categories.
+type CategoryFilter struct {
+	mutex            sync.Mutex
+	maxSubscriptions int
+	sequence         int
+	order            []string
+	subscriptions    map[string]map[string]bool
+}
+
+func NewCategoryFilter(maxSubscriptions int) *CategoryFilter {
+	return &CategoryFilter{
+		maxSubscriptions: maxSubscriptions,
+		subscriptions:    map[string]map[string]bool{},
+	}
+}
+
+// Subscribe reports the id of the new subscription, no category ids subscribe
+// to all of the categories
+func (f *CategoryFilter) Subscribe(categoryIds []string) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.subscriptions) >= f.maxSubscriptions {
+		return "", fmt.Errorf("a connection must not have more than %d subscriptions", f.maxSubscriptions)
+	}
+
+	var ids map[string]bool
+
+	if len(categoryIds) > 0 {
+		ids = map[string]bool{}
+
+		for _, categoryId := range categoryIds {
+			if categoryId == "" || len(categoryId) > 36 {
+				return "", fmt.Errorf("category id %q is not valid", categoryId)
+			}
+
+			ids[categoryId] = true
+		}
+	}
+
+	f.sequence++
+
+	subscriptionId := fmt.Sprintf("sub-%d", f.sequence)
+
+	f.subscriptions[subscriptionId] = ids
+	f.order = append(f.order, subscriptionId)
+
+	return subscriptionId, nil
+}
+
+func (f *CategoryFilter) Unsubscribe(subscriptionId string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.subscriptions[subscriptionId]; !ok {
+		return false
+	}
+
+	delete(f.subscriptions, subscriptionId)
+
+	for i, eachId := range f.order {
+		if eachId == subscriptionId {
+			f.order = append(f.order[:i], f.order[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+// Match lists the subscriptions the event belongs to in the order they were made
+func (f *CategoryFilter) Match(event *model.CategoryEventResponse) []string {
+	category := new(model.CategoryResponse)
+
+	if err := json.Unmarshal(event.Data, category); err != nil {
+		return nil
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	matches := []string{}
+
+	for _, subscriptionId := range f.order {
+		if ids := f.subscriptions[subscriptionId]; ids == nil || ids[category.Id] {
+			matches = append(matches, subscriptionId)
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,8 @@
+package stream
+
+import "context"
+
+// Listener publishes the category events which Postgres notifies to the broker
+type Listener interface {
+	Run(ctx context.Context)
+}
@@ -0,0 +1,139 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model/converter"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+)
+
+const CategoryEventsChannel = "category_events"
+
+var categoryEventTypes = []string{entity.EventCategoryCreated, entity.EventCategoryUpdated, entity.EventCategoryDeleted}
+
+// categoryEventPayload is a notification of notify_category_event, the tenant
+// of the event is never sent to the streams
+type categoryEventPayload struct {
+	model.CategoryEventResponse
+	TenantId string `json:"tenant_id"`
+}
+
+type listenerImpl struct {
+	AppConfig             *config.AppConfig
+	Connect               db.PgxConnector
+	DB                    db.PgxPool
+	Logger                *logrus.Logger
+	OutboxEventRepository repository.OutboxEventRepository
+	Broker                Broker
+}
+
+func NewListenerImpl(appConfig *config.AppConfig, connect db.PgxConnector, database db.PgxPool, logger *logrus.Logger, outboxEventRepository repository.OutboxEventRepository, broker Broker) Listener {
+	return &listenerImpl{
+		AppConfig:             appConfig,
+		Connect:               connect,
+		DB:                    database,
+		Logger:                logger,
+		OutboxEventRepository: outboxEventRepository,
+		Broker:                broker,
+	}
+}
+
+// Run listens until the context is done, and reconnects with a doubling wait
+// whenever the connection is lost
+func (l *listenerImpl) Run(ctx context.Context) {
+	wait := time.Second
+
+	for {
+		listened, err := l.listen(ctx)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if listened {
+			wait = time.Second
+		}
+
+		l.Logger.WithError(err).Warnf("the category event listener is reconnecting in %s", wait)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		wait = min(2*wait, l.AppConfig.Stream.ReconnectMax*time.Second)
+	}
+}
+
+// listen reports whether it got to listen before the connection failed
+func (l *listenerImpl) listen(ctx context.Context) (bool, error) {
+	conn, err := l.Connect(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+CategoryEventsChannel)
+
+	if err != nil {
+		return false, err
+	}
+
+	// The events committed while nobody listened are loaded after LISTEN, so
+	// none of them falls in between
+	if err := l.backfill(ctx); err != nil {
+		return true, err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+
+		if err != nil {
+			return true, err
+		}
+
+		payload := new(categoryEventPayload)
+
+		if err := json.Unmarshal([]byte(notification.Payload), payload); err != nil {
+			l.Logger.WithError(err).Error("the category event listener received a malformed notification")
+			continue
+		}
+
+		payload.CategoryEventResponse.TenantId = payload.TenantId
+
+		l.Broker.Publish(&payload.CategoryEventResponse)
+	}
+}
+
+func (l *listenerImpl) backfill(ctx context.Context) (err error) {
+	defer func() {
+		if errRecover := recover(); errRecover != nil {
+			err = fmt.Errorf("failed to backfill the category events: %v", errRecover)
+		}
+	}()
+
+	tx, errBegin := l.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(errBegin, "stream > listener > backfill")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	outboxEvents := l.OutboxEventRepository.FindLatest(ctx, tx, categoryEventTypes, l.AppConfig.Stream.LogSize)
+
+	for i := range outboxEvents {
+		l.Broker.Publish(converter.OutboxEventToCategoryEventResponse(&outboxEvents[i]))
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+)
+
+var appTestConfig = &config.AppConfig{
+	Stream: &config.Stream{
+		LogSize:      3,
+		BufferSize:   2,
+		Heartbeat:    15,
+		ReconnectMax: 1,
+	},
+}
+
+func newCategoryEvent(id int64) *model.CategoryEventResponse {
+	return &model.CategoryEventResponse{
+		Id:   id,
+		Type: "category.created",
+		Data: []byte(`{"id":"CAT-1","name":"Gadget"}`),
+	}
+}
+
+func eventIds(events []model.CategoryEventResponse) []int64 {
+	ids := []int64{}
+
+	for _, event := range events {
+		ids = append(ids, event.Id)
+	}
+
+	return ids
+}
+
+func TestBrokerPublish(t *testing.T) {
+	// Arrange
+	broker := stream.NewBrokerImpl(appTestConfig)
+
+	subscription := broker.Subscribe("", "")
+	defer broker.Unsubscribe(subscription)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	broker.Publish(newCategoryEvent(1))
+	broker.Publish(newCategoryEvent(1))
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, int64(1), (<-subscription.Events).Id)
+	assert.Empty(t, subscription.Events, "an event in the log already is not published again")
+	assert.Empty(t, subscription.Replay)
+	assert.False(t, subscription.Reset)
+}
+
+func TestBrokerSubscribe(t *testing.T) {
+	// Arrange
+	// Events 4 and 2 arrive in their commit order, the log keeps the latest three
+	broker := stream.NewBrokerImpl(appTestConfig)
+
+	for _, id := range []int64{1, 4, 2, 5} {
+		broker.Publish(newCategoryEvent(id))
+	}
+
+	tests := []struct {
+		name        string
+		lastEventId string
+		replay      []int64
+		reset       bool
+	}{
+		{name: "Without Last Event Id", lastEventId: "", replay: []int64{}},
+		{name: "Replays The Events Arrived After", lastEventId: "4", replay: []int64{2, 5}},
+		{name: "Latest Event", lastEventId: "5", replay: []int64{}},
+		{name: "Evicted From The Log", lastEventId: "1", replay: []int64{}, reset: true},
+		{name: "Unknown Event", lastEventId: "99", replay: []int64{}, reset: true},
+		{name: "Malformed Event Id", lastEventId: "abc", replay: []int64{}, reset: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Action
+			// ---SUT (Subject Under Test)
+			subscription := broker.Subscribe("", test.lastEventId)
+			defer broker.Unsubscribe(subscription)
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, test.replay, eventIds(subscription.Replay))
+			assert.Equal(t, test.reset, subscription.Reset)
+			assert.Equal(t, "5", subscription.LastEventId)
+		})
+	}
+}
+
+func TestBrokerTenants(t *testing.T) {
+	// Arrange
+	broker := stream.NewBrokerImpl(appTestConfig)
+
+	for _, event := range []struct {
+		id       int64
+		tenantId string
+	}{{1, "TENANT-1"}, {2, "TENANT-2"}, {3, "TENANT-1"}, {4, "TENANT-2"}} {
+		categoryEvent := newCategoryEvent(event.id)
+		categoryEvent.TenantId = event.tenantId
+
+		broker.Publish(categoryEvent)
+	}
+
+	// Action
+	// ---SUT (Subject Under Test)
+	subscription := broker.Subscribe("TENANT-1", "2")
+	defer broker.Unsubscribe(subscription)
+
+	allSubscription := broker.Subscribe("", "")
+	defer broker.Unsubscribe(allSubscription)
+
+	otherEvent := newCategoryEvent(5)
+	otherEvent.TenantId = "TENANT-2"
+
+	broker.Publish(otherEvent)
+
+	event := newCategoryEvent(6)
+	event.TenantId = "TENANT-1"
+
+	broker.Publish(event)
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, []int64{3}, eventIds(subscription.Replay))
+	assert.Equal(t, "3", subscription.LastEventId)
+	assert.Equal(t, int64(6), (<-subscription.Events).Id, "the event of another tenant is never sent")
+	assert.Empty(t, subscription.Events)
+
+	assert.Equal(t, "4", allSubscription.LastEventId)
+	assert.Equal(t, int64(5), (<-allSubscription.Events).Id)
+	assert.Equal(t, int64(6), (<-allSubscription.Events).Id)
+}
+
+func TestBrokerDropsSlowSubscriber(t *testing.T) {
+	// Arrange
+	broker := stream.NewBrokerImpl(appTestConfig)
+
+	slowSubscription := broker.Subscribe("", "")
+	subscription := broker.Subscribe("", "")
+	defer broker.Unsubscribe(subscription)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	for _, id := range []int64{1, 2} {
+		broker.Publish(newCategoryEvent(id))
+	}
+
+	<-subscription.Events
+	<-subscription.Events
+
+	broker.Publish(newCategoryEvent(3))
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, int64(3), (<-subscription.Events).Id)
+
+	assert.Equal(t, int64(1), (<-slowSubscription.Events).Id)
+	assert.Equal(t, int64(2), (<-slowSubscription.Events).Id)
+
+	_, ok := <-slowSubscription.Events
+	assert.False(t, ok, "the buffer of the slow subscriber is full, it is dropped")
+
+	assert.NotPanics(t, func() {
+		broker.Unsubscribe(slowSubscription)
+	})
+}
+
+func TestBrokerClose(t *testing.T) {
+	// Arrange
+	broker := stream.NewBrokerImpl(appTestConfig)
+
+	subscription := broker.Subscribe("", "")
+
+	// Action
+	// ---SUT (Subject Under Test)
+	broker.Close()
+	// ---------------------------
+
+	// Assert
+	_, ok := <-subscription.Events
+	assert.False(t, ok)
+
+	_, ok = <-broker.Subscribe("", "").Events
+	assert.False(t, ok, "a subscription after the close ends at once")
+
+	assert.NotPanics(t, func() {
+		broker.Publish(newCategoryEvent(1))
+		broker.Unsubscribe(subscription)
+	})
+}
@@ -0,0 +1,82 @@
+package stream
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+)
+
+func newCategoryEventOf(categoryId string) *model.CategoryEventResponse {
+	return &model.CategoryEventResponse{
+		Id:   1,
+		Type: "category.updated",
+		Data: []byte(fmt.Sprintf(`{"id":%q,"name":"Gadget"}`, categoryId)),
+	}
+}
+
+func TestCategoryFilterMatch(t *testing.T) {
+	// Arrange
+	filter := stream.NewCategoryFilter(3)
+
+	someId, err := filter.Subscribe([]string{"CAT-1", "CAT-2"})
+	assert.NoError(t, err)
+
+	allId, err := filter.Subscribe(nil)
+	assert.NoError(t, err)
+
+	otherId, err := filter.Subscribe([]string{"CAT-2"})
+	assert.NoError(t, err)
+
+	// Action & Assert
+	// ---SUT (Subject Under Test)
+	assert.Equal(t, []string{"sub-1", "sub-2", "sub-3"}, []string{someId, allId, otherId})
+
+	assert.Equal(t, []string{"sub-1", "sub-2"}, filter.Match(newCategoryEventOf("CAT-1")))
+	assert.Equal(t, []string{"sub-1", "sub-2", "sub-3"}, filter.Match(newCategoryEventOf("CAT-2")))
+	assert.Equal(t, []string{"sub-2"}, filter.Match(newCategoryEventOf("CAT-3")))
+
+	assert.True(t, filter.Unsubscribe("sub-2"))
+	assert.False(t, filter.Unsubscribe("sub-2"))
+
+	assert.Empty(t, filter.Match(newCategoryEventOf("CAT-3")))
+	assert.Empty(t, filter.Match(&model.CategoryEventResponse{Data: []byte(`not a json`)}))
+	// ---------------------------
+}
+
+func TestCategoryFilterSubscribeFailed(t *testing.T) {
+	t.Run("Too Many Subscriptions", func(t *testing.T) {
+		// Arrange
+		filter := stream.NewCategoryFilter(1)
+
+		_, err := filter.Subscribe(nil)
+		assert.NoError(t, err)
+
+		// Action
+		// ---SUT (Subject Under Test)
+		_, err = filter.Subscribe([]string{"CAT-1"})
+		// ---------------------------
+
+		// Assert
+		assert.EqualError(t, err, "a connection must not have more than 1 subscriptions")
+	})
+
+	for _, categoryId := range []string{"", strings.Repeat("A", 37)} {
+		t.Run(fmt.Sprintf("Invalid Category Id %q", categoryId), func(t *testing.T) {
+			// Arrange
+			filter := stream.NewCategoryFilter(1)
+
+			// Action
+			// ---SUT (Subject Under Test)
+			_, err := filter.Subscribe([]string{"CAT-1", categoryId})
+			// ---------------------------
+
+			// Assert
+			assert.EqualError(t, err, fmt.Sprintf("category id %q is not valid", categoryId))
+			assert.Empty(t, filter.Match(newCategoryEventOf("CAT-1")), "a failed subscription is not kept")
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	internal_repository_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+)
+
+var logger = func() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}()
+
+// fakeListenConn hands out the notifications sent to it, and fails once the
+// channel is closed like a lost connection
+type fakeListenConn struct {
+	notifications chan *pgconn.Notification
+	listened      []string
+}
+
+func (c *fakeListenConn) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	c.listened = append(c.listened, sql)
+	return pgconn.NewCommandTag("LISTEN"), nil
+}
+
+func (c *fakeListenConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case notification, ok := <-c.notifications:
+		if !ok {
+			return nil, errors.New("conn closed")
+		}
+
+		return notification, nil
+	}
+}
+
+func (c *fakeListenConn) Close(ctx context.Context) error {
+	return nil
+}
+
+func newNotification(payload string) *pgconn.Notification {
+	return &pgconn.Notification{Channel: stream.CategoryEventsChannel, Payload: payload}
+}
+
+func receiveEventIds(t *testing.T, subscription *stream.Subscription, count int) []int64 {
+	ids := []int64{}
+
+	for range count {
+		select {
+		case event := <-subscription.Events:
+			ids = append(ids, event.Id)
+		case <-time.After(3 * time.Second):
+			t.Fatalf("received %d of %d events", len(ids), count)
+		}
+	}
+
+	return ids
+}
+
+func runListener(ctx context.Context, listener stream.Listener) chan bool {
+	doneChan := make(chan bool)
+
+	go func() {
+		listener.Run(ctx)
+
+		doneChan <- true
+	}()
+
+	return doneChan
+}
+
+func TestListenerRun(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+
+	outboxEventRepository.Mock.On("FindLatest", mock.Anything, mock.Anything, []string{entity.EventCategoryCreated, entity.EventCategoryUpdated, entity.EventCategoryDeleted}, 3).Return([]entity.OutboxEvent{
+		{Id: 1, TenantId: "TENANT-1", EventType: entity.EventCategoryCreated, Payload: []byte(`{"id":"CAT-1","name":"Gadget"}`)},
+		{Id: 2, TenantId: "TENANT-2", EventType: entity.EventCategoryUpdated, Payload: []byte(`{"id":"CAT-1","name":"Gadgets"}`)},
+	})
+
+	conn := &fakeListenConn{notifications: make(chan *pgconn.Notification, 3)}
+
+	conn.notifications <- newNotification(`{"id":2,"type":"category.updated","data":{"id":"CAT-1","name":"Gadgets"}}`)
+	conn.notifications <- newNotification(`not a json`)
+	conn.notifications <- newNotification(`{"id":3,"tenant_id":"TENANT-1","type":"category.deleted","created_at":"2026-10-19T14:00:00Z","data":{"id":"CAT-1","name":"Gadgets"}}`)
+
+	connector := func(ctx context.Context) (db.PgxListenConn, error) {
+		return conn, nil
+	}
+
+	broker := stream.NewBrokerImpl(appTestConfig)
+
+	subscription := broker.Subscribe("", "")
+	defer broker.Unsubscribe(subscription)
+
+	tenantSubscription := broker.Subscribe("TENANT-1", "")
+	defer broker.Unsubscribe(tenantSubscription)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Action
+	// ---SUT (Subject Under Test)
+	doneChan := runListener(ctx, stream.NewListenerImpl(appTestConfig, connector, pool, logger, outboxEventRepository, broker))
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, []int64{1, 2, 3}, receiveEventIds(t, subscription, 3), "the notified event 2 is backfilled already")
+	assert.Equal(t, []int64{1, 3}, receiveEventIds(t, tenantSubscription, 2), "the tenant of the notification is kept")
+
+	cancel()
+	<-doneChan
+
+	assert.Equal(t, []string{"LISTEN category_events"}, conn.listened)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestListenerReconnect(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+
+	// The event 2 is committed while the listener reconnects
+	outboxEventRepository.Mock.On("FindLatest", mock.Anything, mock.Anything, mock.Anything, 3).Return([]entity.OutboxEvent{
+		{Id: 1, EventType: entity.EventCategoryCreated, Payload: []byte(`{}`)},
+	}).Once()
+
+	outboxEventRepository.Mock.On("FindLatest", mock.Anything, mock.Anything, mock.Anything, 3).Return([]entity.OutboxEvent{
+		{Id: 1, EventType: entity.EventCategoryCreated, Payload: []byte(`{}`)},
+		{Id: 2, EventType: entity.EventCategoryUpdated, Payload: []byte(`{}`)},
+	}).Once()
+
+	lostConn := &fakeListenConn{notifications: make(chan *pgconn.Notification)}
+	close(lostConn.notifications)
+
+	conn := &fakeListenConn{notifications: make(chan *pgconn.Notification)}
+
+	connects := 0
+
+	connector := func(ctx context.Context) (db.PgxListenConn, error) {
+		connects++
+
+		switch connects {
+		case 1:
+			return lostConn, nil
+		case 2:
+			return nil, errors.New("connection refused")
+		default:
+			return conn, nil
+		}
+	}
+
+	broker := stream.NewBrokerImpl(appTestConfig)
+
+	subscription := broker.Subscribe("", "")
+	defer broker.Unsubscribe(subscription)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Action
+	// ---SUT (Subject Under Test)
+	doneChan := runListener(ctx, stream.NewListenerImpl(appTestConfig, connector, pool, logger, outboxEventRepository, broker))
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, []int64{1, 2}, receiveEventIds(t, subscription, 2))
+
+	cancel()
+	<-doneChan
+
+	assert.Equal(t, 3, connects)
+	assert.NoError(t, pool.ExpectationsWereMet())
+	outboxEventRepository.Mock.AssertExpectations(t)
+}
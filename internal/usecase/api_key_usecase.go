@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+type ApiKeyUseCase interface {
+	Authenticate(ctx context.Context, apiKey string) *security.Principal
+	ClaimNonce(ctx context.Context, apiKeyId string, nonce string) bool
+	Create(ctx context.Context, requestBody *model.CreateApiKeyRequest) *model.ApiKeyResponse
+	Rotate(ctx context.Context, apiKeyId string) *model.ApiKeyResponse
+	Revoke(ctx context.Context, apiKeyId string)
+	FindAll(ctx context.Context) []model.ApiKeyResponse
+}
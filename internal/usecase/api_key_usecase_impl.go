@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model/converter"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+const (
+	apiKeyPrefix       = "pzn_"
+	apiKeySecretLength = 40
+	// apiKeyShownLength is the start of a key kept in plain text, so a key is
+	// told apart in the list without revealing it
+	apiKeyShownLength   = 12
+	signingSecretPrefix = "sig_"
+)
+
+type apiKeyUseCaseImpl struct {
+	AppConfig        *config.AppConfig
+	DB               db.PgxPool
+	Validator        security.Validation
+	IdGenerator      security.IdGenerator
+	ApiKeyRepository repository.ApiKeyRepository
+	TenantRepository repository.TenantRepository
+	NonceRepository  repository.RequestNonceRepository
+}
+
+func NewApiKeyUseCaseImpl(appConfig *config.AppConfig, db db.PgxPool, validate security.Validation, idGenerator security.IdGenerator, apiKeyRepository repository.ApiKeyRepository, tenantRepository repository.TenantRepository, nonceRepository repository.RequestNonceRepository) ApiKeyUseCase {
+	return &apiKeyUseCaseImpl{
+		AppConfig:        appConfig,
+		DB:               db,
+		Validator:        validate,
+		IdGenerator:      idGenerator,
+		ApiKeyRepository: apiKeyRepository,
+		TenantRepository: tenantRepository,
+		NonceRepository:  nonceRepository,
+	}
+}
+
+// hashApiKey needs no salt nor stretching, the keys are long random secrets
+// and never guessable like the passwords
+func hashApiKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func (u *apiKeyUseCaseImpl) generateKey(apiKey *entity.ApiKey) string {
+	secret, err := u.IdGenerator.Generate(apiKeySecretLength)
+	helper.InternalServerPanicIfError(err, "api key > usecase > generateKey")
+
+	key := apiKeyPrefix + secret
+
+	apiKey.KeyPrefix = key[:apiKeyShownLength]
+	apiKey.KeyHash = hashApiKey(key)
+
+	return key
+}
+
+// generateSigningSecret is kept in plain text, the server signs the requests
+// with it to check their signatures
+func (u *apiKeyUseCaseImpl) generateSigningSecret(apiKey *entity.ApiKey) string {
+	secret, err := u.IdGenerator.Generate(apiKeySecretLength)
+	helper.InternalServerPanicIfError(err, "api key > usecase > generateSigningSecret")
+
+	signingSecret := signingSecretPrefix + secret
+
+	apiKey.SigningSecret = &signingSecret
+
+	return signingSecret
+}
+
+// requireManagedKey hides the keys of the other tenants as if they were not
+// there, only the default tenant manages them
+func requireManagedKey(ctx context.Context, apiKey *entity.ApiKey) {
+	if principal := security.PrincipalFromContext(ctx); principal == nil || !principal.ManagesTenant(apiKey.TenantId) {
+		panic(exception.NewErrorClientRequest(errors.New("api key of another tenant"), http.StatusNotFound, "api key is not found"))
+	}
+}
+
+// Authenticate returns nil for an unknown, expired or revoked key. The
+// server.apikey of the config is the bootstrap key with the admin scope, so
+// the first keys can be created, it belongs to the default tenant, and a
+// reload of the config rotates it. The stored keys are found by their hash,
+// the key itself is never compared.
+func (u *apiKeyUseCaseImpl) Authenticate(ctx context.Context, apiKey string) *security.Principal {
+	if apiKey == "" {
+		return nil
+	}
+
+	if bootstrapKey := u.AppConfig.Current().Server.ApiKey; bootstrapKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(bootstrapKey)) == 1 {
+		return &security.Principal{
+			Id:       helper.ApiKeyIdentity(bootstrapKey),
+			Name:     "bootstrap",
+			TenantId: security.DefaultTenantId,
+			Scopes:   []string{security.ScopeAdmin},
+		}
+	}
+
+	tx, err := u.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "api key > usecase > Authenticate")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	storedKey := u.ApiKeyRepository.FindByHash(ctx, tx, hashApiKey(apiKey))
+
+	if storedKey == nil || storedKey.RevokedAt != nil || (storedKey.ExpiresAt != nil && !storedKey.ExpiresAt.After(time.Now())) {
+		return nil
+	}
+
+	u.ApiKeyRepository.TouchLastUsed(ctx, tx, storedKey.Id)
+
+	principal := &security.Principal{
+		Id:       storedKey.Id,
+		Name:     storedKey.Name,
+		TenantId: storedKey.TenantId,
+		Scopes:   storedKey.Scopes,
+	}
+
+	if storedKey.SigningSecret != nil {
+		principal.SigningSecret = *storedKey.SigningSecret
+	}
+
+	return principal
+}
+
+// ClaimNonce returns false when the key has signed a request with the nonce
+// already, the expired nonces are deleted on the way
+func (u *apiKeyUseCaseImpl) ClaimNonce(ctx context.Context, apiKeyId string, nonce string) bool {
+	tx, err := u.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "api key > usecase > ClaimNonce")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	u.NonceRepository.DeleteExpired(ctx, tx)
+
+	return u.NonceRepository.Save(ctx, tx, apiKeyId, nonce)
+}
+
+// Create answers with the key, it is never shown again. The key belongs to the
+// tenant of the caller unless the default tenant creates it for another one.
+func (u *apiKeyUseCaseImpl) Create(ctx context.Context, requestBody *model.CreateApiKeyRequest) *model.ApiKeyResponse {
+	err := u.Validator.Struct(requestBody)
+	helper.PanicIfError(err)
+
+	if requestBody.ExpiresAt != nil && !requestBody.ExpiresAt.After(time.Now()) {
+		panic(exception.NewErrorClientRequest(errors.New("expires_at is in the past"), http.StatusBadRequest, "expires_at must be in the future"))
+	}
+
+	tenantId := requestBody.TenantId
+
+	// The tenant of the caller is required only when no tenant is given, so a
+	// caller without one is answered by the tenant check below
+	if tenantId == "" {
+		tenantId = security.RequireTenant(ctx)
+	}
+
+	if !security.PrincipalFromContext(ctx).ManagesTenant(tenantId) {
+		panic(exception.NewErrorClientRequest(errors.New("forbidden"), http.StatusForbidden, "only the default tenant manages the other tenants"))
+	}
+
+	apiKey := &entity.ApiKey{
+		TenantId:  tenantId,
+		Name:      requestBody.Name,
+		Scopes:    requestBody.Scopes,
+		ExpiresAt: requestBody.ExpiresAt,
+	}
+
+	key := u.generateKey(apiKey)
+
+	var signingSecret string
+
+	if requestBody.Signed {
+		signingSecret = u.generateSigningSecret(apiKey)
+	}
+
+	tx, err := u.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "api key > usecase > Create")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	u.TenantRepository.FindById(ctx, tx, tenantId)
+
+	apiKey = u.ApiKeyRepository.Save(ctx, tx, apiKey)
+
+	apiKeyResponse := converter.ApiKeyToResponse(apiKey)
+	apiKeyResponse.Key = key
+	apiKeyResponse.SigningSecret = signingSecret
+
+	return apiKeyResponse
+}
+
+// Rotate answers with a new key in place of the old one, which stops working
+// at once, a key which requires signed requests gets a new signing secret too
+func (u *apiKeyUseCaseImpl) Rotate(ctx context.Context, apiKeyId string) *model.ApiKeyResponse {
+	tx, err := u.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "api key > usecase > Rotate")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	apiKey := u.ApiKeyRepository.FindById(ctx, tx, apiKeyId)
+
+	requireManagedKey(ctx, apiKey)
+
+	if apiKey.RevokedAt != nil {
+		panic(exception.NewErrorClientRequest(errors.New("api key is revoked"), http.StatusConflict, "api key is revoked"))
+	}
+
+	key := u.generateKey(apiKey)
+
+	var signingSecret string
+
+	if apiKey.SigningSecret != nil {
+		signingSecret = u.generateSigningSecret(apiKey)
+	}
+
+	apiKey = u.ApiKeyRepository.UpdateKey(ctx, tx, apiKey)
+
+	apiKeyResponse := converter.ApiKeyToResponse(apiKey)
+	apiKeyResponse.Key = key
+	apiKeyResponse.SigningSecret = signingSecret
+
+	return apiKeyResponse
+}
+
+func (u *apiKeyUseCaseImpl) Revoke(ctx context.Context, apiKeyId string) {
+	tx, err := u.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "api key > usecase > Revoke")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	requireManagedKey(ctx, u.ApiKeyRepository.FindById(ctx, tx, apiKeyId))
+
+	u.ApiKeyRepository.Revoke(ctx, tx, apiKeyId)
+}
+
+// FindAll lists the keys of the tenant of the caller, or of every tenant for
+// the default tenant
+func (u *apiKeyUseCaseImpl) FindAll(ctx context.Context) []model.ApiKeyResponse {
+	tenantId := security.RequireTenant(ctx)
+
+	if tenantId == security.DefaultTenantId {
+		tenantId = ""
+	}
+
+	tx, err := u.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "api key > usecase > FindAll")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	result := u.ApiKeyRepository.FindAll(ctx, tx, tenantId)
+
+	return converter.ApiKeysToResponse(result)
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/cache"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/metrics"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	categoryCacheName    = "category"
+	categoryCacheKeyAll  = "all"
+	categoryCacheKeyById = "id:"
+)
+
+// CategoryCache keeps the categories the cached usecase reads, the concurrent
+// misses of a key share a single query. The keys are prefixed by the tenant, a
+// tenant never reads the cached rows of another. Between Start and Close the
+// writes of the other instances arrive as category events from the broker and
+// invalidate it
+type CategoryCache struct {
+	broker   stream.Broker
+	cache    cache.Cache
+	group    singleflight.Group
+	cancel   context.CancelFunc
+	doneChan chan bool
+}
+
+// loadPanic carries the panic of a shared load, so every caller panics with
+// the original error instead of the wrapper of singleflight
+type loadPanic struct {
+	value any
+}
+
+func NewCategoryCache(appConfig *config.AppConfig, broker stream.Broker) *CategoryCache {
+	return &CategoryCache{
+		broker: broker,
+		cache:  cache.NewLRUCacheImpl(appConfig.CategoryCache.Size, appConfig.CategoryCache.TTL*time.Second),
+	}
+}
+
+// Start subscribes to the broker before it returns, so no event published
+// afterwards is missed, and follows the events until the context is done or
+// Close is called
+func (c *CategoryCache) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.doneChan = make(chan bool)
+
+	go c.watch(ctx, c.broker.Subscribe("", ""))
+}
+
+// Close unsubscribes from the broker and waits for the events being applied
+func (c *CategoryCache) Close() {
+	if c.cancel == nil {
+		return
+	}
+
+	c.cancel()
+	<-c.doneChan
+}
+
+// watch invalidates the categories of the events until the context is done or
+// the broker closes. A subscription dropped for falling behind may have missed
+// events, so the whole cache is purged before it subscribes again
+func (c *CategoryCache) watch(ctx context.Context, subscription *stream.Subscription) {
+	defer close(c.doneChan)
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.broker.Unsubscribe(subscription)
+			return
+		case event, ok := <-subscription.Events:
+			if ok {
+				c.invalidateEvent(&event)
+				continue
+			}
+
+			if c.broker.IsClosed() {
+				return
+			}
+
+			c.cache.Purge()
+			metrics.CacheInvalidationsTotal.WithLabelValues(categoryCacheName, "reset").Inc()
+
+			subscription = c.broker.Subscribe("", "")
+		}
+	}
+}
+
+func (c *CategoryCache) invalidateEvent(event *model.CategoryEventResponse) {
+	category := new(model.CategoryResponse)
+
+	if err := json.Unmarshal(event.Data, category); err != nil || category.Id == "" {
+		c.cache.Purge()
+		metrics.CacheInvalidationsTotal.WithLabelValues(categoryCacheName, "reset").Inc()
+		return
+	}
+
+	c.invalidate(event.TenantId, category.Id, "notify")
+}
+
+// invalidate forgets the running loads too, they may read the category from
+// before the write and a caller arriving now must not share them
+func (c *CategoryCache) invalidate(tenantId string, categoryId string, source string) {
+	keys := []string{categoryCacheKey(tenantId, categoryCacheKeyAll), categoryCacheKey(tenantId, categoryCacheKeyById+categoryId)}
+
+	c.cache.Invalidate(keys...)
+
+	for _, key := range keys {
+		c.group.Forget(key)
+	}
+
+	metrics.CacheInvalidationsTotal.WithLabelValues(categoryCacheName, source).Inc()
+}
+
+func categoryCacheKey(tenantId string, key string) string {
+	return tenantId + "/" + key
+}
+
+// load answers the key from the cache or runs find once for all the callers
+// of the key. The query runs without the cancellation of the first caller,
+// the other callers wait for it too
+func (c *CategoryCache) load(ctx context.Context, method string, key string, find func(ctx context.Context) any) any {
+	value, hit := c.cache.Get(key)
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("cache.hit", hit))
+
+	if hit {
+		metrics.CacheRequestsTotal.WithLabelValues(categoryCacheName, method, "hit").Inc()
+		return value
+	}
+
+	metrics.CacheRequestsTotal.WithLabelValues(categoryCacheName, method, "miss").Inc()
+
+	value, _, _ = c.group.Do(key, func() (value any, err error) {
+		defer func() {
+			if errRecover := recover(); errRecover != nil {
+				value = &loadPanic{value: errRecover}
+			}
+		}()
+
+		generation := c.cache.Generation()
+
+		value = find(context.WithoutCancel(ctx))
+
+		c.cache.Set(key, value, generation)
+
+		return value, nil
+	})
+
+	if panicValue, ok := value.(*loadPanic); ok {
+		panic(panicValue.value)
+	}
+
+	return value
+}
@@ -8,8 +8,12 @@ import (
 
 type CategoryUseCase interface {
 	Create(ctx context.Context, requestBody *model.CreateCategoryRequest) *model.CategoryResponse
+	CreateIdempotent(ctx context.Context, idempotencyKey string, requestBody *model.CreateCategoryRequest) (*model.CategoryResponse, bool)
 	Update(ctx context.Context, categoryId string, requestBody *model.UpdateCategoryRequest) *model.CategoryResponse
 	Delete(ctx context.Context, categoryId string)
 	FindById(ctx context.Context, categoryId string) *model.CategoryResponse
 	FindAll(ctx context.Context) []model.CategoryResponse
+	FindByIds(ctx context.Context, categoryIds []string) []model.CategoryResponse
+	FindPage(ctx context.Context, requestQuery *model.PageCategoryRequest) *model.CategoryPageResponse
+	FindVersion(ctx context.Context) *model.CategoryVersionResponse
 }
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+// categoryUseCaseCache reads FindById and FindAll through the category cache,
+// the writes of this instance invalidate it once they are committed
+type categoryUseCaseCache struct {
+	UseCase CategoryUseCase
+	Cache   *CategoryCache
+}
+
+func NewCategoryUseCaseCache(useCase CategoryUseCase, categoryCache *CategoryCache) CategoryUseCase {
+	return &categoryUseCaseCache{
+		UseCase: useCase,
+		Cache:   categoryCache,
+	}
+}
+
+func (u *categoryUseCaseCache) Create(ctx context.Context, requestBody *model.CreateCategoryRequest) *model.CategoryResponse {
+	categoryResponse := u.UseCase.Create(ctx, requestBody)
+
+	u.Cache.invalidate(security.RequireTenant(ctx), categoryResponse.Id, "write")
+
+	return categoryResponse
+}
+
+func (u *categoryUseCaseCache) CreateIdempotent(ctx context.Context, idempotencyKey string, requestBody *model.CreateCategoryRequest) (*model.CategoryResponse, bool) {
+	categoryResponse, replayed := u.UseCase.CreateIdempotent(ctx, idempotencyKey, requestBody)
+
+	if !replayed {
+		u.Cache.invalidate(security.RequireTenant(ctx), categoryResponse.Id, "write")
+	}
+
+	return categoryResponse, replayed
+}
+
+func (u *categoryUseCaseCache) Update(ctx context.Context, categoryId string, requestBody *model.UpdateCategoryRequest) *model.CategoryResponse {
+	categoryResponse := u.UseCase.Update(ctx, categoryId, requestBody)
+
+	u.Cache.invalidate(security.RequireTenant(ctx), categoryId, "write")
+
+	return categoryResponse
+}
+
+func (u *categoryUseCaseCache) Delete(ctx context.Context, categoryId string) {
+	u.UseCase.Delete(ctx, categoryId)
+
+	u.Cache.invalidate(security.RequireTenant(ctx), categoryId, "write")
+}
+
+// FindById hands out a copy, the callers must not change the cached category
+func (u *categoryUseCaseCache) FindById(ctx context.Context, categoryId string) *model.CategoryResponse {
+	categoryResponse := *u.Cache.load(ctx, "FindById", categoryCacheKey(security.RequireTenant(ctx), categoryCacheKeyById+categoryId), func(ctx context.Context) any {
+		return u.UseCase.FindById(ctx, categoryId)
+	}).(*model.CategoryResponse)
+
+	return &categoryResponse
+}
+
+func (u *categoryUseCaseCache) FindAll(ctx context.Context) []model.CategoryResponse {
+	return slices.Clone(u.Cache.load(ctx, "FindAll", categoryCacheKey(security.RequireTenant(ctx), categoryCacheKeyAll), func(ctx context.Context) any {
+		return u.UseCase.FindAll(ctx)
+	}).([]model.CategoryResponse))
+}
+
+func (u *categoryUseCaseCache) FindByIds(ctx context.Context, categoryIds []string) []model.CategoryResponse {
+	return u.UseCase.FindByIds(ctx, categoryIds)
+}
+
+func (u *categoryUseCaseCache) FindPage(ctx context.Context, requestQuery *model.PageCategoryRequest) *model.CategoryPageResponse {
+	return u.UseCase.FindPage(ctx, requestQuery)
+}
+
+// FindVersion is never cached, the conditional requests compare it with the
+// validators of the client
+func (u *categoryUseCaseCache) FindVersion(ctx context.Context) *model.CategoryVersionResponse {
+	return u.UseCase.FindVersion(ctx)
+}
@@ -2,27 +2,39 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
 
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model/converter"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type categoryUseCaseImpl struct {
-	DB                 db.PgxPool
-	Validator          security.Validation
-	CategoryRepository repository.CategoryRepository
+	DB                       db.PgxPool
+	Validator                security.Validation
+	CategoryRepository       repository.CategoryRepository
+	IdempotencyKeyRepository repository.IdempotencyKeyRepository
+	OutboxEventRepository    repository.OutboxEventRepository
 }
 
-func NewCategoryUseCaseImpl(db db.PgxPool, validate security.Validation, categoryRepository repository.CategoryRepository) CategoryUseCase {
+func NewCategoryUseCaseImpl(db db.PgxPool, validate security.Validation, categoryRepository repository.CategoryRepository, idempotencyKeyRepository repository.IdempotencyKeyRepository, outboxEventRepository repository.OutboxEventRepository) CategoryUseCase {
 	return &categoryUseCaseImpl{
-		DB:                 db,
-		Validator:          validate,
-		CategoryRepository: categoryRepository,
+		DB:                       db,
+		Validator:                validate,
+		CategoryRepository:       categoryRepository,
+		IdempotencyKeyRepository: idempotencyKeyRepository,
+		OutboxEventRepository:    outboxEventRepository,
 	}
 }
 
@@ -30,7 +42,7 @@ func (u *categoryUseCaseImpl) Create(ctx context.Context, requestBody *model.Cre
 	err := u.Validator.Struct(requestBody)
 	helper.PanicIfError(err)
 
-	tx, err := u.DB.Begin(ctx)
+	tx, err := beginTenantTx(ctx, u.DB)
 	helper.InternalServerPanicIfError(err, "category > usecase > Create")
 
 	defer helper.TxCommitRollback(ctx, tx)
@@ -41,14 +53,77 @@ func (u *categoryUseCaseImpl) Create(ctx context.Context, requestBody *model.Cre
 
 	category = u.CategoryRepository.Save(ctx, tx, category)
 
+	u.saveEvent(ctx, tx, entity.EventCategoryCreated, category)
+
 	return converter.CategoryToResponse(category)
 }
 
+// CreateIdempotent stores the response with the key in the transaction which
+// creates the category, so a retry replays it instead of creating another one.
+// It reports whether the response is a replay.
+func (u *categoryUseCaseImpl) CreateIdempotent(ctx context.Context, idempotencyKey string, requestBody *model.CreateCategoryRequest) (*model.CategoryResponse, bool) {
+	err := u.Validator.Struct(&model.IdempotencyKeyRequest{Key: idempotencyKey})
+	helper.PanicIfError(err)
+
+	err = u.Validator.Struct(requestBody)
+	helper.PanicIfError(err)
+
+	requestBytes, err := json.Marshal(requestBody)
+	helper.InternalServerPanicIfError(err, "category > usecase > CreateIdempotent")
+
+	requestHash := sha256.Sum256(requestBytes)
+
+	// The keys of different API keys never meet
+	if requestInfo := helper.RequestInfoFromContext(ctx); requestInfo != nil && requestInfo.ApiKeyIdentity != "" {
+		idempotencyKey = requestInfo.ApiKeyIdentity + ":" + idempotencyKey
+	}
+
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "category > usecase > CreateIdempotent")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	u.IdempotencyKeyRepository.Lock(ctx, tx, idempotencyKey)
+	u.IdempotencyKeyRepository.DeleteExpired(ctx, tx)
+
+	if storedKey := u.IdempotencyKeyRepository.FindByKey(ctx, tx, idempotencyKey); storedKey != nil {
+		if storedKey.RequestHash != hex.EncodeToString(requestHash[:]) {
+			panic(exception.NewErrorClientRequest(errors.New("idempotency key is reused"), http.StatusUnprocessableEntity, "idempotency key is already used with a different request body"))
+		}
+
+		categoryResponse := new(model.CategoryResponse)
+
+		err = json.Unmarshal(storedKey.ResponseBody, categoryResponse)
+		helper.InternalServerPanicIfError(err, "category > usecase > CreateIdempotent")
+
+		return categoryResponse, true
+	}
+
+	category := u.CategoryRepository.Save(ctx, tx, &entity.Category{
+		Name: requestBody.Name,
+	})
+
+	u.saveEvent(ctx, tx, entity.EventCategoryCreated, category)
+
+	categoryResponse := converter.CategoryToResponse(category)
+
+	responseBytes, err := json.Marshal(categoryResponse)
+	helper.InternalServerPanicIfError(err, "category > usecase > CreateIdempotent")
+
+	u.IdempotencyKeyRepository.Save(ctx, tx, &entity.IdempotencyKey{
+		Key:          idempotencyKey,
+		RequestHash:  hex.EncodeToString(requestHash[:]),
+		ResponseBody: responseBytes,
+	})
+
+	return categoryResponse, false
+}
+
 func (u *categoryUseCaseImpl) Update(ctx context.Context, categoryId string, requestBody *model.UpdateCategoryRequest) *model.CategoryResponse {
 	err := u.Validator.Struct(requestBody)
 	helper.PanicIfError(err)
 
-	tx, err := u.DB.Begin(ctx)
+	tx, err := beginTenantTx(ctx, u.DB)
 	helper.InternalServerPanicIfError(err, "category > usecase > Update")
 
 	defer helper.TxCommitRollback(ctx, tx)
@@ -62,21 +137,25 @@ func (u *categoryUseCaseImpl) Update(ctx context.Context, categoryId string, req
 
 	category = u.CategoryRepository.Update(ctx, tx, category)
 
+	u.saveEvent(ctx, tx, entity.EventCategoryUpdated, category)
+
 	return converter.CategoryToResponse(category)
 }
 
 func (u *categoryUseCaseImpl) Delete(ctx context.Context, categoryId string) {
-	tx, err := u.DB.Begin(ctx)
+	tx, err := beginTenantTx(ctx, u.DB)
 	helper.InternalServerPanicIfError(err, "category > usecase > Delete")
 
 	defer helper.TxCommitRollback(ctx, tx)
 
-	u.CategoryRepository.FindById(ctx, tx, categoryId)
+	category := u.CategoryRepository.FindById(ctx, tx, categoryId)
 	u.CategoryRepository.Delete(ctx, tx, categoryId)
+
+	u.saveEvent(ctx, tx, entity.EventCategoryDeleted, category)
 }
 
 func (u *categoryUseCaseImpl) FindById(ctx context.Context, categoryId string) *model.CategoryResponse {
-	tx, err := u.DB.Begin(ctx)
+	tx, err := beginTenantTx(ctx, u.DB)
 	helper.InternalServerPanicIfError(err, "category > usecase > FindById")
 
 	defer helper.TxCommitRollback(ctx, tx)
@@ -87,7 +166,7 @@ func (u *categoryUseCaseImpl) FindById(ctx context.Context, categoryId string) *
 }
 
 func (u *categoryUseCaseImpl) FindAll(ctx context.Context) []model.CategoryResponse {
-	tx, err := u.DB.Begin(ctx)
+	tx, err := beginTenantTx(ctx, u.DB)
 	helper.InternalServerPanicIfError(err, "category > usecase > FindAll")
 
 	defer helper.TxCommitRollback(ctx, tx)
@@ -96,3 +175,64 @@ func (u *categoryUseCaseImpl) FindAll(ctx context.Context) []model.CategoryRespo
 
 	return converter.CategoriesToResponse(result)
 }
+
+func (u *categoryUseCaseImpl) FindByIds(ctx context.Context, categoryIds []string) []model.CategoryResponse {
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "category > usecase > FindByIds")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	result := u.CategoryRepository.FindByIds(ctx, tx, categoryIds)
+
+	return converter.CategoriesToResponse(result)
+}
+
+func (u *categoryUseCaseImpl) FindPage(ctx context.Context, requestQuery *model.PageCategoryRequest) *model.CategoryPageResponse {
+	err := u.Validator.Struct(requestQuery)
+	helper.PanicIfError(err)
+
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "category > usecase > FindPage")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	// One extra row is fetched to find out whether another page exists
+	result := u.CategoryRepository.FindPage(ctx, tx, requestQuery.AfterId, requestQuery.Limit+1)
+	totalCount := u.CategoryRepository.Count(ctx, tx)
+
+	hasNextPage := len(result) > requestQuery.Limit
+
+	if hasNextPage {
+		result = result[:requestQuery.Limit]
+	}
+
+	return &model.CategoryPageResponse{
+		Categories:  converter.CategoriesToResponse(result),
+		HasNextPage: hasNextPage,
+		TotalCount:  totalCount,
+	}
+}
+
+func (u *categoryUseCaseImpl) FindVersion(ctx context.Context) *model.CategoryVersionResponse {
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "category > usecase > FindVersion")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	result := u.CategoryRepository.FindVersion(ctx, tx)
+
+	return converter.TableVersionToCategoryVersionResponse(result)
+}
+
+// saveEvent writes the change to the outbox in the transaction of the change,
+// so the webhooks are sent for every committed change and only for those
+func (u *categoryUseCaseImpl) saveEvent(ctx context.Context, tx pgx.Tx, eventType string, category *entity.Category) {
+	payload, err := json.Marshal(converter.CategoryToResponse(category))
+	helper.InternalServerPanicIfError(err, "category > usecase > saveEvent")
+
+	u.OutboxEventRepository.Save(ctx, tx, &entity.OutboxEvent{
+		EventType:   eventType,
+		AggregateId: category.Id,
+		Payload:     payload,
+	})
+}
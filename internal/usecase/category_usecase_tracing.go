@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+
+// categoryUseCaseTracing wraps every method of a CategoryUseCase in a span,
+// the pgx spans of the method become its children
+type categoryUseCaseTracing struct {
+	UseCase CategoryUseCase
+}
+
+func NewCategoryUseCaseTracing(useCase CategoryUseCase) CategoryUseCase {
+	return &categoryUseCaseTracing{
+		UseCase: useCase,
+	}
+}
+
+// NewCategoryUseCase is the traced category usecase the injectors provide, it
+// reads through the category cache unless categorycache.enabled is off. The
+// cache is inside the span, so the hits are traced as well
+func NewCategoryUseCase(appConfig *config.AppConfig, db db.PgxPool, validate security.Validation, categoryRepository repository.CategoryRepository, idempotencyKeyRepository repository.IdempotencyKeyRepository, outboxEventRepository repository.OutboxEventRepository, categoryCache *CategoryCache) CategoryUseCase {
+	useCase := NewCategoryUseCaseImpl(db, validate, categoryRepository, idempotencyKeyRepository, outboxEventRepository)
+
+	if appConfig.CategoryCache.Enabled {
+		useCase = NewCategoryUseCaseCache(useCase, categoryCache)
+	}
+
+	return NewCategoryUseCaseTracing(useCase)
+}
+
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name)
+}
+
+// endSpan must be deferred directly, it records the panic of the method and
+// passes it on to the panic middleware
+func endSpan(span trace.Span) {
+	errRecover := recover()
+
+	if errRecover != nil {
+		if err, ok := errRecover.(error); ok {
+			span.RecordError(err)
+		}
+
+		// The client errors are answered as designed, they do not fail the span
+		switch errRecover.(type) {
+		case *exception.ErrorClientRequest, *exception.ErrorValidationRequest, validator.ValidationErrors:
+		default:
+			span.SetStatus(codes.Error, fmt.Sprint(errRecover))
+		}
+	}
+
+	span.End()
+
+	if errRecover != nil {
+		panic(errRecover)
+	}
+}
+
+func (u *categoryUseCaseTracing) Create(ctx context.Context, requestBody *model.CreateCategoryRequest) *model.CategoryResponse {
+	ctx, span := startSpan(ctx, "CategoryUseCase.Create")
+	defer endSpan(span)
+
+	return u.UseCase.Create(ctx, requestBody)
+}
+
+func (u *categoryUseCaseTracing) CreateIdempotent(ctx context.Context, idempotencyKey string, requestBody *model.CreateCategoryRequest) (*model.CategoryResponse, bool) {
+	ctx, span := startSpan(ctx, "CategoryUseCase.CreateIdempotent")
+	defer endSpan(span)
+
+	return u.UseCase.CreateIdempotent(ctx, idempotencyKey, requestBody)
+}
+
+func (u *categoryUseCaseTracing) Update(ctx context.Context, categoryId string, requestBody *model.UpdateCategoryRequest) *model.CategoryResponse {
+	ctx, span := startSpan(ctx, "CategoryUseCase.Update")
+	defer endSpan(span)
+
+	return u.UseCase.Update(ctx, categoryId, requestBody)
+}
+
+func (u *categoryUseCaseTracing) Delete(ctx context.Context, categoryId string) {
+	ctx, span := startSpan(ctx, "CategoryUseCase.Delete")
+	defer endSpan(span)
+
+	u.UseCase.Delete(ctx, categoryId)
+}
+
+func (u *categoryUseCaseTracing) FindById(ctx context.Context, categoryId string) *model.CategoryResponse {
+	ctx, span := startSpan(ctx, "CategoryUseCase.FindById")
+	defer endSpan(span)
+
+	return u.UseCase.FindById(ctx, categoryId)
+}
+
+func (u *categoryUseCaseTracing) FindAll(ctx context.Context) []model.CategoryResponse {
+	ctx, span := startSpan(ctx, "CategoryUseCase.FindAll")
+	defer endSpan(span)
+
+	return u.UseCase.FindAll(ctx)
+}
+
+func (u *categoryUseCaseTracing) FindByIds(ctx context.Context, categoryIds []string) []model.CategoryResponse {
+	ctx, span := startSpan(ctx, "CategoryUseCase.FindByIds")
+	defer endSpan(span)
+
+	return u.UseCase.FindByIds(ctx, categoryIds)
+}
+
+func (u *categoryUseCaseTracing) FindPage(ctx context.Context, requestQuery *model.PageCategoryRequest) *model.CategoryPageResponse {
+	ctx, span := startSpan(ctx, "CategoryUseCase.FindPage")
+	defer endSpan(span)
+
+	return u.UseCase.FindPage(ctx, requestQuery)
+}
+
+func (u *categoryUseCaseTracing) FindVersion(ctx context.Context) *model.CategoryVersionResponse {
+	ctx, span := startSpan(ctx, "CategoryUseCase.FindVersion")
+	defer endSpan(span)
+
+	return u.UseCase.FindVersion(ctx)
+}
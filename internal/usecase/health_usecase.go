@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+type HealthUseCase interface {
+	Ready(ctx context.Context) *model.HealthResponse
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db_migration "github.com/syahdaromansyah/pzn-golang-restful-api/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	healthCheckPass = "pass"
+	healthCheckFail = "fail"
+
+	// healthCheckUnavailable is the message of a failing check, the probes are
+	// public so the cause is only logged
+	healthCheckUnavailable = "unavailable"
+
+	readinessTimeout = 2 * time.Second
+)
+
+type healthUseCaseImpl struct {
+	DB                        db.PgxPool
+	Logger                    *logrus.Logger
+	Lifecycle                 *helper.Lifecycle
+	SchemaMigrationRepository repository.SchemaMigrationRepository
+	MigrationVersion          int64
+}
+
+func NewHealthUseCaseImpl(db db.PgxPool, logger *logrus.Logger, lifecycle *helper.Lifecycle, schemaMigrationRepository repository.SchemaMigrationRepository) HealthUseCase {
+	return &healthUseCaseImpl{
+		DB:                        db,
+		Logger:                    logger,
+		Lifecycle:                 lifecycle,
+		SchemaMigrationRepository: schemaMigrationRepository,
+		MigrationVersion:          db_migration.MigrationVersion(),
+	}
+}
+
+func (u *healthUseCaseImpl) Ready(ctx context.Context) *model.HealthResponse {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	healthResponse := &model.HealthResponse{
+		Status: healthCheckPass,
+		Checks: []model.HealthCheckResponse{
+			u.check("shutdown", u.checkShutdown),
+			u.check("database", func() error { return u.DB.Ping(ctx) }),
+			u.check("migration", func() error { return u.checkMigration(ctx) }),
+		},
+	}
+
+	for _, check := range healthResponse.Checks {
+		if check.Status == healthCheckFail {
+			healthResponse.Status = healthCheckFail
+		}
+	}
+
+	return healthResponse
+}
+
+// check turns both the errors and the panics of the repositories into a
+// failing check, readiness reports them instead of answering 500
+func (u *healthUseCaseImpl) check(name string, fn func() error) (checkResponse model.HealthCheckResponse) {
+	checkResponse = model.HealthCheckResponse{Name: name, Status: healthCheckPass}
+
+	fail := func(cause string) {
+		u.Logger.WithField("error", cause).WithField("check", name).Warn("a readiness check failed")
+
+		checkResponse.Status = healthCheckFail
+		checkResponse.Message = healthCheckUnavailable
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			fail(fmt.Sprint(err))
+		}
+	}()
+
+	if err := fn(); err != nil {
+		fail(err.Error())
+	}
+
+	return checkResponse
+}
+
+func (u *healthUseCaseImpl) checkShutdown() error {
+	if u.Lifecycle.IsDraining() {
+		return errors.New("the server is shutting down")
+	}
+
+	return nil
+}
+
+func (u *healthUseCaseImpl) checkMigration(ctx context.Context) error {
+	tx, err := u.DB.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	schemaMigration := u.SchemaMigrationRepository.FindVersion(ctx, tx)
+
+	switch {
+	case schemaMigration == nil:
+		return fmt.Errorf("the database is not migrated, expected version %d", u.MigrationVersion)
+	case schemaMigration.Dirty:
+		return fmt.Errorf("migration version %d is dirty", schemaMigration.Version)
+	case schemaMigration.Version != u.MigrationVersion:
+		return fmt.Errorf("migration version is %d, expected %d", schemaMigration.Version, u.MigrationVersion)
+	}
+
+	return nil
+}
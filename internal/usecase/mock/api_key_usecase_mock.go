@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+type apiKeyUseCaseMock struct {
+	Mock *mock.Mock
+}
+
+func NewApiKeyUseCaseMock() *apiKeyUseCaseMock {
+	return &apiKeyUseCaseMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (u *apiKeyUseCaseMock) Authenticate(ctx context.Context, apiKey string) *security.Principal {
+	args := u.Mock.Called(ctx, apiKey)
+	principal, _ := args.Get(0).(*security.Principal)
+	return principal
+}
+
+func (u *apiKeyUseCaseMock) ClaimNonce(ctx context.Context, apiKeyId string, nonce string) bool {
+	args := u.Mock.Called(ctx, apiKeyId, nonce)
+	return args.Bool(0)
+}
+
+func (u *apiKeyUseCaseMock) Create(ctx context.Context, requestBody *model.CreateApiKeyRequest) *model.ApiKeyResponse {
+	args := u.Mock.Called(ctx, requestBody)
+	return args.Get(0).(*model.ApiKeyResponse)
+}
+
+func (u *apiKeyUseCaseMock) Rotate(ctx context.Context, apiKeyId string) *model.ApiKeyResponse {
+	args := u.Mock.Called(ctx, apiKeyId)
+	return args.Get(0).(*model.ApiKeyResponse)
+}
+
+func (u *apiKeyUseCaseMock) Revoke(ctx context.Context, apiKeyId string) {
+	u.Mock.Called(ctx, apiKeyId)
+}
+
+func (u *apiKeyUseCaseMock) FindAll(ctx context.Context) []model.ApiKeyResponse {
+	args := u.Mock.Called(ctx)
+	return args.Get(0).([]model.ApiKeyResponse)
+}
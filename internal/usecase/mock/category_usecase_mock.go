@@ -22,6 +22,11 @@ func (u *categoryUseCaseMock) Create(ctx context.Context, requestBody *model.Cre
 	return args.Get(0).(*model.CategoryResponse)
 }
 
+func (u *categoryUseCaseMock) CreateIdempotent(ctx context.Context, idempotencyKey string, requestBody *model.CreateCategoryRequest) (*model.CategoryResponse, bool) {
+	args := u.Mock.Called(ctx, idempotencyKey, requestBody)
+	return args.Get(0).(*model.CategoryResponse), args.Bool(1)
+}
+
 func (u *categoryUseCaseMock) Update(ctx context.Context, categoryId string, requestBody *model.UpdateCategoryRequest) *model.CategoryResponse {
 	args := u.Mock.Called(ctx, categoryId, requestBody)
 	return args.Get(0).(*model.CategoryResponse)
@@ -40,3 +45,18 @@ func (u *categoryUseCaseMock) FindAll(ctx context.Context) []model.CategoryRespo
 	args := u.Mock.Called(ctx)
 	return args.Get(0).([]model.CategoryResponse)
 }
+
+func (u *categoryUseCaseMock) FindByIds(ctx context.Context, categoryIds []string) []model.CategoryResponse {
+	args := u.Mock.Called(ctx, categoryIds)
+	return args.Get(0).([]model.CategoryResponse)
+}
+
+func (u *categoryUseCaseMock) FindPage(ctx context.Context, requestQuery *model.PageCategoryRequest) *model.CategoryPageResponse {
+	args := u.Mock.Called(ctx, requestQuery)
+	return args.Get(0).(*model.CategoryPageResponse)
+}
+
+func (u *categoryUseCaseMock) FindVersion(ctx context.Context) *model.CategoryVersionResponse {
+	args := u.Mock.Called(ctx)
+	return args.Get(0).(*model.CategoryVersionResponse)
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+type healthUseCaseMock struct {
+	Mock *mock.Mock
+}
+
+func NewHealthUseCaseMock() *healthUseCaseMock {
+	return &healthUseCaseMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (u *healthUseCaseMock) Ready(ctx context.Context) *model.HealthResponse {
+	args := u.Mock.Called(ctx)
+	return args.Get(0).(*model.HealthResponse)
+}
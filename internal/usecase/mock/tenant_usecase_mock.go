@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+type tenantUseCaseMock struct {
+	Mock *mock.Mock
+}
+
+func NewTenantUseCaseMock() *tenantUseCaseMock {
+	return &tenantUseCaseMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (u *tenantUseCaseMock) Create(ctx context.Context, requestBody *model.CreateTenantRequest) *model.TenantResponse {
+	args := u.Mock.Called(ctx, requestBody)
+	return args.Get(0).(*model.TenantResponse)
+}
+
+func (u *tenantUseCaseMock) FindById(ctx context.Context, tenantId string) *model.TenantResponse {
+	args := u.Mock.Called(ctx, tenantId)
+	return args.Get(0).(*model.TenantResponse)
+}
+
+func (u *tenantUseCaseMock) FindAll(ctx context.Context) []model.TenantResponse {
+	args := u.Mock.Called(ctx)
+	return args.Get(0).([]model.TenantResponse)
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+type webhookUseCaseMock struct {
+	Mock *mock.Mock
+}
+
+func NewWebhookUseCaseMock() *webhookUseCaseMock {
+	return &webhookUseCaseMock{
+		Mock: new(mock.Mock),
+	}
+}
+
+func (u *webhookUseCaseMock) Create(ctx context.Context, requestBody *model.CreateWebhookRequest) *model.WebhookResponse {
+	args := u.Mock.Called(ctx, requestBody)
+	return args.Get(0).(*model.WebhookResponse)
+}
+
+func (u *webhookUseCaseMock) Delete(ctx context.Context, webhookId string) {
+	u.Mock.Called(ctx, webhookId)
+}
+
+func (u *webhookUseCaseMock) FindById(ctx context.Context, webhookId string) *model.WebhookResponse {
+	args := u.Mock.Called(ctx, webhookId)
+	return args.Get(0).(*model.WebhookResponse)
+}
+
+func (u *webhookUseCaseMock) FindAll(ctx context.Context) []model.WebhookResponse {
+	args := u.Mock.Called(ctx)
+	return args.Get(0).([]model.WebhookResponse)
+}
+
+func (u *webhookUseCaseMock) FindDeliveries(ctx context.Context, webhookId string) []model.WebhookDeliveryResponse {
+	args := u.Mock.Called(ctx, webhookId)
+	return args.Get(0).([]model.WebhookDeliveryResponse)
+}
+
+func (u *webhookUseCaseMock) Redeliver(ctx context.Context, webhookId string, deliveryId int64) *model.WebhookDeliveryResponse {
+	args := u.Mock.Called(ctx, webhookId, deliveryId)
+	return args.Get(0).(*model.WebhookDeliveryResponse)
+}
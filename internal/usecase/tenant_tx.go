@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+// beginTenantTx begins a transaction of the tenant of the caller, the row
+// level security of Postgres hides the rows of the other tenants from it
+func beginTenantTx(ctx context.Context, database db.PgxPool) (pgx.Tx, error) {
+	tenantId := security.RequireTenant(ctx)
+
+	tx, err := database.Begin(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := helper.TxSetLocalTenant(ctx, tx, tenantId); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+type TenantUseCase interface {
+	Create(ctx context.Context, requestBody *model.CreateTenantRequest) *model.TenantResponse
+	FindById(ctx context.Context, tenantId string) *model.TenantResponse
+	FindAll(ctx context.Context) []model.TenantResponse
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model/converter"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+type tenantUseCaseImpl struct {
+	DB               db.PgxPool
+	Validator        security.Validation
+	TenantRepository repository.TenantRepository
+}
+
+func NewTenantUseCaseImpl(db db.PgxPool, validate security.Validation, tenantRepository repository.TenantRepository) TenantUseCase {
+	return &tenantUseCaseImpl{
+		DB:               db,
+		Validator:        validate,
+		TenantRepository: tenantRepository,
+	}
+}
+
+// Create provisions a tenant, its first admin key is created for it with the
+// tenant_id of the API key endpoint
+func (u *tenantUseCaseImpl) Create(ctx context.Context, requestBody *model.CreateTenantRequest) *model.TenantResponse {
+	security.RequireDefaultTenant(ctx)
+
+	err := u.Validator.Struct(requestBody)
+	helper.PanicIfError(err)
+
+	tx, err := u.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "tenant > usecase > Create")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	tenant := u.TenantRepository.Save(ctx, tx, &entity.Tenant{
+		Name: requestBody.Name,
+	})
+
+	return converter.TenantToResponse(tenant)
+}
+
+func (u *tenantUseCaseImpl) FindById(ctx context.Context, tenantId string) *model.TenantResponse {
+	security.RequireDefaultTenant(ctx)
+
+	tx, err := u.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "tenant > usecase > FindById")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	result := u.TenantRepository.FindById(ctx, tx, tenantId)
+
+	return converter.TenantToResponse(result)
+}
+
+func (u *tenantUseCaseImpl) FindAll(ctx context.Context) []model.TenantResponse {
+	security.RequireDefaultTenant(ctx)
+
+	tx, err := u.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "tenant > usecase > FindAll")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	result := u.TenantRepository.FindAll(ctx, tx)
+
+	return converter.TenantsToResponse(result)
+}
@@ -0,0 +1,626 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	internal_repository_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	internal_security_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/security/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+)
+
+var appApiKeyTestConfig = &config.AppConfig{
+	Server: &config.Server{
+		ApiKey: "bootstrap_key",
+	},
+}
+
+func hashTestApiKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func TestApiKeyAuthenticateBootstrap(t *testing.T) {
+	// Arrange
+	apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+	// Action
+	// ---SUT (Subject Under Test)
+	principal := usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, nil, nil, nil, apiKeyRepository, nil, nil).Authenticate(t.Context(), "bootstrap_key")
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, &security.Principal{
+		Id:       helper.ApiKeyIdentity("bootstrap_key"),
+		Name:     "bootstrap",
+		TenantId: security.DefaultTenantId,
+		Scopes:   []string{security.ScopeAdmin},
+	}, principal)
+
+	apiKeyRepository.Mock.AssertNotCalled(t, "FindByHash", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApiKeyAuthenticateFailed(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		storedKey *entity.ApiKey
+	}{
+		{name: "Unknown Key", storedKey: nil},
+		{name: "Revoked Key", storedKey: &entity.ApiKey{Id: "KEY-1", Scopes: []string{security.ScopeCategoriesRead}, RevokedAt: &past}},
+		{name: "Expired Key", storedKey: &entity.ApiKey{Id: "KEY-1", Scopes: []string{security.ScopeCategoriesRead}, ExpiresAt: &past}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			pool, err := pgxmock.NewPool()
+			helper.PanicIfError(err)
+
+			defer pool.Close()
+
+			pool.ExpectBegin()
+			pool.ExpectCommit()
+
+			apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+			apiKeyRepository.Mock.On("FindByHash", mock.Anything, mock.Anything, hashTestApiKey("pzn_unknown")).Return(test.storedKey).Times(1)
+
+			// Action
+			// ---SUT (Subject Under Test)
+			principal := usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, nil, apiKeyRepository, nil, nil).Authenticate(t.Context(), "pzn_unknown")
+			// ---------------------------
+
+			// Assert
+			assert.Nil(t, principal)
+
+			apiKeyRepository.Mock.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Empty Key", func(t *testing.T) {
+		// Arrange
+		apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		principal := usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, nil, nil, nil, apiKeyRepository, nil, nil).Authenticate(t.Context(), "")
+		// ---------------------------
+
+		// Assert
+		assert.Nil(t, principal)
+	})
+}
+
+func TestApiKeyAuthenticateSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	future := time.Now().Add(time.Hour)
+
+	apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+	apiKeyRepository.Mock.On("FindByHash", mock.Anything, mock.Anything, hashTestApiKey("pzn_reader")).Return(&entity.ApiKey{
+		Id:        "KEY-1",
+		TenantId:  "TENANT-1",
+		Name:      "reader",
+		Scopes:    []string{security.ScopeCategoriesRead},
+		ExpiresAt: &future,
+	}).Times(1)
+	apiKeyRepository.Mock.On("TouchLastUsed", mock.Anything, mock.Anything, "KEY-1").Times(1)
+
+	// Action
+	// ---SUT (Subject Under Test)
+	principal := usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, nil, apiKeyRepository, nil, nil).Authenticate(t.Context(), "pzn_reader")
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, &security.Principal{
+		Id:       "KEY-1",
+		Name:     "reader",
+		TenantId: "TENANT-1",
+		Scopes:   []string{security.ScopeCategoriesRead},
+	}, principal)
+
+	apiKeyRepository.Mock.AssertExpectations(t)
+}
+
+func TestApiKeyCreateFailed(t *testing.T) {
+	t.Run("Unknown Scope", func(t *testing.T) {
+		// Arrange
+		apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+		// Action & Assert
+		assert.Panics(t, func() {
+			// ---SUT (Subject Under Test)
+			usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, nil, security.NewValidationImpl(), nil, apiKeyRepository, nil, nil).Create(t.Context(), &model.CreateApiKeyRequest{
+				Name:   "reader",
+				Scopes: []string{"categories:delete"},
+			})
+			// ---------------------------
+		})
+
+		apiKeyRepository.Mock.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("Expiry In The Past", func(t *testing.T) {
+		// Arrange
+		apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+		past := time.Now().Add(-time.Hour)
+
+		// Action & Assert
+		defer func() {
+			errRecover := recover()
+
+			if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+				assert.Equal(t, http.StatusBadRequest, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+			}
+
+			apiKeyRepository.Mock.AssertNumberOfCalls(t, "Save", 0)
+		}()
+
+		// ---SUT (Subject Under Test)
+		usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, nil, security.NewValidationImpl(), nil, apiKeyRepository, nil, nil).Create(t.Context(), &model.CreateApiKeyRequest{
+			Name:      "reader",
+			Scopes:    []string{security.ScopeCategoriesRead},
+			ExpiresAt: &past,
+		})
+		// ---------------------------
+	})
+}
+
+func TestApiKeyCreateSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	idGenerator := internal_security_mock.NewIdGenMock()
+
+	idGenerator.Mock.On("Generate", 40).Return("abcdefghijklmnopqrstuvwxyz01234567890123", nil).Times(1)
+
+	key := "pzn_abcdefghijklmnopqrstuvwxyz01234567890123"
+	createdAt := time.Date(2026, time.October, 19, 15, 0, 0, 0, time.UTC)
+
+	apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+	tenantRepository := internal_repository_mock.NewTenantRepositoryMock()
+
+	tenantRepository.Mock.On("FindById", mock.Anything, mock.Anything, "TENANT-1").Return(&entity.Tenant{Id: "TENANT-1"}).Times(1)
+
+	apiKeyRepository.Mock.On("Save", mock.Anything, mock.Anything, &entity.ApiKey{
+		TenantId:  "TENANT-1",
+		Name:      "reader",
+		KeyPrefix: "pzn_abcdefgh",
+		KeyHash:   hashTestApiKey(key),
+		Scopes:    []string{security.ScopeCategoriesRead},
+	}).Return(&entity.ApiKey{
+		Id:        "KEY-1",
+		TenantId:  "TENANT-1",
+		Name:      "reader",
+		KeyPrefix: "pzn_abcdefgh",
+		KeyHash:   hashTestApiKey(key),
+		Scopes:    []string{security.ScopeCategoriesRead},
+		CreatedAt: createdAt,
+	}).Times(1)
+
+	var result *model.ApiKeyResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, security.NewValidationImpl(), idGenerator, apiKeyRepository, tenantRepository, nil).Create(tenantContext(t), &model.CreateApiKeyRequest{
+			Name:   "reader",
+			Scopes: []string{security.ScopeCategoriesRead},
+		})
+		// ---------------------------
+	})
+
+	assert.Equal(t, &model.ApiKeyResponse{
+		Id:        "KEY-1",
+		TenantId:  "TENANT-1",
+		Name:      "reader",
+		Prefix:    "pzn_abcdefgh",
+		Scopes:    []string{security.ScopeCategoriesRead},
+		Key:       key,
+		CreatedAt: createdAt,
+	}, result)
+
+	idGenerator.Mock.AssertExpectations(t)
+	apiKeyRepository.Mock.AssertExpectations(t)
+	tenantRepository.Mock.AssertExpectations(t)
+}
+
+func TestApiKeyRotateFailed(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectRollback()
+
+	revokedAt := time.Now()
+
+	apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+	apiKeyRepository.Mock.On("FindById", mock.Anything, mock.Anything, "KEY-1").Return(&entity.ApiKey{Id: "KEY-1", TenantId: "TENANT-1", RevokedAt: &revokedAt}).Times(1)
+
+	// Action & Assert
+	defer func() {
+		errRecover := recover()
+
+		if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+			assert.Equal(t, http.StatusConflict, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+		}
+
+		apiKeyRepository.Mock.AssertNumberOfCalls(t, "UpdateKey", 0)
+	}()
+
+	// ---SUT (Subject Under Test)
+	usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, nil, apiKeyRepository, nil, nil).Rotate(tenantContext(t), "KEY-1")
+	// ---------------------------
+}
+
+func TestApiKeyRotateSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	idGenerator := internal_security_mock.NewIdGenMock()
+
+	idGenerator.Mock.On("Generate", 40).Return("zyxwvutsrqponmlkjihgfedcba01234567890123", nil).Times(1)
+
+	key := "pzn_zyxwvutsrqponmlkjihgfedcba01234567890123"
+	rotatedAt := time.Date(2026, time.October, 19, 15, 0, 0, 0, time.UTC)
+
+	apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+	apiKeyRepository.Mock.On("FindById", mock.Anything, mock.Anything, "KEY-1").Return(&entity.ApiKey{
+		Id:        "KEY-1",
+		TenantId:  "TENANT-1",
+		Name:      "reader",
+		KeyPrefix: "pzn_abcdefgh",
+		KeyHash:   "old-hash",
+		Scopes:    []string{security.ScopeCategoriesRead},
+	}).Times(1)
+	apiKeyRepository.Mock.On("UpdateKey", mock.Anything, mock.Anything, &entity.ApiKey{
+		Id:        "KEY-1",
+		TenantId:  "TENANT-1",
+		Name:      "reader",
+		KeyPrefix: "pzn_zyxwvuts",
+		KeyHash:   hashTestApiKey(key),
+		Scopes:    []string{security.ScopeCategoriesRead},
+	}).Return(&entity.ApiKey{
+		Id:        "KEY-1",
+		TenantId:  "TENANT-1",
+		Name:      "reader",
+		KeyPrefix: "pzn_zyxwvuts",
+		KeyHash:   hashTestApiKey(key),
+		Scopes:    []string{security.ScopeCategoriesRead},
+		RotatedAt: &rotatedAt,
+	}).Times(1)
+
+	var result *model.ApiKeyResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, idGenerator, apiKeyRepository, nil, nil).Rotate(tenantContext(t), "KEY-1")
+		// ---------------------------
+	})
+
+	assert.Equal(t, "pzn_zyxwvuts", result.Prefix)
+	assert.Equal(t, key, result.Key)
+	assert.Equal(t, &rotatedAt, result.RotatedAt)
+
+	apiKeyRepository.Mock.AssertExpectations(t)
+}
+
+func TestApiKeyRevokeSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+	apiKeyRepository.Mock.On("FindById", mock.Anything, mock.Anything, "KEY-1").Return(&entity.ApiKey{Id: "KEY-1", TenantId: "TENANT-1"}).Times(1)
+	apiKeyRepository.Mock.On("Revoke", mock.Anything, mock.Anything, "KEY-1").Times(1)
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, nil, apiKeyRepository, nil, nil).Revoke(tenantContext(t), "KEY-1")
+		// ---------------------------
+	})
+
+	apiKeyRepository.Mock.AssertExpectations(t)
+}
+
+func TestApiKeyOfAnotherTenant(t *testing.T) {
+	t.Run("Create For Another Tenant", func(t *testing.T) {
+		// Arrange
+		apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+		// Action & Assert
+		assert.PanicsWithError(t, "forbidden", func() {
+			// ---SUT (Subject Under Test)
+			usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, nil, security.NewValidationImpl(), nil, apiKeyRepository, nil, nil).Create(tenantContext(t), &model.CreateApiKeyRequest{
+				Name:     "reader",
+				Scopes:   []string{security.ScopeCategoriesRead},
+				TenantId: "TENANT-2",
+			})
+			// ---------------------------
+		})
+
+		apiKeyRepository.Mock.AssertNumberOfCalls(t, "Save", 0)
+	})
+
+	t.Run("Create For A Tenant Without One", func(t *testing.T) {
+		// Arrange
+		apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+		tenantlessCtx := security.ContextWithPrincipal(t.Context(), &security.Principal{Id: "admin"})
+
+		// Action & Assert
+		defer func() {
+			errRecover := recover()
+
+			if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+				assert.Equal(t, http.StatusForbidden, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+				assert.Equal(t, "only the default tenant manages the other tenants", errRecover.(*exception.ErrorClientRequest).GetDetailError())
+			}
+
+			apiKeyRepository.Mock.AssertNumberOfCalls(t, "Save", 0)
+		}()
+
+		// ---SUT (Subject Under Test)
+		usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, nil, security.NewValidationImpl(), nil, apiKeyRepository, nil, nil).Create(tenantlessCtx, &model.CreateApiKeyRequest{
+			Name:     "reader",
+			Scopes:   []string{security.ScopeCategoriesRead},
+			TenantId: "TENANT-2",
+		})
+		// ---------------------------
+	})
+
+	t.Run("Revoke A Key Of Another Tenant", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		pool.ExpectBegin()
+		pool.ExpectRollback()
+
+		apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+		apiKeyRepository.Mock.On("FindById", mock.Anything, mock.Anything, "KEY-2").Return(&entity.ApiKey{Id: "KEY-2", TenantId: "TENANT-2"}).Times(1)
+
+		// Action & Assert
+		defer func() {
+			errRecover := recover()
+
+			if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+				assert.Equal(t, http.StatusNotFound, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+			}
+
+			apiKeyRepository.Mock.AssertNumberOfCalls(t, "Revoke", 0)
+		}()
+
+		// ---SUT (Subject Under Test)
+		usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, nil, apiKeyRepository, nil, nil).Revoke(tenantContext(t), "KEY-2")
+		// ---------------------------
+	})
+}
+
+func TestApiKeyFindAllOfEveryTenant(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+	apiKeyRepository.Mock.On("FindAll", mock.Anything, mock.Anything, "").Return([]entity.ApiKey{{Id: "KEY-1", TenantId: "TENANT-1"}}).Times(1)
+
+	var result []model.ApiKeyResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, nil, apiKeyRepository, nil, nil).FindAll(defaultTenantContext(t))
+		// ---------------------------
+	})
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "TENANT-1", result[0].TenantId)
+	}
+
+	apiKeyRepository.Mock.AssertExpectations(t)
+}
+
+func TestApiKeySigned(t *testing.T) {
+	t.Run("Create Answers The Signing Secret Once", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		pool.ExpectBegin()
+		pool.ExpectCommit()
+
+		idGenerator := internal_security_mock.NewIdGenMock()
+
+		idGenerator.Mock.On("Generate", 40).Return("abcdefghijklmnopqrstuvwxyz01234567890123", nil).Times(2)
+
+		signingSecret := "sig_abcdefghijklmnopqrstuvwxyz01234567890123"
+
+		apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+		tenantRepository := internal_repository_mock.NewTenantRepositoryMock()
+
+		tenantRepository.Mock.On("FindById", mock.Anything, mock.Anything, "TENANT-1").Return(&entity.Tenant{Id: "TENANT-1"}).Times(1)
+
+		apiKeyRepository.Mock.On("Save", mock.Anything, mock.Anything, mock.MatchedBy(func(apiKey *entity.ApiKey) bool {
+			return apiKey.SigningSecret != nil && *apiKey.SigningSecret == signingSecret
+		})).Return(&entity.ApiKey{Id: "KEY-1", TenantId: "TENANT-1", Name: "partner", SigningSecret: &signingSecret}).Times(1)
+
+		var result *model.ApiKeyResponse
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			result = usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, security.NewValidationImpl(), idGenerator, apiKeyRepository, tenantRepository, nil).Create(tenantContext(t), &model.CreateApiKeyRequest{
+				Name:   "partner",
+				Scopes: []string{security.ScopeCategoriesWrite},
+				Signed: true,
+			})
+			// ---------------------------
+		})
+
+		assert.True(t, result.Signed)
+		assert.Equal(t, signingSecret, result.SigningSecret)
+
+		apiKeyRepository.Mock.AssertExpectations(t)
+	})
+
+	t.Run("Rotate Replaces The Signing Secret", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		pool.ExpectBegin()
+		pool.ExpectCommit()
+
+		idGenerator := internal_security_mock.NewIdGenMock()
+
+		idGenerator.Mock.On("Generate", 40).Return("zyxwvutsrqponmlkjihgfedcba01234567890123", nil).Times(2)
+
+		oldSecret := "sig_old"
+		newSecret := "sig_zyxwvutsrqponmlkjihgfedcba01234567890123"
+
+		apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+		apiKeyRepository.Mock.On("FindById", mock.Anything, mock.Anything, "KEY-1").Return(&entity.ApiKey{Id: "KEY-1", TenantId: "TENANT-1", SigningSecret: &oldSecret}).Times(1)
+		apiKeyRepository.Mock.On("UpdateKey", mock.Anything, mock.Anything, mock.MatchedBy(func(apiKey *entity.ApiKey) bool {
+			return *apiKey.SigningSecret == newSecret
+		})).Return(&entity.ApiKey{Id: "KEY-1", TenantId: "TENANT-1", SigningSecret: &newSecret}).Times(1)
+
+		var result *model.ApiKeyResponse
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			result = usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, idGenerator, apiKeyRepository, nil, nil).Rotate(tenantContext(t), "KEY-1")
+			// ---------------------------
+		})
+
+		assert.Equal(t, newSecret, result.SigningSecret)
+
+		apiKeyRepository.Mock.AssertExpectations(t)
+	})
+
+	t.Run("Authenticate Passes The Signing Secret On", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		pool.ExpectBegin()
+		pool.ExpectCommit()
+
+		signingSecret := "sig_secret"
+
+		apiKeyRepository := internal_repository_mock.NewApiKeyRepositoryMock()
+
+		apiKeyRepository.Mock.On("FindByHash", mock.Anything, mock.Anything, hashTestApiKey("pzn_partner")).Return(&entity.ApiKey{Id: "KEY-1", TenantId: "TENANT-1", SigningSecret: &signingSecret}).Times(1)
+		apiKeyRepository.Mock.On("TouchLastUsed", mock.Anything, mock.Anything, "KEY-1").Times(1)
+
+		// Action
+		// ---SUT (Subject Under Test)
+		principal := usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, nil, apiKeyRepository, nil, nil).Authenticate(t.Context(), "pzn_partner")
+		// ---------------------------
+
+		// Assert
+		if assert.NotNil(t, principal) {
+			assert.Equal(t, signingSecret, principal.SigningSecret)
+		}
+	})
+}
+
+func TestApiKeyClaimNonce(t *testing.T) {
+	for _, saved := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Saved %t", saved), func(t *testing.T) {
+			// Arrange
+			pool, err := pgxmock.NewPool()
+			helper.PanicIfError(err)
+
+			defer pool.Close()
+
+			pool.ExpectBegin()
+			pool.ExpectCommit()
+
+			nonceRepository := internal_repository_mock.NewRequestNonceRepositoryMock()
+
+			nonceRepository.Mock.On("DeleteExpired", mock.Anything, mock.Anything).Times(1)
+			nonceRepository.Mock.On("Save", mock.Anything, mock.Anything, "KEY-1", "nonce-1").Return(saved).Times(1)
+
+			// Action
+			// ---SUT (Subject Under Test)
+			claimed := usecase.NewApiKeyUseCaseImpl(appApiKeyTestConfig, pool, nil, nil, nil, nil, nonceRepository).ClaimNonce(t.Context(), "KEY-1", "nonce-1")
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, saved, claimed)
+
+			nonceRepository.Mock.AssertExpectations(t)
+			assert.NoError(t, pool.ExpectationsWereMet())
+		})
+	}
+}
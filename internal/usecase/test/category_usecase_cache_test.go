@@ -0,0 +1,331 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/metrics"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var appCacheTestConfig = &config.AppConfig{
+	Stream: &config.Stream{
+		LogSize:    10,
+		BufferSize: 10,
+	},
+	CategoryCache: &config.CategoryCache{
+		Enabled: true,
+		Size:    10,
+		TTL:     60,
+	},
+}
+
+func setupBroker(t *testing.T) stream.Broker {
+	broker := stream.NewBrokerImpl(appCacheTestConfig)
+	t.Cleanup(broker.Close)
+	return broker
+}
+
+func setupCategoryCache(t *testing.T, broker stream.Broker) *usecase.CategoryCache {
+	categoryCache := usecase.NewCategoryCache(appCacheTestConfig, broker)
+	categoryCache.Start(t.Context())
+	t.Cleanup(categoryCache.Close)
+	return categoryCache
+}
+
+func TestCacheFindById(t *testing.T) {
+	// Arrange
+	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+	categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-1").Return(&model.CategoryResponse{Id: "CAT-1", Name: "Fashions"}).Once()
+
+	cachedUseCase := usecase.NewCategoryUseCaseCache(categoryUseCase, setupCategoryCache(t, setupBroker(t)))
+
+	hits := testutil.ToFloat64(metrics.CacheRequestsTotal.WithLabelValues("category", "FindById", "hit"))
+	misses := testutil.ToFloat64(metrics.CacheRequestsTotal.WithLabelValues("category", "FindById", "miss"))
+
+	// Action
+	// ---SUT (Subject Under Test)
+	firstResponse := cachedUseCase.FindById(tenantContext(t), "CAT-1")
+	firstResponse.Name = "Changed"
+	secondResponse := cachedUseCase.FindById(tenantContext(t), "CAT-1")
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, &model.CategoryResponse{Id: "CAT-1", Name: "Fashions"}, secondResponse)
+
+	assert.Equal(t, hits+1, testutil.ToFloat64(metrics.CacheRequestsTotal.WithLabelValues("category", "FindById", "hit")))
+	assert.Equal(t, misses+1, testutil.ToFloat64(metrics.CacheRequestsTotal.WithLabelValues("category", "FindById", "miss")))
+
+	categoryUseCase.Mock.AssertExpectations(t)
+}
+
+func TestCacheFindAll(t *testing.T) {
+	// Arrange
+	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+	categoryUseCase.Mock.On("FindAll", mock.Anything).Return([]model.CategoryResponse{{Id: "CAT-1", Name: "Fashions"}}).Once()
+
+	cachedUseCase := usecase.NewCategoryUseCaseCache(categoryUseCase, setupCategoryCache(t, setupBroker(t)))
+
+	// Action
+	// ---SUT (Subject Under Test)
+	cachedUseCase.FindAll(tenantContext(t))[0].Name = "Changed"
+	categoryResponses := cachedUseCase.FindAll(tenantContext(t))
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, []model.CategoryResponse{{Id: "CAT-1", Name: "Fashions"}}, categoryResponses)
+
+	categoryUseCase.Mock.AssertExpectations(t)
+}
+
+func TestCacheCollapsesConcurrentMisses(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+
+	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+	categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-1").Run(func(mock.Arguments) {
+		<-release
+	}).Return(&model.CategoryResponse{Id: "CAT-1", Name: "Fashions"})
+
+	cachedUseCase := usecase.NewCategoryUseCaseCache(categoryUseCase, setupCategoryCache(t, setupBroker(t)))
+
+	categoryResponses := make([]*model.CategoryResponse, 10)
+
+	wg := sync.WaitGroup{}
+
+	// Action
+	for i := range categoryResponses {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// ---SUT (Subject Under Test)
+			categoryResponses[i] = cachedUseCase.FindById(tenantContext(t), "CAT-1")
+			// ---------------------------
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	wg.Wait()
+
+	// Assert
+	for _, categoryResponse := range categoryResponses {
+		assert.Equal(t, &model.CategoryResponse{Id: "CAT-1", Name: "Fashions"}, categoryResponse)
+	}
+
+	categoryUseCase.Mock.AssertNumberOfCalls(t, "FindById", 1)
+}
+
+func TestCacheDoesNotCachePanic(t *testing.T) {
+	// Arrange
+	errNotFound := exception.NewErrorClientRequest(errors.New("category is not found"), http.StatusNotFound, "category is not found")
+
+	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+	categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-1").Run(func(mock.Arguments) {
+		panic(errNotFound)
+	})
+
+	cachedUseCase := usecase.NewCategoryUseCaseCache(categoryUseCase, setupCategoryCache(t, setupBroker(t)))
+
+	// Action & Assert
+	for range 2 {
+		assert.PanicsWithValue(t, errNotFound, func() {
+			// ---SUT (Subject Under Test)
+			cachedUseCase.FindById(tenantContext(t), "CAT-1")
+			// ---------------------------
+		})
+	}
+
+	categoryUseCase.Mock.AssertNumberOfCalls(t, "FindById", 2)
+}
+
+func TestCacheInvalidatesOnWrite(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(cachedUseCase usecase.CategoryUseCase)
+	}{
+		{name: "Create", write: func(cachedUseCase usecase.CategoryUseCase) {
+			cachedUseCase.Create(tenantContext(t), &model.CreateCategoryRequest{Name: "Gadgets"})
+		}},
+		{name: "CreateIdempotent", write: func(cachedUseCase usecase.CategoryUseCase) {
+			cachedUseCase.CreateIdempotent(tenantContext(t), "key-1", &model.CreateCategoryRequest{Name: "Gadgets"})
+		}},
+		{name: "Update", write: func(cachedUseCase usecase.CategoryUseCase) {
+			cachedUseCase.Update(tenantContext(t), "CAT-1", &model.UpdateCategoryRequest{Name: "Gadgets"})
+		}},
+		{name: "Delete", write: func(cachedUseCase usecase.CategoryUseCase) {
+			cachedUseCase.Delete(tenantContext(t), "CAT-1")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+			categoryUseCase.Mock.On("FindAll", mock.Anything).Return([]model.CategoryResponse{{Id: "CAT-1", Name: "Fashions"}})
+			categoryUseCase.Mock.On("Create", mock.Anything, mock.Anything).Return(&model.CategoryResponse{Id: "CAT-2", Name: "Gadgets"})
+			categoryUseCase.Mock.On("CreateIdempotent", mock.Anything, mock.Anything, mock.Anything).Return(&model.CategoryResponse{Id: "CAT-2", Name: "Gadgets"}, false)
+			categoryUseCase.Mock.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(&model.CategoryResponse{Id: "CAT-1", Name: "Gadgets"})
+			categoryUseCase.Mock.On("Delete", mock.Anything, mock.Anything)
+
+			cachedUseCase := usecase.NewCategoryUseCaseCache(categoryUseCase, setupCategoryCache(t, setupBroker(t)))
+
+			cachedUseCase.FindAll(tenantContext(t))
+
+			// Action
+			// ---SUT (Subject Under Test)
+			test.write(cachedUseCase)
+			// ---------------------------
+
+			// Assert
+			cachedUseCase.FindAll(tenantContext(t))
+
+			categoryUseCase.Mock.AssertNumberOfCalls(t, "FindAll", 2)
+		})
+	}
+}
+
+func TestCacheKeepsOnIdempotentReplay(t *testing.T) {
+	// Arrange
+	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+	categoryUseCase.Mock.On("FindAll", mock.Anything).Return([]model.CategoryResponse{{Id: "CAT-1", Name: "Fashions"}})
+	categoryUseCase.Mock.On("CreateIdempotent", mock.Anything, mock.Anything, mock.Anything).Return(&model.CategoryResponse{Id: "CAT-1", Name: "Fashions"}, true)
+
+	cachedUseCase := usecase.NewCategoryUseCaseCache(categoryUseCase, setupCategoryCache(t, setupBroker(t)))
+
+	cachedUseCase.FindAll(tenantContext(t))
+
+	// Action
+	// ---SUT (Subject Under Test)
+	cachedUseCase.CreateIdempotent(tenantContext(t), "key-1", &model.CreateCategoryRequest{Name: "Fashions"})
+	// ---------------------------
+
+	// Assert
+	cachedUseCase.FindAll(tenantContext(t))
+
+	categoryUseCase.Mock.AssertNumberOfCalls(t, "FindAll", 1)
+}
+
+func TestCacheInvalidatesOnEvent(t *testing.T) {
+	// Arrange
+	broker := setupBroker(t)
+
+	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+	categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-1").Return(&model.CategoryResponse{Id: "CAT-1", Name: "Fashions"})
+
+	cachedUseCase := usecase.NewCategoryUseCaseCache(categoryUseCase, setupCategoryCache(t, broker))
+
+	cachedUseCase.FindById(tenantContext(t), "CAT-1")
+
+	// Action
+	// ---SUT (Subject Under Test)
+	broker.Publish(&model.CategoryEventResponse{
+		Id:       1,
+		TenantId: "TENANT-1",
+		Type:     "category.updated",
+		Data:     []byte(`{"id":"CAT-1","name":"Gadgets"}`),
+	})
+	// ---------------------------
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		cachedUseCase.FindById(tenantContext(t), "CAT-1")
+		return len(categoryUseCase.Mock.Calls) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCacheClosed(t *testing.T) {
+	// Arrange
+	broker := setupBroker(t)
+
+	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+	categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-1").Return(&model.CategoryResponse{Id: "CAT-1", Name: "Fashions"})
+
+	categoryCache := usecase.NewCategoryCache(appCacheTestConfig, broker)
+	categoryCache.Start(t.Context())
+
+	cachedUseCase := usecase.NewCategoryUseCaseCache(categoryUseCase, categoryCache)
+
+	cachedUseCase.FindById(tenantContext(t), "CAT-1")
+
+	// Action
+	// ---SUT (Subject Under Test)
+	categoryCache.Close()
+	// ---------------------------
+
+	broker.Publish(&model.CategoryEventResponse{
+		Id:       1,
+		TenantId: "TENANT-1",
+		Type:     "category.updated",
+		Data:     []byte(`{"id":"CAT-1","name":"Gadgets"}`),
+	})
+
+	// Assert
+	assert.Never(t, func() bool {
+		cachedUseCase.FindById(tenantContext(t), "CAT-1")
+		return len(categoryUseCase.Mock.Calls) != 1
+	}, 100*time.Millisecond, 10*time.Millisecond, "a closed cache no longer follows the broker")
+}
+
+func TestCacheKeysPerTenant(t *testing.T) {
+	// Arrange
+	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+	categoryUseCase.Mock.On("FindAll", mock.Anything).Return([]model.CategoryResponse{{Id: "CAT-1", Name: "Fashions"}}).Once()
+	categoryUseCase.Mock.On("FindAll", mock.Anything).Return([]model.CategoryResponse{}).Once()
+
+	cachedUseCase := usecase.NewCategoryUseCaseCache(categoryUseCase, setupCategoryCache(t, setupBroker(t)))
+
+	otherTenantCtx := security.ContextWithPrincipal(t.Context(), &security.Principal{Id: "KEY-2", TenantId: "TENANT-2"})
+
+	// Action
+	// ---SUT (Subject Under Test)
+	categoryResponses := cachedUseCase.FindAll(tenantContext(t))
+	otherTenantCategoryResponses := cachedUseCase.FindAll(otherTenantCtx)
+	// ---------------------------
+
+	// Assert
+	assert.Len(t, categoryResponses, 1)
+	assert.Empty(t, otherTenantCategoryResponses, "a tenant never reads the cached categories of another")
+
+	categoryUseCase.Mock.AssertExpectations(t)
+}
+
+func TestCacheDisabled(t *testing.T) {
+	// Arrange
+	appConfig := &config.AppConfig{
+		CategoryCache: &config.CategoryCache{Enabled: false},
+	}
+
+	// Action
+	// ---SUT (Subject Under Test)
+	categoryUseCase := usecase.NewCategoryUseCase(appConfig, nil, nil, nil, nil, nil, nil)
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, usecase.NewCategoryUseCaseTracing(usecase.NewCategoryUseCaseImpl(nil, nil, nil, nil, nil)), categoryUseCase)
+}
@@ -1,12 +1,16 @@
 package usecase
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
 	internal_repository_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
 	internal_security_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/security/mock"
 
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
@@ -25,7 +29,7 @@ func TestCreateFailed(t *testing.T) {
 
 		defer pool.Close()
 
-		pool.ExpectBegin()
+		expectTenantBegin(pool)
 		pool.ExpectRollback()
 
 		validate := internal_security_mock.NewValidationMock()
@@ -41,7 +45,7 @@ func TestCreateFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "repository save method panic", func() {
 			// ---SUT (Subject Under Test)
-			usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository).Create(t.Context(), &model.CreateCategoryRequest{
+			usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository, nil, nil).Create(tenantContext(t), &model.CreateCategoryRequest{
 				Name: "Fashions",
 			})
 			// ---------------------------
@@ -62,7 +66,7 @@ func TestCreateSuccess(t *testing.T) {
 
 	defer pool.Close()
 
-	pool.ExpectBegin()
+	expectTenantBegin(pool)
 	pool.ExpectCommit()
 
 	validate := internal_security_mock.NewValidationMock()
@@ -78,12 +82,20 @@ func TestCreateSuccess(t *testing.T) {
 		Name: "Fashions",
 	}).Times(1)
 
+	outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+
+	outboxEventRepository.Mock.On("Save", mock.Anything, mock.Anything, mock.MatchedBy(func(outboxEvent *entity.OutboxEvent) bool {
+		return outboxEvent.EventType == entity.EventCategoryCreated &&
+			outboxEvent.AggregateId == "CAT-1" &&
+			string(outboxEvent.Payload) == `{"id":"CAT-1","name":"Fashions"}`
+	})).Return(new(entity.OutboxEvent)).Times(1)
+
 	var result *model.CategoryResponse
 
 	// Action & Assert
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
-		result = usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository).Create(t.Context(), &model.CreateCategoryRequest{
+		result = usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository, nil, outboxEventRepository).Create(tenantContext(t), &model.CreateCategoryRequest{
 			Name: "Fashions",
 		})
 		// ---------------------------
@@ -99,6 +111,8 @@ func TestCreateSuccess(t *testing.T) {
 
 	categoryRepository.Mock.AssertExpectations(t)
 	categoryRepository.Mock.AssertNumberOfCalls(t, "Save", 1)
+
+	outboxEventRepository.Mock.AssertExpectations(t)
 }
 
 func TestUpdateFailed(t *testing.T) {
@@ -109,7 +123,7 @@ func TestUpdateFailed(t *testing.T) {
 
 		defer pool.Close()
 
-		pool.ExpectBegin()
+		expectTenantBegin(pool)
 		pool.ExpectRollback()
 
 		validate := internal_security_mock.NewValidationMock()
@@ -125,7 +139,7 @@ func TestUpdateFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "repository FindById method panic", func() {
 			// ---SUT (Subject Under Test)
-			usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository).Update(t.Context(), "CAT-1", &model.UpdateCategoryRequest{
+			usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository, nil, nil).Update(tenantContext(t), "CAT-1", &model.UpdateCategoryRequest{
 				Name: "Electronics",
 			})
 			// ---------------------------
@@ -148,7 +162,7 @@ func TestUpdateFailed(t *testing.T) {
 
 		defer pool.Close()
 
-		pool.ExpectBegin()
+		expectTenantBegin(pool)
 		pool.ExpectRollback()
 
 		validate := internal_security_mock.NewValidationMock()
@@ -166,7 +180,7 @@ func TestUpdateFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "repository Update method panic", func() {
 			// ---SUT (Subject Under Test)
-			usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository).Update(t.Context(), "CAT-1", &model.UpdateCategoryRequest{
+			usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository, nil, nil).Update(tenantContext(t), "CAT-1", &model.UpdateCategoryRequest{
 				Name: "Electronics",
 			})
 			// ---------------------------
@@ -190,7 +204,7 @@ func TestUpdateSuccess(t *testing.T) {
 
 	defer pool.Close()
 
-	pool.ExpectBegin()
+	expectTenantBegin(pool)
 	pool.ExpectCommit()
 
 	validate := internal_security_mock.NewValidationMock()
@@ -213,12 +227,20 @@ func TestUpdateSuccess(t *testing.T) {
 		Name: "Electronics",
 	}).Times(1)
 
+	outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+
+	outboxEventRepository.Mock.On("Save", mock.Anything, mock.Anything, mock.MatchedBy(func(outboxEvent *entity.OutboxEvent) bool {
+		return outboxEvent.EventType == entity.EventCategoryUpdated &&
+			outboxEvent.AggregateId == "CAT-1" &&
+			string(outboxEvent.Payload) == `{"id":"CAT-1","name":"Electronics"}`
+	})).Return(new(entity.OutboxEvent)).Times(1)
+
 	var result *model.CategoryResponse
 
 	// Action & Assert
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
-		result = usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository).Update(t.Context(), "CAT-1", requestBody)
+		result = usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository, nil, outboxEventRepository).Update(tenantContext(t), "CAT-1", requestBody)
 		// ---------------------------
 	})
 
@@ -235,6 +257,8 @@ func TestUpdateSuccess(t *testing.T) {
 
 	categoryRepository.Mock.AssertExpectations(t)
 	categoryRepository.Mock.AssertNumberOfCalls(t, "Update", 1)
+
+	outboxEventRepository.Mock.AssertExpectations(t)
 }
 
 func TestDeleteFailed(t *testing.T) {
@@ -245,7 +269,7 @@ func TestDeleteFailed(t *testing.T) {
 
 		defer pool.Close()
 
-		pool.ExpectBegin()
+		expectTenantBegin(pool)
 		pool.ExpectRollback()
 
 		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
@@ -255,7 +279,7 @@ func TestDeleteFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "repository Delete method panic", func() {
 			// ---SUT (Subject Under Test)
-			usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository).Delete(t.Context(), "CAT-1")
+			usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository, nil, nil).Delete(tenantContext(t), "CAT-1")
 			// ---------------------------
 		})
 
@@ -273,7 +297,7 @@ func TestDeleteFailed(t *testing.T) {
 
 		defer pool.Close()
 
-		pool.ExpectBegin()
+		expectTenantBegin(pool)
 		pool.ExpectRollback()
 
 		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
@@ -285,7 +309,7 @@ func TestDeleteFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "repository Delete method panic", func() {
 			// ---SUT (Subject Under Test)
-			usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository).Delete(t.Context(), "CAT-1")
+			usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository, nil, nil).Delete(tenantContext(t), "CAT-1")
 			// ---------------------------
 		})
 
@@ -304,18 +328,29 @@ func TestDeleteSuccess(t *testing.T) {
 
 	defer pool.Close()
 
-	pool.ExpectBegin()
+	expectTenantBegin(pool)
 	pool.ExpectCommit()
 
 	categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
 
-	categoryRepository.Mock.On("FindById", mock.Anything, mock.Anything, "CAT-1").Return(new(entity.Category)).Times(1)
+	categoryRepository.Mock.On("FindById", mock.Anything, mock.Anything, "CAT-1").Return(&entity.Category{
+		Id:   "CAT-1",
+		Name: "Fashions",
+	}).Times(1)
 	categoryRepository.Mock.On("Delete", mock.Anything, mock.Anything, "CAT-1").Times(1)
 
+	outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+
+	outboxEventRepository.Mock.On("Save", mock.Anything, mock.Anything, mock.MatchedBy(func(outboxEvent *entity.OutboxEvent) bool {
+		return outboxEvent.EventType == entity.EventCategoryDeleted &&
+			outboxEvent.AggregateId == "CAT-1" &&
+			string(outboxEvent.Payload) == `{"id":"CAT-1","name":"Fashions"}`
+	})).Return(new(entity.OutboxEvent)).Times(1)
+
 	// Action & Assert
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
-		usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository).Delete(t.Context(), "CAT-1")
+		usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository, nil, outboxEventRepository).Delete(tenantContext(t), "CAT-1")
 		// ---------------------------
 	})
 
@@ -324,6 +359,8 @@ func TestDeleteSuccess(t *testing.T) {
 
 	categoryRepository.Mock.AssertExpectations(t)
 	categoryRepository.Mock.AssertNumberOfCalls(t, "Delete", 1)
+
+	outboxEventRepository.Mock.AssertExpectations(t)
 }
 
 func TestFindByIdFailed(t *testing.T) {
@@ -334,7 +371,7 @@ func TestFindByIdFailed(t *testing.T) {
 
 		defer pool.Close()
 
-		pool.ExpectBegin()
+		expectTenantBegin(pool)
 		pool.ExpectRollback()
 
 		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
@@ -344,7 +381,7 @@ func TestFindByIdFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "repository FindById method panic", func() {
 			// ---SUT (Subject Under Test)
-			usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository).FindById(t.Context(), "CAT-1")
+			usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository, nil, nil).FindById(tenantContext(t), "CAT-1")
 			// ---------------------------
 		})
 
@@ -360,7 +397,7 @@ func TestFindByIdSuccess(t *testing.T) {
 
 	defer pool.Close()
 
-	pool.ExpectBegin()
+	expectTenantBegin(pool)
 	pool.ExpectCommit()
 
 	categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
@@ -375,7 +412,7 @@ func TestFindByIdSuccess(t *testing.T) {
 	// Action & Assert
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
-		result = usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository).FindById(t.Context(), "CAT-1")
+		result = usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository, nil, nil).FindById(tenantContext(t), "CAT-1")
 		// ---------------------------
 	})
 
@@ -396,7 +433,7 @@ func TestFindAllFailed(t *testing.T) {
 
 		defer pool.Close()
 
-		pool.ExpectBegin()
+		expectTenantBegin(pool)
 		pool.ExpectRollback()
 
 		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
@@ -406,7 +443,7 @@ func TestFindAllFailed(t *testing.T) {
 		// Action & Assert
 		assert.PanicsWithValue(t, "repository FindAll method panic", func() {
 			// ---SUT (Subject Under Test)
-			usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository).FindAll(t.Context())
+			usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository, nil, nil).FindAll(tenantContext(t))
 			// ---------------------------
 		})
 
@@ -422,7 +459,7 @@ func TestFindAllSuccess(t *testing.T) {
 
 	defer pool.Close()
 
-	pool.ExpectBegin()
+	expectTenantBegin(pool)
 	pool.ExpectCommit()
 
 	categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
@@ -438,7 +475,7 @@ func TestFindAllSuccess(t *testing.T) {
 	// Action & Assert
 	assert.NotPanics(t, func() {
 		// ---SUT (Subject Under Test)
-		result = usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository).FindAll(t.Context())
+		result = usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository, nil, nil).FindAll(tenantContext(t))
 		// ---------------------------
 	})
 
@@ -451,3 +488,404 @@ func TestFindAllSuccess(t *testing.T) {
 	categoryRepository.Mock.AssertExpectations(t)
 	categoryRepository.Mock.AssertNumberOfCalls(t, "FindAll", 1)
 }
+
+func TestFindByIdsSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	expectTenantBegin(pool)
+	pool.ExpectCommit()
+
+	categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
+
+	categoryRepository.Mock.On("FindByIds", mock.Anything, mock.Anything, []string{"CAT-1", "CAT-2"}).Return([]entity.Category{
+		{Id: "CAT-1", Name: "Drinks"},
+		{Id: "CAT-2", Name: "Foods"},
+	}).Times(1)
+
+	var result []model.CategoryResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository, nil, nil).FindByIds(tenantContext(t), []string{"CAT-1", "CAT-2"})
+		// ---------------------------
+	})
+
+	assert.Equal(t, []model.CategoryResponse{
+		{Id: "CAT-1", Name: "Drinks"},
+		{Id: "CAT-2", Name: "Foods"},
+	}, result)
+
+	categoryRepository.Mock.AssertExpectations(t)
+	categoryRepository.Mock.AssertNumberOfCalls(t, "FindByIds", 1)
+}
+
+func TestFindPageFailed(t *testing.T) {
+	t.Run("Validation Error", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		validate := internal_security_mock.NewValidationMock()
+
+		validate.Mock.On("Struct", mock.Anything).Return(validator.New().Struct(&model.PageCategoryRequest{
+			Limit: 1000,
+		}))
+
+		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
+
+		// Action & Assert
+		assert.Panics(t, func() {
+			// ---SUT (Subject Under Test)
+			usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository, nil, nil).FindPage(tenantContext(t), &model.PageCategoryRequest{
+				Limit: 1000,
+			})
+			// ---------------------------
+		})
+
+		categoryRepository.Mock.AssertNumberOfCalls(t, "FindPage", 0)
+	})
+}
+
+func TestFindPageSuccess(t *testing.T) {
+	t.Run("Has Next Page", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		expectTenantBegin(pool)
+		pool.ExpectCommit()
+
+		validate := internal_security_mock.NewValidationMock()
+
+		validate.Mock.On("Struct", mock.Anything).Return(nil)
+
+		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
+
+		categoryRepository.Mock.On("FindPage", mock.Anything, mock.Anything, "", 3).Return([]entity.Category{
+			{Id: "CAT-1", Name: "Drinks"},
+			{Id: "CAT-2", Name: "Foods"},
+			{Id: "CAT-3", Name: "Furniture"},
+		}).Times(1)
+
+		categoryRepository.Mock.On("Count", mock.Anything, mock.Anything).Return(5).Times(1)
+
+		var result *model.CategoryPageResponse
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			result = usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository, nil, nil).FindPage(tenantContext(t), &model.PageCategoryRequest{
+				Limit: 2,
+			})
+			// ---------------------------
+		})
+
+		assert.Equal(t, &model.CategoryPageResponse{
+			Categories: []model.CategoryResponse{
+				{Id: "CAT-1", Name: "Drinks"},
+				{Id: "CAT-2", Name: "Foods"},
+			},
+			HasNextPage: true,
+			TotalCount:  5,
+		}, result)
+
+		categoryRepository.Mock.AssertExpectations(t)
+	})
+
+	t.Run("Last Page", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		expectTenantBegin(pool)
+		pool.ExpectCommit()
+
+		validate := internal_security_mock.NewValidationMock()
+
+		validate.Mock.On("Struct", mock.Anything).Return(nil)
+
+		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
+
+		categoryRepository.Mock.On("FindPage", mock.Anything, mock.Anything, "CAT-3", 3).Return([]entity.Category{
+			{Id: "CAT-4", Name: "Toys"},
+		}).Times(1)
+
+		categoryRepository.Mock.On("Count", mock.Anything, mock.Anything).Return(4).Times(1)
+
+		var result *model.CategoryPageResponse
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			result = usecase.NewCategoryUseCaseImpl(pool, validate, categoryRepository, nil, nil).FindPage(tenantContext(t), &model.PageCategoryRequest{
+				AfterId: "CAT-3",
+				Limit:   2,
+			})
+			// ---------------------------
+		})
+
+		assert.Equal(t, &model.CategoryPageResponse{
+			Categories: []model.CategoryResponse{
+				{Id: "CAT-4", Name: "Toys"},
+			},
+			HasNextPage: false,
+			TotalCount:  4,
+		}, result)
+
+		categoryRepository.Mock.AssertExpectations(t)
+	})
+}
+
+func TestFindVersionSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	expectTenantBegin(pool)
+	pool.ExpectCommit()
+
+	updatedAt := time.Date(2026, time.October, 19, 11, 0, 0, 0, time.UTC)
+
+	categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
+
+	categoryRepository.Mock.On("FindVersion", mock.Anything, mock.Anything).Return(&entity.TableVersion{
+		TableName: "categories",
+		TenantId:  "TENANT-1",
+		Version:   7,
+		UpdatedAt: updatedAt,
+	}).Times(1)
+
+	var result *model.CategoryVersionResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewCategoryUseCaseImpl(pool, nil, categoryRepository, nil, nil).FindVersion(tenantContext(t))
+		// ---------------------------
+	})
+
+	assert.Equal(t, &model.CategoryVersionResponse{
+		TenantId:  "TENANT-1",
+		Version:   7,
+		UpdatedAt: updatedAt,
+	}, result)
+
+	categoryRepository.Mock.AssertExpectations(t)
+	categoryRepository.Mock.AssertNumberOfCalls(t, "FindVersion", 1)
+}
+
+func TestCreateIdempotentFailed(t *testing.T) {
+	t.Run("Invalid Idempotency Key", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		// Action & Assert
+		assert.Panics(t, func() {
+			// ---SUT (Subject Under Test)
+			usecase.NewCategoryUseCaseImpl(pool, security.NewValidationImpl(), nil, nil, nil).CreateIdempotent(tenantContext(t), strings.Repeat("k", 256), &model.CreateCategoryRequest{
+				Name: "Fashions",
+			})
+			// ---------------------------
+		})
+
+		assert.NoError(t, pool.ExpectationsWereMet())
+	})
+
+	t.Run("Idempotency Key Reused With A Different Body", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		expectTenantBegin(pool)
+		pool.ExpectRollback()
+
+		idempotencyKeyRepository := internal_repository_mock.NewIdempotencyKeyRepositoryMock()
+
+		idempotencyKeyRepository.Mock.On("Lock", mock.Anything, mock.Anything, "key-1").Times(1)
+		idempotencyKeyRepository.Mock.On("DeleteExpired", mock.Anything, mock.Anything).Times(1)
+		idempotencyKeyRepository.Mock.On("FindByKey", mock.Anything, mock.Anything, "key-1").Return(&entity.IdempotencyKey{
+			Key:          "key-1",
+			RequestHash:  "hash of another body",
+			ResponseBody: []byte(`{"id":"CAT-1","name":"Drinks"}`),
+		}).Times(1)
+
+		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
+
+		// Action & Assert
+		assert.PanicsWithError(t, "idempotency key is reused", func() {
+			// ---SUT (Subject Under Test)
+			usecase.NewCategoryUseCaseImpl(pool, security.NewValidationImpl(), categoryRepository, idempotencyKeyRepository, nil).CreateIdempotent(tenantContext(t), "key-1", &model.CreateCategoryRequest{
+				Name: "Fashions",
+			})
+			// ---------------------------
+		})
+
+		idempotencyKeyRepository.Mock.AssertExpectations(t)
+		categoryRepository.Mock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCreateIdempotentSuccess(t *testing.T) {
+	t.Run("First Request Creates And Stores The Response", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		expectTenantBegin(pool)
+		pool.ExpectCommit()
+
+		idempotencyKeyRepository := internal_repository_mock.NewIdempotencyKeyRepositoryMock()
+
+		idempotencyKeyRepository.Mock.On("Lock", mock.Anything, mock.Anything, "key_1234:key-1").Times(1)
+		idempotencyKeyRepository.Mock.On("DeleteExpired", mock.Anything, mock.Anything).Times(1)
+		idempotencyKeyRepository.Mock.On("FindByKey", mock.Anything, mock.Anything, "key_1234:key-1").Return(nil).Times(1)
+		idempotencyKeyRepository.Mock.On("Save", mock.Anything, mock.Anything, mock.MatchedBy(func(idempotencyKey *entity.IdempotencyKey) bool {
+			return idempotencyKey.Key == "key_1234:key-1" &&
+				len(idempotencyKey.RequestHash) == 64 &&
+				string(idempotencyKey.ResponseBody) == `{"id":"CAT-1","name":"Fashions"}`
+		})).Return(new(entity.IdempotencyKey)).Times(1)
+
+		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
+
+		categoryRepository.Mock.On("Save", mock.Anything, mock.Anything, &entity.Category{Name: "Fashions"}).Return(&entity.Category{
+			Id:   "CAT-1",
+			Name: "Fashions",
+		}).Times(1)
+
+		outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+
+		outboxEventRepository.Mock.On("Save", mock.Anything, mock.Anything, mock.MatchedBy(func(outboxEvent *entity.OutboxEvent) bool {
+			return outboxEvent.EventType == entity.EventCategoryCreated && outboxEvent.AggregateId == "CAT-1"
+		})).Return(new(entity.OutboxEvent)).Times(1)
+
+		ctx := helper.ContextWithRequestInfo(tenantContext(t), &helper.RequestInfo{ApiKeyIdentity: "key_1234"})
+
+		var result *model.CategoryResponse
+		var replayed bool
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			result, replayed = usecase.NewCategoryUseCaseImpl(pool, security.NewValidationImpl(), categoryRepository, idempotencyKeyRepository, outboxEventRepository).CreateIdempotent(ctx, "key-1", &model.CreateCategoryRequest{
+				Name: "Fashions",
+			})
+			// ---------------------------
+		})
+
+		assert.Equal(t, &model.CategoryResponse{Id: "CAT-1", Name: "Fashions"}, result)
+		assert.False(t, replayed)
+
+		idempotencyKeyRepository.Mock.AssertExpectations(t)
+		categoryRepository.Mock.AssertExpectations(t)
+		outboxEventRepository.Mock.AssertExpectations(t)
+	})
+
+	t.Run("Retry Replays The Stored Response", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		expectTenantBegin(pool)
+		pool.ExpectCommit()
+
+		var storedKey *entity.IdempotencyKey
+
+		idempotencyKeyRepository := internal_repository_mock.NewIdempotencyKeyRepositoryMock()
+
+		idempotencyKeyRepository.Mock.On("Lock", mock.Anything, mock.Anything, "key-1")
+		idempotencyKeyRepository.Mock.On("DeleteExpired", mock.Anything, mock.Anything)
+		idempotencyKeyRepository.Mock.On("FindByKey", mock.Anything, mock.Anything, "key-1").Return(nil).Once()
+		idempotencyKeyRepository.Mock.On("Save", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			storedKey = args.Get(2).(*entity.IdempotencyKey)
+		}).Return(new(entity.IdempotencyKey)).Once()
+
+		categoryRepository := internal_repository_mock.NewCategoryRepositoryMock()
+
+		categoryRepository.Mock.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Category{
+			Id:   "CAT-1",
+			Name: "Fashions",
+		}).Once()
+
+		outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+
+		outboxEventRepository.Mock.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(new(entity.OutboxEvent)).Once()
+
+		categoryUseCase := usecase.NewCategoryUseCaseImpl(pool, security.NewValidationImpl(), categoryRepository, idempotencyKeyRepository, outboxEventRepository)
+
+		categoryUseCase.CreateIdempotent(tenantContext(t), "key-1", &model.CreateCategoryRequest{Name: "Fashions"})
+
+		expectTenantBegin(pool)
+		pool.ExpectCommit()
+
+		idempotencyKeyRepository.Mock.On("FindByKey", mock.Anything, mock.Anything, "key-1").Return(storedKey).Once()
+
+		var result *model.CategoryResponse
+		var replayed bool
+
+		// Action & Assert
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			result, replayed = categoryUseCase.CreateIdempotent(tenantContext(t), "key-1", &model.CreateCategoryRequest{
+				Name: "Fashions",
+			})
+			// ---------------------------
+		})
+
+		assert.Equal(t, &model.CategoryResponse{Id: "CAT-1", Name: "Fashions"}, result)
+		assert.True(t, replayed)
+
+		categoryRepository.Mock.AssertNumberOfCalls(t, "Save", 1)
+		idempotencyKeyRepository.Mock.AssertExpectations(t)
+		outboxEventRepository.Mock.AssertNumberOfCalls(t, "Save", 1)
+	})
+}
+
+func TestCategoryUseCaseWithoutTenant(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	ctx := security.ContextWithPrincipal(t.Context(), &security.Principal{Id: "jwt:user-1", Scopes: []string{security.ScopeCategoriesRead}})
+
+	// Action & Assert
+	assert.PanicsWithError(t, "forbidden", func() {
+		// ---SUT (Subject Under Test)
+		usecase.NewCategoryUseCaseImpl(pool, nil, nil, nil, nil).FindAll(ctx)
+		// ---------------------------
+	})
+
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func tenantContext(t *testing.T) context.Context {
+	return security.ContextWithPrincipal(t.Context(), &security.Principal{Id: "KEY-1", TenantId: "TENANT-1"})
+}
+
+func expectTenantBegin(pool pgxmock.PgxPoolIface) {
+	pool.ExpectBegin()
+	pool.ExpectExec("set_config").WithArgs("TENANT-1").WillReturnResult(pgxmock.NewResult("SELECT", 1))
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	internal_usecase_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupSpanExporter() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	return exporter
+}
+
+func TestTracingSuccess(t *testing.T) {
+	// Arrange
+	exporter := setupSpanExporter()
+
+	var useCaseSpanContext trace.SpanContext
+
+	categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+	categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-1").Run(func(args mock.Arguments) {
+		useCaseSpanContext = trace.SpanContextFromContext(args.Get(0).(context.Context))
+	}).Return(&model.CategoryResponse{Id: "CAT-1", Name: "Fashions"})
+
+	// Action
+	// ---SUT (Subject Under Test)
+	categoryResponse := usecase.NewCategoryUseCaseTracing(categoryUseCase).FindById(t.Context(), "CAT-1")
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, &model.CategoryResponse{Id: "CAT-1", Name: "Fashions"}, categoryResponse)
+
+	spans := exporter.GetSpans()
+
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "CategoryUseCase.FindById", spans[0].Name)
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+		assert.Equal(t, spans[0].SpanContext.SpanID(), useCaseSpanContext.SpanID())
+	}
+
+	categoryUseCase.Mock.AssertExpectations(t)
+}
+
+func TestTracingFailed(t *testing.T) {
+	tests := []struct {
+		name       string
+		panic      any
+		statusCode codes.Code
+	}{
+		{name: "Client Error", panic: exception.NewErrorClientRequest(errors.New("category is not found"), http.StatusNotFound, "category is not found"), statusCode: codes.Unset},
+		{name: "Internal Error", panic: exception.NewErrorInternalServer(errors.New("connection refused"), "category > usecase > FindById"), statusCode: codes.Error},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			exporter := setupSpanExporter()
+
+			categoryUseCase := internal_usecase_mock.NewCategoryUseCaseMock()
+
+			categoryUseCase.Mock.On("FindById", mock.Anything, "CAT-1").Run(func(mock.Arguments) {
+				panic(test.panic)
+			})
+
+			// Action & Assert
+			assert.PanicsWithValue(t, test.panic, func() {
+				// ---SUT (Subject Under Test)
+				usecase.NewCategoryUseCaseTracing(categoryUseCase).FindById(t.Context(), "CAT-1")
+				// ---------------------------
+			})
+
+			spans := exporter.GetSpans()
+
+			if assert.Len(t, spans, 1) {
+				assert.Equal(t, test.statusCode, spans[0].Status.Code)
+				assert.Equal(t, "exception", spans[0].Events[0].Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	db_migration "github.com/syahdaromansyah/pzn-golang-restful-api/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	internal_repository_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+
+	"github.com/pashagolub/pgxmock/v4"
+	logrus_test "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadySuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectPing()
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	schemaMigrationRepository := internal_repository_mock.NewSchemaMigrationRepositoryMock()
+
+	hookLogger, _ := logrus_test.NewNullLogger()
+
+	schemaMigrationRepository.Mock.On("FindVersion", mock.Anything, mock.Anything).Return(&entity.SchemaMigration{
+		Version: db_migration.MigrationVersion(),
+	})
+
+	// Action
+	// ---SUT (Subject Under Test)
+	healthResponse := usecase.NewHealthUseCaseImpl(pool, hookLogger, helper.NewLifecycle(), schemaMigrationRepository).Ready(t.Context())
+	// ---------------------------
+
+	// Assert
+	assert.Equal(t, "pass", healthResponse.Status)
+	assert.Equal(t, []model.HealthCheckResponse{
+		{Name: "shutdown", Status: "pass"},
+		{Name: "database", Status: "pass"},
+		{Name: "migration", Status: "pass"},
+	}, healthResponse.Checks)
+
+	assert.NoError(t, pool.ExpectationsWereMet())
+	schemaMigrationRepository.Mock.AssertExpectations(t)
+}
+
+func TestReadyFailed(t *testing.T) {
+	t.Run("Shutting Down", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		pool.ExpectPing()
+		pool.ExpectBegin()
+		pool.ExpectCommit()
+
+		schemaMigrationRepository := internal_repository_mock.NewSchemaMigrationRepositoryMock()
+
+		hookLogger, hook := logrus_test.NewNullLogger()
+
+		schemaMigrationRepository.Mock.On("FindVersion", mock.Anything, mock.Anything).Return(&entity.SchemaMigration{
+			Version: db_migration.MigrationVersion(),
+		})
+
+		lifecycle := helper.NewLifecycle()
+		lifecycle.Drain()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		healthResponse := usecase.NewHealthUseCaseImpl(pool, hookLogger, lifecycle, schemaMigrationRepository).Ready(t.Context())
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, "fail", healthResponse.Status)
+		assert.Equal(t, model.HealthCheckResponse{Name: "shutdown", Status: "fail", Message: "unavailable"}, healthResponse.Checks[0])
+		assert.Equal(t, "the server is shutting down", hook.LastEntry().Data["error"])
+	})
+
+	t.Run("Database Is Unreachable", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		pool.ExpectPing().WillReturnError(errors.New("connection refused"))
+		pool.ExpectBegin().WillReturnError(errors.New("connection refused"))
+
+		schemaMigrationRepository := internal_repository_mock.NewSchemaMigrationRepositoryMock()
+
+		hookLogger, hook := logrus_test.NewNullLogger()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		healthResponse := usecase.NewHealthUseCaseImpl(pool, hookLogger, helper.NewLifecycle(), schemaMigrationRepository).Ready(t.Context())
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, "fail", healthResponse.Status)
+		assert.Equal(t, []model.HealthCheckResponse{
+			{Name: "shutdown", Status: "pass"},
+			{Name: "database", Status: "fail", Message: "unavailable"},
+			{Name: "migration", Status: "fail", Message: "unavailable"},
+		}, healthResponse.Checks)
+
+		// The cause is logged only, the probes are public
+		if assert.Len(t, hook.AllEntries(), 2) {
+			assert.Equal(t, "connection refused", hook.AllEntries()[0].Data["error"])
+			assert.Equal(t, "database", hook.AllEntries()[0].Data["check"])
+		}
+
+		schemaMigrationRepository.Mock.AssertNumberOfCalls(t, "FindVersion", 0)
+	})
+
+	latestVersion := db_migration.MigrationVersion()
+
+	tests := []struct {
+		name            string
+		schemaMigration *entity.SchemaMigration
+		message         string
+	}{
+		{name: "Not Migrated", schemaMigration: nil, message: fmt.Sprintf("the database is not migrated, expected version %d", latestVersion)},
+		{name: "Dirty Migration", schemaMigration: &entity.SchemaMigration{Version: latestVersion, Dirty: true}, message: fmt.Sprintf("migration version %d is dirty", latestVersion)},
+		{name: "Outdated Migration", schemaMigration: &entity.SchemaMigration{Version: 20250501154846}, message: fmt.Sprintf("migration version is 20250501154846, expected %d", latestVersion)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			pool, err := pgxmock.NewPool()
+			helper.PanicIfError(err)
+
+			defer pool.Close()
+
+			pool.ExpectPing()
+			pool.ExpectBegin()
+			pool.ExpectCommit()
+
+			schemaMigrationRepository := internal_repository_mock.NewSchemaMigrationRepositoryMock()
+
+			hookLogger, hook := logrus_test.NewNullLogger()
+
+			schemaMigrationRepository.Mock.On("FindVersion", mock.Anything, mock.Anything).Return(test.schemaMigration)
+
+			// Action
+			// ---SUT (Subject Under Test)
+			healthResponse := usecase.NewHealthUseCaseImpl(pool, hookLogger, helper.NewLifecycle(), schemaMigrationRepository).Ready(t.Context())
+			// ---------------------------
+
+			// Assert
+			assert.Equal(t, "fail", healthResponse.Status)
+			assert.Equal(t, model.HealthCheckResponse{Name: "migration", Status: "fail", Message: "unavailable"}, healthResponse.Checks[2])
+			assert.Equal(t, test.message, hook.LastEntry().Data["error"])
+
+			assert.NoError(t, pool.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Repository FindVersion Method Panic", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		pool.ExpectPing()
+		pool.ExpectBegin()
+		pool.ExpectRollback()
+
+		schemaMigrationRepository := internal_repository_mock.NewSchemaMigrationRepositoryMock()
+
+		hookLogger, hook := logrus_test.NewNullLogger()
+
+		schemaMigrationRepository.Mock.On("FindVersion", mock.Anything, mock.Anything).Panic("relation \"schema_migrations\" does not exist")
+
+		// Action & Assert
+		var healthResponse *model.HealthResponse
+
+		assert.NotPanics(t, func() {
+			// ---SUT (Subject Under Test)
+			healthResponse = usecase.NewHealthUseCaseImpl(pool, hookLogger, helper.NewLifecycle(), schemaMigrationRepository).Ready(t.Context())
+			// ---------------------------
+		})
+
+		assert.Equal(t, "fail", healthResponse.Status)
+		assert.Equal(t, model.HealthCheckResponse{Name: "migration", Status: "fail", Message: "unavailable"}, healthResponse.Checks[2])
+		assert.Equal(t, "relation \"schema_migrations\" does not exist", hook.LastEntry().Data["error"])
+
+		assert.NoError(t, pool.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	internal_repository_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+)
+
+func TestTenantCreateFailed(t *testing.T) {
+	t.Run("Not The Default Tenant", func(t *testing.T) {
+		// Arrange
+		tenantRepository := internal_repository_mock.NewTenantRepositoryMock()
+
+		// Action & Assert
+		defer func() {
+			errRecover := recover()
+
+			if assert.IsType(t, new(exception.ErrorClientRequest), errRecover) {
+				assert.Equal(t, http.StatusForbidden, errRecover.(*exception.ErrorClientRequest).GetStatusCode())
+			}
+
+			tenantRepository.Mock.AssertNumberOfCalls(t, "Save", 0)
+		}()
+
+		// ---SUT (Subject Under Test)
+		usecase.NewTenantUseCaseImpl(nil, security.NewValidationImpl(), tenantRepository).Create(tenantContext(t), &model.CreateTenantRequest{
+			Name: "brand-b",
+		})
+		// ---------------------------
+	})
+
+	t.Run("Name Too Short", func(t *testing.T) {
+		// Arrange
+		tenantRepository := internal_repository_mock.NewTenantRepositoryMock()
+
+		// Action & Assert
+		assert.Panics(t, func() {
+			// ---SUT (Subject Under Test)
+			usecase.NewTenantUseCaseImpl(nil, security.NewValidationImpl(), tenantRepository).Create(defaultTenantContext(t), &model.CreateTenantRequest{
+				Name: "b",
+			})
+			// ---------------------------
+		})
+
+		tenantRepository.Mock.AssertNumberOfCalls(t, "Save", 0)
+	})
+}
+
+func TestTenantCreateSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	createdAt := time.Date(2026, time.October, 19, 16, 0, 0, 0, time.UTC)
+
+	tenantRepository := internal_repository_mock.NewTenantRepositoryMock()
+
+	tenantRepository.Mock.On("Save", mock.Anything, mock.Anything, &entity.Tenant{Name: "brand-b"}).Return(&entity.Tenant{
+		Id:        "TENANT-2",
+		Name:      "brand-b",
+		CreatedAt: createdAt,
+	}).Times(1)
+
+	var result *model.TenantResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewTenantUseCaseImpl(pool, security.NewValidationImpl(), tenantRepository).Create(defaultTenantContext(t), &model.CreateTenantRequest{
+			Name: "brand-b",
+		})
+		// ---------------------------
+	})
+
+	assert.Equal(t, &model.TenantResponse{Id: "TENANT-2", Name: "brand-b", CreatedAt: createdAt}, result)
+
+	tenantRepository.Mock.AssertExpectations(t)
+	assert.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestTenantFindAllSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	pool.ExpectBegin()
+	pool.ExpectCommit()
+
+	tenantRepository := internal_repository_mock.NewTenantRepositoryMock()
+
+	tenantRepository.Mock.On("FindAll", mock.Anything, mock.Anything).Return([]entity.Tenant{
+		{Id: security.DefaultTenantId, Name: security.DefaultTenantId},
+		{Id: "TENANT-2", Name: "brand-b"},
+	}).Times(1)
+
+	var result []model.TenantResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewTenantUseCaseImpl(pool, nil, tenantRepository).FindAll(defaultTenantContext(t))
+		// ---------------------------
+	})
+
+	assert.Len(t, result, 2)
+
+	tenantRepository.Mock.AssertExpectations(t)
+}
+
+func defaultTenantContext(t *testing.T) context.Context {
+	return security.ContextWithPrincipal(t.Context(), &security.Principal{Id: "KEY-0", TenantId: security.DefaultTenantId})
+}
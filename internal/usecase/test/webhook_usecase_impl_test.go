@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	internal_repository_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	internal_security_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/security/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+)
+
+func TestWebhookCreateFailed(t *testing.T) {
+	tests := []struct {
+		name        string
+		requestBody *model.CreateWebhookRequest
+	}{
+		{name: "Not An HTTP URL", requestBody: &model.CreateWebhookRequest{Url: "ftp://example.com/hook", EventTypes: []string{"category.created"}}},
+		{name: "Without Event Types", requestBody: &model.CreateWebhookRequest{Url: "https://example.com/hook", EventTypes: []string{}}},
+		{name: "Unknown Event Type", requestBody: &model.CreateWebhookRequest{Url: "https://example.com/hook", EventTypes: []string{"category.renamed"}}},
+		{name: "Duplicate Event Types", requestBody: &model.CreateWebhookRequest{Url: "https://example.com/hook", EventTypes: []string{"category.created", "category.created"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			webhookSubscriptionRepository := internal_repository_mock.NewWebhookSubscriptionRepositoryMock()
+
+			// Action & Assert
+			assert.PanicsWithError(t, validator.New(validator.WithRequiredStructEnabled()).Struct(test.requestBody).Error(), func() {
+				// ---SUT (Subject Under Test)
+				usecase.NewWebhookUseCaseImpl(nil, security.NewValidationImpl(), nil, webhookSubscriptionRepository, nil).Create(tenantContext(t), test.requestBody)
+				// ---------------------------
+			})
+
+			webhookSubscriptionRepository.Mock.AssertNumberOfCalls(t, "Save", 0)
+		})
+	}
+}
+
+func TestWebhookCreateSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	expectTenantBegin(pool)
+	pool.ExpectCommit()
+
+	idGenerator := internal_security_mock.NewIdGenMock()
+
+	idGenerator.Mock.On("Generate", 32).Return("abcdefghijklmnopqrstuvwxyz012345", nil).Times(1)
+
+	createdAt := time.Date(2026, time.October, 19, 13, 0, 0, 0, time.UTC)
+
+	webhookSubscriptionRepository := internal_repository_mock.NewWebhookSubscriptionRepositoryMock()
+
+	webhookSubscriptionRepository.Mock.On("Save", mock.Anything, mock.Anything, &entity.WebhookSubscription{
+		Url:        "https://example.com/hook",
+		Secret:     "whsec_abcdefghijklmnopqrstuvwxyz012345",
+		EventTypes: []string{"category.created", "category.deleted"},
+	}).Return(&entity.WebhookSubscription{
+		Id:         "WH-1",
+		Url:        "https://example.com/hook",
+		Secret:     "whsec_abcdefghijklmnopqrstuvwxyz012345",
+		EventTypes: []string{"category.created", "category.deleted"},
+		CreatedAt:  createdAt,
+	}).Times(1)
+
+	var result *model.WebhookResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewWebhookUseCaseImpl(pool, security.NewValidationImpl(), idGenerator, webhookSubscriptionRepository, nil).Create(tenantContext(t), &model.CreateWebhookRequest{
+			Url:        "https://example.com/hook",
+			EventTypes: []string{"category.created", "category.deleted"},
+		})
+		// ---------------------------
+	})
+
+	assert.Equal(t, &model.WebhookResponse{
+		Id:         "WH-1",
+		Url:        "https://example.com/hook",
+		EventTypes: []string{"category.created", "category.deleted"},
+		Secret:     "whsec_abcdefghijklmnopqrstuvwxyz012345",
+		CreatedAt:  createdAt,
+	}, result)
+
+	idGenerator.Mock.AssertExpectations(t)
+	webhookSubscriptionRepository.Mock.AssertExpectations(t)
+}
+
+func TestWebhookFindByIdSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	expectTenantBegin(pool)
+	pool.ExpectCommit()
+
+	webhookSubscriptionRepository := internal_repository_mock.NewWebhookSubscriptionRepositoryMock()
+
+	webhookSubscriptionRepository.Mock.On("FindById", mock.Anything, mock.Anything, "WH-1").Return(&entity.WebhookSubscription{
+		Id:         "WH-1",
+		Url:        "https://example.com/hook",
+		Secret:     "whsec_secret",
+		EventTypes: []string{"category.updated"},
+	}).Times(1)
+
+	var result *model.WebhookResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewWebhookUseCaseImpl(pool, nil, nil, webhookSubscriptionRepository, nil).FindById(tenantContext(t), "WH-1")
+		// ---------------------------
+	})
+
+	assert.Equal(t, "WH-1", result.Id)
+	assert.Empty(t, result.Secret, "the secret is only answered on creation")
+
+	webhookSubscriptionRepository.Mock.AssertExpectations(t)
+}
+
+func TestWebhookFindDeliveriesFailed(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	expectTenantBegin(pool)
+	pool.ExpectRollback()
+
+	webhookSubscriptionRepository := internal_repository_mock.NewWebhookSubscriptionRepositoryMock()
+
+	webhookSubscriptionRepository.Mock.On("FindById", mock.Anything, mock.Anything, "WH-404").Panic("webhook is not found").Times(1)
+
+	webhookDeliveryRepository := internal_repository_mock.NewWebhookDeliveryRepositoryMock()
+
+	// Action & Assert
+	assert.PanicsWithValue(t, "webhook is not found", func() {
+		// ---SUT (Subject Under Test)
+		usecase.NewWebhookUseCaseImpl(pool, nil, nil, webhookSubscriptionRepository, webhookDeliveryRepository).FindDeliveries(tenantContext(t), "WH-404")
+		// ---------------------------
+	})
+
+	webhookDeliveryRepository.Mock.AssertNumberOfCalls(t, "FindBySubscriptionId", 0)
+}
+
+func TestWebhookRedeliverFailed(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	expectTenantBegin(pool)
+	pool.ExpectRollback()
+
+	webhookDeliveryRepository := internal_repository_mock.NewWebhookDeliveryRepositoryMock()
+
+	webhookDeliveryRepository.Mock.On("FindById", mock.Anything, mock.Anything, "WH-1", int64(7)).Return(&entity.WebhookDelivery{
+		Id:     7,
+		Status: entity.WebhookDeliveryPending,
+	}).Times(1)
+
+	// Action
+	var errRecover any
+
+	func() {
+		defer func() { errRecover = recover() }()
+
+		// ---SUT (Subject Under Test)
+		usecase.NewWebhookUseCaseImpl(pool, nil, nil, nil, webhookDeliveryRepository).Redeliver(tenantContext(t), "WH-1", 7)
+		// ---------------------------
+	}()
+
+	// Assert
+	errClient, ok := errRecover.(*exception.ErrorClientRequest)
+
+	if assert.True(t, ok) {
+		assert.Equal(t, http.StatusConflict, errClient.GetStatusCode())
+		assert.Equal(t, "webhook delivery is still pending", errClient.GetDetailError())
+	}
+
+	webhookDeliveryRepository.Mock.AssertNumberOfCalls(t, "Update", 0)
+}
+
+func TestWebhookRedeliverSuccess(t *testing.T) {
+	// Arrange
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	expectTenantBegin(pool)
+	pool.ExpectCommit()
+
+	lastStatusCode := http.StatusInternalServerError
+	lastError := "the receiver answered with status 500"
+
+	webhookDeliveryRepository := internal_repository_mock.NewWebhookDeliveryRepositoryMock()
+
+	webhookDeliveryRepository.Mock.On("FindById", mock.Anything, mock.Anything, "WH-1", int64(7)).Return(&entity.WebhookDelivery{
+		Id:             7,
+		EventId:        3,
+		Status:         entity.WebhookDeliveryDead,
+		Attempts:       8,
+		LastStatusCode: &lastStatusCode,
+		LastError:      &lastError,
+	}).Times(1)
+
+	webhookDeliveryRepository.Mock.On("Update", mock.Anything, mock.Anything, mock.MatchedBy(func(delivery *entity.WebhookDelivery) bool {
+		return delivery.Status == entity.WebhookDeliveryPending && delivery.Attempts == 0
+	})).Return(&entity.WebhookDelivery{
+		Id:             7,
+		EventId:        3,
+		Status:         entity.WebhookDeliveryPending,
+		LastStatusCode: &lastStatusCode,
+		LastError:      &lastError,
+	}).Times(1)
+
+	var result *model.WebhookDeliveryResponse
+
+	// Action & Assert
+	assert.NotPanics(t, func() {
+		// ---SUT (Subject Under Test)
+		result = usecase.NewWebhookUseCaseImpl(pool, nil, nil, nil, webhookDeliveryRepository).Redeliver(tenantContext(t), "WH-1", 7)
+		// ---------------------------
+	})
+
+	assert.Equal(t, "pending", result.Status)
+	assert.Equal(t, 0, result.Attempts)
+
+	webhookDeliveryRepository.Mock.AssertExpectations(t)
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+type WebhookUseCase interface {
+	Create(ctx context.Context, requestBody *model.CreateWebhookRequest) *model.WebhookResponse
+	Delete(ctx context.Context, webhookId string)
+	FindById(ctx context.Context, webhookId string) *model.WebhookResponse
+	FindAll(ctx context.Context) []model.WebhookResponse
+	FindDeliveries(ctx context.Context, webhookId string) []model.WebhookDeliveryResponse
+	Redeliver(ctx context.Context, webhookId string, deliveryId int64) *model.WebhookDeliveryResponse
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/exception"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model/converter"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+)
+
+const webhookDeliveriesLimit = 100
+
+type webhookUseCaseImpl struct {
+	DB                            db.PgxPool
+	Validator                     security.Validation
+	IdGenerator                   security.IdGenerator
+	WebhookSubscriptionRepository repository.WebhookSubscriptionRepository
+	WebhookDeliveryRepository     repository.WebhookDeliveryRepository
+}
+
+func NewWebhookUseCaseImpl(db db.PgxPool, validate security.Validation, idGenerator security.IdGenerator, webhookSubscriptionRepository repository.WebhookSubscriptionRepository, webhookDeliveryRepository repository.WebhookDeliveryRepository) WebhookUseCase {
+	return &webhookUseCaseImpl{
+		DB:                            db,
+		Validator:                     validate,
+		IdGenerator:                   idGenerator,
+		WebhookSubscriptionRepository: webhookSubscriptionRepository,
+		WebhookDeliveryRepository:     webhookDeliveryRepository,
+	}
+}
+
+// Create answers with the signing secret of the webhook, it is never shown again
+func (u *webhookUseCaseImpl) Create(ctx context.Context, requestBody *model.CreateWebhookRequest) *model.WebhookResponse {
+	err := u.Validator.Struct(requestBody)
+	helper.PanicIfError(err)
+
+	secret, err := u.IdGenerator.Generate(32)
+	helper.InternalServerPanicIfError(err, "webhook > usecase > Create")
+
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "webhook > usecase > Create")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	subscription := u.WebhookSubscriptionRepository.Save(ctx, tx, &entity.WebhookSubscription{
+		Url:        requestBody.Url,
+		Secret:     "whsec_" + secret,
+		EventTypes: requestBody.EventTypes,
+	})
+
+	webhookResponse := converter.WebhookToResponse(subscription)
+	webhookResponse.Secret = subscription.Secret
+
+	return webhookResponse
+}
+
+func (u *webhookUseCaseImpl) Delete(ctx context.Context, webhookId string) {
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "webhook > usecase > Delete")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	u.WebhookSubscriptionRepository.FindById(ctx, tx, webhookId)
+	u.WebhookSubscriptionRepository.Delete(ctx, tx, webhookId)
+}
+
+func (u *webhookUseCaseImpl) FindById(ctx context.Context, webhookId string) *model.WebhookResponse {
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "webhook > usecase > FindById")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	result := u.WebhookSubscriptionRepository.FindById(ctx, tx, webhookId)
+
+	return converter.WebhookToResponse(result)
+}
+
+func (u *webhookUseCaseImpl) FindAll(ctx context.Context) []model.WebhookResponse {
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "webhook > usecase > FindAll")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	result := u.WebhookSubscriptionRepository.FindAll(ctx, tx)
+
+	return converter.WebhooksToResponse(result)
+}
+
+func (u *webhookUseCaseImpl) FindDeliveries(ctx context.Context, webhookId string) []model.WebhookDeliveryResponse {
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "webhook > usecase > FindDeliveries")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	u.WebhookSubscriptionRepository.FindById(ctx, tx, webhookId)
+
+	result := u.WebhookDeliveryRepository.FindBySubscriptionId(ctx, tx, webhookId, webhookDeliveriesLimit)
+
+	return converter.WebhookDeliveriesToResponse(result)
+}
+
+// Redeliver queues a dead or delivered delivery again with fresh attempts, a
+// pending one is still retried by the dispatcher
+func (u *webhookUseCaseImpl) Redeliver(ctx context.Context, webhookId string, deliveryId int64) *model.WebhookDeliveryResponse {
+	tx, err := beginTenantTx(ctx, u.DB)
+	helper.InternalServerPanicIfError(err, "webhook > usecase > Redeliver")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	delivery := u.WebhookDeliveryRepository.FindById(ctx, tx, webhookId, deliveryId)
+
+	if delivery.Status == entity.WebhookDeliveryPending {
+		panic(exception.NewErrorClientRequest(errors.New("webhook delivery is pending"), http.StatusConflict, "webhook delivery is still pending"))
+	}
+
+	delivery.Status = entity.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.DeliveredAt = nil
+
+	delivery = u.WebhookDeliveryRepository.Update(ctx, tx, delivery)
+
+	return converter.WebhookDeliveryToResponse(delivery)
+}
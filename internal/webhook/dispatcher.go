@@ -0,0 +1,9 @@
+package webhook
+
+import "context"
+
+type Dispatcher interface {
+	Run(ctx context.Context)
+	DispatchOnce(ctx context.Context) int
+	PruneOnce(ctx context.Context) int
+}
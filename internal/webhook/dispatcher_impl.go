@@ -0,0 +1,292 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/metrics"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
+)
+
+// pruneInterval is how often the events past the retention are deleted
+const pruneInterval = time.Hour
+
+type dispatcherImpl struct {
+	AppConfig                 *config.AppConfig
+	DB                        db.PgxPool
+	Logger                    *logrus.Logger
+	OutboxEventRepository     repository.OutboxEventRepository
+	WebhookDeliveryRepository repository.WebhookDeliveryRepository
+	Client                    *http.Client
+}
+
+func NewDispatcherImpl(appConfig *config.AppConfig, database db.PgxPool, logger *logrus.Logger, outboxEventRepository repository.OutboxEventRepository, webhookDeliveryRepository repository.WebhookDeliveryRepository) Dispatcher {
+	return &dispatcherImpl{
+		AppConfig:                 appConfig,
+		DB:                        database,
+		Logger:                    logger,
+		OutboxEventRepository:     outboxEventRepository,
+		WebhookDeliveryRepository: webhookDeliveryRepository,
+		Client: &http.Client{
+			Timeout: appConfig.Webhook.Timeout * time.Second,
+			// A redirect is answered as a failed delivery, the secret is never
+			// sent to another URL than the subscribed one
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Run polls the outbox every poll interval and prunes it at the start and
+// every prune interval until the context is done
+func (d *dispatcherImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.AppConfig.Webhook.PollInterval * time.Second)
+	defer ticker.Stop()
+
+	pruneTicker := time.NewTicker(pruneInterval)
+	defer pruneTicker.Stop()
+
+	d.prune(ctx)
+	d.poll(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.poll(ctx)
+		case <-pruneTicker.C:
+			d.prune(ctx)
+		}
+	}
+}
+
+func (d *dispatcherImpl) poll(ctx context.Context) {
+	defer func() {
+		if errRecover := recover(); errRecover != nil {
+			d.Logger.WithField("error", errRecover).Error("the webhook dispatcher failed to poll the outbox")
+		}
+	}()
+
+	d.DispatchOnce(ctx)
+}
+
+func (d *dispatcherImpl) prune(ctx context.Context) {
+	defer func() {
+		if errRecover := recover(); errRecover != nil {
+			d.Logger.WithField("error", errRecover).Error("the webhook dispatcher failed to prune the outbox")
+		}
+	}()
+
+	d.PruneOnce(ctx)
+}
+
+// DispatchOnce queues the deliveries of the new outbox events, then sends the
+// due deliveries concurrently and reports how many of them it sent
+func (d *dispatcherImpl) DispatchOnce(ctx context.Context) int {
+	d.fanOut(ctx)
+
+	jobs := d.claimDue(ctx)
+
+	var wg sync.WaitGroup
+
+	for i := range jobs {
+		wg.Add(1)
+
+		go func(job *entity.WebhookDeliveryJob) {
+			defer wg.Done()
+
+			defer func() {
+				if errRecover := recover(); errRecover != nil {
+					d.Logger.WithField("error", errRecover).WithField("delivery_id", job.Id).Error("the webhook dispatcher failed to record a delivery")
+				}
+			}()
+
+			d.deliver(ctx, job)
+		}(&jobs[i])
+	}
+
+	wg.Wait()
+
+	return len(jobs)
+}
+
+// PruneOnce deletes the delivered and dead events older than the retention a
+// batch per transaction, so the locks stay short, and reports how many events
+// it deleted. A zero retention keeps them all.
+func (d *dispatcherImpl) PruneOnce(ctx context.Context) int {
+	retention := d.AppConfig.Webhook.Retention * time.Hour
+
+	if retention == 0 {
+		return 0
+	}
+
+	pruned := 0
+
+	for {
+		batch := d.pruneBatch(ctx, retention)
+		pruned += batch
+
+		if batch < d.AppConfig.Webhook.BatchSize {
+			return pruned
+		}
+	}
+}
+
+func (d *dispatcherImpl) pruneBatch(ctx context.Context, retention time.Duration) int {
+	tx, err := d.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "webhook > dispatcher > pruneBatch")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	return d.OutboxEventRepository.Prune(ctx, tx, retention, d.AppConfig.Webhook.BatchSize)
+}
+
+func (d *dispatcherImpl) fanOut(ctx context.Context) {
+	tx, err := d.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "webhook > dispatcher > fanOut")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	d.OutboxEventRepository.FanOut(ctx, tx, d.AppConfig.Webhook.BatchSize)
+}
+
+func (d *dispatcherImpl) claimDue(ctx context.Context) []entity.WebhookDeliveryJob {
+	tx, err := d.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "webhook > dispatcher > claimDue")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	// The deliveries are sent concurrently, so the lease covers one timeout
+	// with a margin for recording the outcome
+	lease := 2 * d.AppConfig.Webhook.Timeout * time.Second
+
+	return d.WebhookDeliveryRepository.ClaimDue(ctx, tx, d.AppConfig.Webhook.BatchSize, lease)
+}
+
+func (d *dispatcherImpl) deliver(ctx context.Context, job *entity.WebhookDeliveryJob) {
+	statusCode, err := d.send(ctx, job)
+
+	delivery := &job.WebhookDelivery
+	delivery.Attempts++
+
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+
+	var outcome string
+
+	switch {
+	case err == nil:
+		deliveredAt := time.Now()
+
+		delivery.Status = entity.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &deliveredAt
+		delivery.LastError = nil
+
+		outcome = "delivered"
+	case delivery.Attempts >= d.AppConfig.Webhook.MaxAttempts:
+		lastError := err.Error()
+
+		delivery.Status = entity.WebhookDeliveryDead
+		delivery.LastError = &lastError
+
+		outcome = "dead"
+	default:
+		lastError := err.Error()
+
+		delivery.NextAttemptAt = time.Now().Add(d.backoff(delivery.Attempts))
+		delivery.LastError = &lastError
+
+		outcome = "retried"
+	}
+
+	if err != nil {
+		d.Logger.WithError(err).WithFields(logrus.Fields{
+			"delivery_id": delivery.Id,
+			"event_id":    delivery.EventId,
+			"attempts":    delivery.Attempts,
+			"outcome":     outcome,
+		}).Warn("the webhook delivery failed")
+	}
+
+	tx, err := d.DB.Begin(ctx)
+	helper.InternalServerPanicIfError(err, "webhook > dispatcher > deliver")
+
+	defer helper.TxCommitRollback(ctx, tx)
+
+	d.WebhookDeliveryRepository.Update(ctx, tx, delivery)
+
+	metrics.WebhookDeliveriesTotal.WithLabelValues(outcome).Inc()
+}
+
+// send reports the status code of the receiver, or 0 when there is no
+// response, and an error unless the receiver answered with 2xx
+func (d *dispatcherImpl) send(ctx context.Context, job *entity.WebhookDeliveryJob) (int, error) {
+	body, err := json.Marshal(&model.WebhookEvent{
+		Id:        job.EventId,
+		Type:      job.EventType,
+		CreatedAt: job.EventAt,
+		Data:      job.Payload,
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, job.Url, bytes.NewReader(body))
+
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("content-type", "application/json")
+	request.Header.Set("user-agent", "pzn-golang-restful-api-webhook")
+	request.Header.Set("webhook-id", strconv.FormatInt(job.EventId, 10))
+	request.Header.Set("webhook-delivery", strconv.FormatInt(job.Id, 10))
+	request.Header.Set("webhook-event", job.EventType)
+	request.Header.Set(SignatureHeader, Sign(job.Secret, time.Now(), body))
+
+	response, err := d.Client.Do(request)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+
+	// The body is drained, so the connection is reused
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("the receiver answered with status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// backoff doubles the base delay after every failed attempt up to the max
+func (d *dispatcherImpl) backoff(attempts int) time.Duration {
+	backoff := d.AppConfig.Webhook.BackoffBase * time.Second
+	maxBackoff := d.AppConfig.Webhook.BackoffMax * time.Second
+
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const SignatureHeader = "Webhook-Signature"
+
+// Sign signs the timestamp and the body with the secret of the subscription,
+// the value is "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">"
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	return fmt.Sprintf("t=%s,v1=%s", unix, signature(secret, unix, body))
+}
+
+// Verify checks a signature made by Sign, the timestamp must be within the
+// tolerance of now so a captured request can not be replayed later
+func Verify(secret string, signatureValue string, body []byte, tolerance time.Duration, now time.Time) error {
+	var unix, signed string
+
+	for _, part := range strings.Split(signatureValue, ",") {
+		key, value, _ := strings.Cut(part, "=")
+
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			signed = value
+		}
+	}
+
+	timestamp, err := strconv.ParseInt(unix, 10, 64)
+
+	if err != nil || signed == "" {
+		return errors.New("malformed webhook signature")
+	}
+
+	if age := now.Sub(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return errors.New("webhook signature timestamp is outside of the tolerance")
+	}
+
+	if !hmac.Equal([]byte(signed), []byte(signature(secret, unix, body))) {
+		return errors.New("webhook signature does not match")
+	}
+
+	return nil
+}
+
+func signature(secret string, unix string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
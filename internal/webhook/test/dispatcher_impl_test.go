@@ -0,0 +1,282 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	internal_repository_mock "github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository/mock"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/webhook"
+)
+
+var appTestConfig = &config.AppConfig{
+	Webhook: &config.Webhook{
+		PollInterval: 1,
+		BatchSize:    10,
+		Timeout:      2,
+		MaxAttempts:  3,
+		BackoffBase:  10,
+		BackoffMax:   15,
+		Retention:    168,
+	},
+}
+
+var logger = func() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}()
+
+func newDeliveryJob(url string, attempts int) entity.WebhookDeliveryJob {
+	return entity.WebhookDeliveryJob{
+		WebhookDelivery: entity.WebhookDelivery{
+			Id:             7,
+			EventId:        3,
+			SubscriptionId: "WH-1",
+			Status:         entity.WebhookDeliveryPending,
+			Attempts:       attempts,
+		},
+		EventType: entity.EventCategoryCreated,
+		Payload:   []byte(`{"id":"CAT-1","name":"Gadget"}`),
+		EventAt:   time.Date(2026, time.October, 19, 13, 0, 0, 0, time.UTC),
+		Url:       url,
+		Secret:    "whsec_secret",
+	}
+}
+
+// dispatchOnce runs one poll with a single due delivery and reports the
+// delivery the dispatcher records
+func dispatchOnce(t *testing.T, job entity.WebhookDeliveryJob) *entity.WebhookDelivery {
+	pool, err := pgxmock.NewPool()
+	helper.PanicIfError(err)
+
+	defer pool.Close()
+
+	// Fan out, claim and record the outcome
+	for range 3 {
+		pool.ExpectBegin()
+		pool.ExpectCommit()
+	}
+
+	outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+
+	outboxEventRepository.Mock.On("FanOut", mock.Anything, mock.Anything, 10).Return(1).Times(1)
+
+	webhookDeliveryRepository := internal_repository_mock.NewWebhookDeliveryRepositoryMock()
+
+	webhookDeliveryRepository.Mock.On("ClaimDue", mock.Anything, mock.Anything, 10, 4*time.Second).Return([]entity.WebhookDeliveryJob{job}).Times(1)
+
+	var recorded *entity.WebhookDelivery
+
+	webhookDeliveryRepository.Mock.On("Update", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(2).(*entity.WebhookDelivery)
+	}).Return(new(entity.WebhookDelivery)).Times(1)
+
+	// ---SUT (Subject Under Test)
+	sent := webhook.NewDispatcherImpl(appTestConfig, pool, logger, outboxEventRepository, webhookDeliveryRepository).DispatchOnce(t.Context())
+	// ---------------------------
+
+	assert.Equal(t, 1, sent)
+	assert.NoError(t, pool.ExpectationsWereMet())
+
+	outboxEventRepository.Mock.AssertExpectations(t)
+	webhookDeliveryRepository.Mock.AssertExpectations(t)
+
+	return recorded
+}
+
+func TestDispatchDelivered(t *testing.T) {
+	// Arrange
+	var receivedEvent *model.WebhookEvent
+	var receivedHeader http.Header
+	var verifyErr error
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		helper.PanicIfError(err)
+
+		receivedHeader = r.Header
+		verifyErr = webhook.Verify("whsec_secret", r.Header.Get(webhook.SignatureHeader), body, 5*time.Minute, time.Now())
+
+		receivedEvent = new(model.WebhookEvent)
+
+		err = json.Unmarshal(body, receivedEvent)
+		helper.PanicIfError(err)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	// Action
+	delivery := dispatchOnce(t, newDeliveryJob(receiver.URL, 0))
+
+	// Assert
+	assert.NoError(t, verifyErr)
+	assert.Equal(t, "application/json", receivedHeader.Get("content-type"))
+	assert.Equal(t, "3", receivedHeader.Get("webhook-id"))
+	assert.Equal(t, "7", receivedHeader.Get("webhook-delivery"))
+	assert.Equal(t, "category.created", receivedHeader.Get("webhook-event"))
+
+	assert.Equal(t, int64(3), receivedEvent.Id)
+	assert.Equal(t, "category.created", receivedEvent.Type)
+	assert.JSONEq(t, `{"id":"CAT-1","name":"Gadget"}`, string(receivedEvent.Data))
+
+	assert.Equal(t, entity.WebhookDeliveryDelivered, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, *delivery.LastStatusCode)
+	assert.Nil(t, delivery.LastError)
+	assert.NotNil(t, delivery.DeliveredAt)
+}
+
+func TestDispatchFailed(t *testing.T) {
+	t.Run("Retried With Backoff", func(t *testing.T) {
+		// Arrange
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+
+		// Action
+		delivery := dispatchOnce(t, newDeliveryJob(receiver.URL, 0))
+
+		// Assert
+		assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusServiceUnavailable, *delivery.LastStatusCode)
+		assert.Equal(t, "the receiver answered with status 503", *delivery.LastError)
+		assert.WithinDuration(t, time.Now().Add(10*time.Second), delivery.NextAttemptAt, time.Second)
+		assert.Nil(t, delivery.DeliveredAt)
+	})
+
+	t.Run("Backoff Is Capped", func(t *testing.T) {
+		// Arrange
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer receiver.Close()
+
+		// Action
+		delivery := dispatchOnce(t, newDeliveryJob(receiver.URL, 1))
+
+		// Assert
+		assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.WithinDuration(t, time.Now().Add(15*time.Second), delivery.NextAttemptAt, time.Second)
+	})
+
+	t.Run("Redirect Is Not Followed", func(t *testing.T) {
+		// Arrange
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://example.com/", http.StatusFound)
+		}))
+		defer receiver.Close()
+
+		// Action
+		delivery := dispatchOnce(t, newDeliveryJob(receiver.URL, 0))
+
+		// Assert
+		assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, http.StatusFound, *delivery.LastStatusCode)
+	})
+
+	t.Run("Unreachable Receiver", func(t *testing.T) {
+		// Arrange
+		receiver := httptest.NewServer(http.NotFoundHandler())
+		receiver.Close()
+
+		// Action
+		delivery := dispatchOnce(t, newDeliveryJob(receiver.URL, 0))
+
+		// Assert
+		assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status)
+		assert.Nil(t, delivery.LastStatusCode)
+		assert.Contains(t, *delivery.LastError, "connection refused")
+	})
+
+	t.Run("Dead After The Last Attempt", func(t *testing.T) {
+		// Arrange
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer receiver.Close()
+
+		// Action
+		delivery := dispatchOnce(t, newDeliveryJob(receiver.URL, 2))
+
+		// Assert
+		assert.Equal(t, entity.WebhookDeliveryDead, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Equal(t, "the receiver answered with status 400", *delivery.LastError)
+	})
+}
+
+func TestPruneOnce(t *testing.T) {
+	t.Run("Pruned In Batches", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		for range 2 {
+			pool.ExpectBegin()
+			pool.ExpectCommit()
+		}
+
+		outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+
+		outboxEventRepository.Mock.On("Prune", mock.Anything, mock.Anything, 168*time.Hour, 10).Return(10).Once()
+		outboxEventRepository.Mock.On("Prune", mock.Anything, mock.Anything, 168*time.Hour, 10).Return(3).Once()
+
+		webhookDeliveryRepository := internal_repository_mock.NewWebhookDeliveryRepositoryMock()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		pruned := webhook.NewDispatcherImpl(appTestConfig, pool, logger, outboxEventRepository, webhookDeliveryRepository).PruneOnce(t.Context())
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, 13, pruned)
+		assert.NoError(t, pool.ExpectationsWereMet())
+
+		outboxEventRepository.Mock.AssertExpectations(t)
+	})
+
+	t.Run("Zero Retention Keeps The Events", func(t *testing.T) {
+		// Arrange
+		pool, err := pgxmock.NewPool()
+		helper.PanicIfError(err)
+
+		defer pool.Close()
+
+		appConfig := *appTestConfig
+		webhookConfig := *appTestConfig.Webhook
+		webhookConfig.Retention = 0
+		appConfig.Webhook = &webhookConfig
+
+		outboxEventRepository := internal_repository_mock.NewOutboxEventRepositoryMock()
+		webhookDeliveryRepository := internal_repository_mock.NewWebhookDeliveryRepositoryMock()
+
+		// Action
+		// ---SUT (Subject Under Test)
+		pruned := webhook.NewDispatcherImpl(&appConfig, pool, logger, outboxEventRepository, webhookDeliveryRepository).PruneOnce(t.Context())
+		// ---------------------------
+
+		// Assert
+		assert.Equal(t, 0, pruned)
+		assert.NoError(t, pool.ExpectationsWereMet())
+
+		outboxEventRepository.Mock.AssertNotCalled(t, "Prune", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
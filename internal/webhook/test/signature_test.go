@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/webhook"
+)
+
+var signedAt = time.Date(2026, time.October, 19, 13, 0, 0, 0, time.UTC)
+
+func TestSign(t *testing.T) {
+	// Arrange & Action
+	// ---SUT (Subject Under Test)
+	signature := webhook.Sign("whsec_secret", signedAt, []byte(`{"id":1}`))
+	// ---------------------------
+
+	// Assert
+	assert.Regexp(t, `^t=1792414800,v1=[0-9a-f]{64}$`, signature)
+	assert.NoError(t, webhook.Verify("whsec_secret", signature, []byte(`{"id":1}`), 5*time.Minute, signedAt.Add(time.Minute)))
+}
+
+func TestVerifyFailed(t *testing.T) {
+	signature := webhook.Sign("whsec_secret", signedAt, []byte(`{"id":1}`))
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		body      string
+		now       time.Time
+		message   string
+	}{
+		{name: "Tampered Body", secret: "whsec_secret", signature: signature, body: `{"id":2}`, now: signedAt, message: "webhook signature does not match"},
+		{name: "Another Secret", secret: "whsec_another", signature: signature, body: `{"id":1}`, now: signedAt, message: "webhook signature does not match"},
+		{name: "Stale Timestamp", secret: "whsec_secret", signature: signature, body: `{"id":1}`, now: signedAt.Add(6 * time.Minute), message: "webhook signature timestamp is outside of the tolerance"},
+		{name: "Malformed Signature", secret: "whsec_secret", signature: "v1=abc", body: `{"id":1}`, now: signedAt, message: "malformed webhook signature"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Action
+			// ---SUT (Subject Under Test)
+			err := webhook.Verify(test.secret, test.signature, []byte(test.body), 5*time.Minute, test.now)
+			// ---------------------------
+
+			// Assert
+			assert.EqualError(t, err, test.message)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package signing
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/pkg/signing"
+)
+
+var signedAt = time.Date(2026, time.October, 19, 13, 0, 0, 0, time.UTC)
+
+func signedHeader() http.Header {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
+	return header
+}
+
+func TestSign(t *testing.T) {
+	// Arrange & Action
+	// ---SUT (Subject Under Test)
+	signature := signing.Sign("sig_secret", http.MethodPost, "/api/v2/categories", signedHeader(), []string{"Content-Type"}, []byte(`{"name":"Category 1"}`), signedAt, "nonce-1")
+	// ---------------------------
+
+	// Assert
+	assert.Regexp(t, `^t=1792414800,nonce=nonce-1,headers=content-type,v1=[0-9a-f]{64}$`, signature)
+
+	parsed, err := signing.Verify("sig_secret", http.MethodPost, "/api/v2/categories", signedHeader(), []byte(`{"name":"Category 1"}`), signature, 5*time.Minute, signedAt.Add(time.Minute))
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "nonce-1", parsed.Nonce)
+		assert.Equal(t, []string{"content-type"}, parsed.Headers)
+		assert.True(t, parsed.SignsHeaders([]string{"Content-Type"}))
+		assert.False(t, parsed.SignsHeaders([]string{"Content-Type", "X-Request-ID"}))
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodPut, "/api/v2/categories/CAT-1?dry_run=true", strings.NewReader(`{"name":"Category 1"}`))
+	testRequest.Header.Set("Content-Type", "application/json")
+
+	// Action
+	// ---SUT (Subject Under Test)
+	err := signing.SignRequest(testRequest, "sig_secret", "Content-Type")
+	// ---------------------------
+
+	// Assert
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(testRequest.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"Category 1"}`, string(body))
+
+	_, err = signing.Verify("sig_secret", http.MethodPut, "/api/v2/categories/CAT-1?dry_run=true", testRequest.Header, body, testRequest.Header.Get(signing.SignatureHeader), 5*time.Minute, time.Now())
+	assert.NoError(t, err)
+}
+
+func TestVerifyFailed(t *testing.T) {
+	signature := signing.Sign("sig_secret", http.MethodPost, "/api/v2/categories", signedHeader(), []string{"Content-Type"}, []byte(`{"name":"Category 1"}`), signedAt, "nonce-1")
+
+	tamperedHeader := http.Header{}
+	tamperedHeader.Set("Content-Type", "text/plain")
+
+	tests := []struct {
+		name      string
+		secret    string
+		method    string
+		target    string
+		header    http.Header
+		body      string
+		signature string
+		now       time.Time
+		message   string
+	}{
+		{name: "Tampered Body", secret: "sig_secret", method: http.MethodPost, target: "/api/v2/categories", header: signedHeader(), body: `{"name":"Category 2"}`, signature: signature, now: signedAt, message: "request signature does not match"},
+		{name: "Tampered Method", secret: "sig_secret", method: http.MethodPut, target: "/api/v2/categories", header: signedHeader(), body: `{"name":"Category 1"}`, signature: signature, now: signedAt, message: "request signature does not match"},
+		{name: "Tampered Path", secret: "sig_secret", method: http.MethodPost, target: "/api/v2/webhooks", header: signedHeader(), body: `{"name":"Category 1"}`, signature: signature, now: signedAt, message: "request signature does not match"},
+		{name: "Tampered Header", secret: "sig_secret", method: http.MethodPost, target: "/api/v2/categories", header: tamperedHeader, body: `{"name":"Category 1"}`, signature: signature, now: signedAt, message: "request signature does not match"},
+		{name: "Another Secret", secret: "sig_another", method: http.MethodPost, target: "/api/v2/categories", header: signedHeader(), body: `{"name":"Category 1"}`, signature: signature, now: signedAt, message: "request signature does not match"},
+		{name: "Stale Timestamp", secret: "sig_secret", method: http.MethodPost, target: "/api/v2/categories", header: signedHeader(), body: `{"name":"Category 1"}`, signature: signature, now: signedAt.Add(6 * time.Minute), message: "request signature timestamp is outside of the clock skew"},
+		{name: "Future Timestamp", secret: "sig_secret", method: http.MethodPost, target: "/api/v2/categories", header: signedHeader(), body: `{"name":"Category 1"}`, signature: signature, now: signedAt.Add(-6 * time.Minute), message: "request signature timestamp is outside of the clock skew"},
+		{name: "Malformed Signature Without Nonce", secret: "sig_secret", method: http.MethodPost, target: "/api/v2/categories", header: signedHeader(), body: `{"name":"Category 1"}`, signature: "t=1792414800,v1=abc", now: signedAt, message: "malformed request signature"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Action
+			// ---SUT (Subject Under Test)
+			_, err := signing.Verify(test.secret, test.method, test.target, test.header, []byte(test.body), test.signature, 5*time.Minute, test.now)
+			// ---------------------------
+
+			// Assert
+			assert.EqualError(t, err, test.message)
+		})
+	}
+}
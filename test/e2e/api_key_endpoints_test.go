@@ -0,0 +1,94 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/test/helper"
+)
+
+var apiKeysDbTableHelper = helper.NewApiKeysDbTable(
+	config.NewAppConfig(configPath),
+)
+
+func serveApiKeyTestRequest(t *testing.T, apiKey string, method string, path string, requestBody string) *http.Response {
+	testRequest := httptest.NewRequest(method, fmt.Sprintf("%s%s", baseUrl, path), strings.NewReader(requestBody))
+
+	testRequest.Header.Set("X-API-Key", apiKey)
+
+	if requestBody != "" {
+		testRequest.Header.Set("content-type", "application/json")
+	}
+
+	recorder := httptest.NewRecorder()
+
+	setupMiddleware(t, appTestConfig).ServeHTTP(recorder, testRequest)
+
+	return recorder.Result()
+}
+
+func TestApiKeyScopes(t *testing.T) {
+	// Arrange
+	apiKeysDbTableHelper.DeleteAll()
+	defer apiKeysDbTableHelper.DeleteAll()
+	defer categoriesDbTableHelper.DeleteAll()
+
+	createResponse := serveApiKeyTestRequest(t, "test_key", http.MethodPost, "/api/v2/api-keys", `{"name":"reader","scopes":["categories:read"]}`)
+
+	assert.Equal(t, http.StatusCreated, createResponse.StatusCode)
+
+	apiKey := decodeWebhookTestResponse[*model.ApiKeyResponse](createResponse).Data
+
+	// Action & Assert
+	readResponse := serveApiKeyTestRequest(t, apiKey.Key, http.MethodGet, "/api/v2/categories", "")
+	assert.Equal(t, http.StatusOK, readResponse.StatusCode)
+
+	writeResponse := serveApiKeyTestRequest(t, apiKey.Key, http.MethodPost, "/api/v2/categories", `{"name":"Fashions"}`)
+	assert.Equal(t, http.StatusForbidden, writeResponse.StatusCode)
+
+	adminResponse := serveApiKeyTestRequest(t, apiKey.Key, http.MethodGet, "/api/v2/api-keys", "")
+	assert.Equal(t, http.StatusForbidden, adminResponse.StatusCode)
+}
+
+func TestApiKeyRotateAndRevoke(t *testing.T) {
+	// Arrange
+	apiKeysDbTableHelper.DeleteAll()
+	defer apiKeysDbTableHelper.DeleteAll()
+
+	createResponse := serveApiKeyTestRequest(t, "test_key", http.MethodPost, "/api/v2/api-keys", `{"name":"writer","scopes":["categories:read","categories:write"]}`)
+	apiKey := decodeWebhookTestResponse[*model.ApiKeyResponse](createResponse).Data
+
+	// Action & Assert
+	rotateResponse := serveApiKeyTestRequest(t, "test_key", http.MethodPost, "/api/v2/api-keys/"+apiKey.Id+"/rotate", "")
+	assert.Equal(t, http.StatusOK, rotateResponse.StatusCode)
+
+	rotatedKey := decodeWebhookTestResponse[*model.ApiKeyResponse](rotateResponse).Data
+	assert.NotEqual(t, apiKey.Key, rotatedKey.Key)
+
+	assert.Equal(t, http.StatusUnauthorized, serveApiKeyTestRequest(t, apiKey.Key, http.MethodGet, "/api/v2/categories", "").StatusCode)
+	assert.Equal(t, http.StatusOK, serveApiKeyTestRequest(t, rotatedKey.Key, http.MethodGet, "/api/v2/categories", "").StatusCode)
+
+	listResponse := serveApiKeyTestRequest(t, "test_key", http.MethodGet, "/api/v2/api-keys", "")
+	assert.Equal(t, http.StatusOK, listResponse.StatusCode)
+
+	apiKeys := decodeWebhookTestResponse[[]model.ApiKeyResponse](listResponse).Data
+
+	if assert.Len(t, apiKeys, 1) {
+		assert.Equal(t, rotatedKey.Prefix, apiKeys[0].Prefix)
+		assert.NotNil(t, apiKeys[0].LastUsedAt)
+		assert.Empty(t, apiKeys[0].Key)
+	}
+
+	revokeResponse := serveApiKeyTestRequest(t, "test_key", http.MethodDelete, "/api/v2/api-keys/"+apiKey.Id, "")
+	assert.Equal(t, http.StatusOK, revokeResponse.StatusCode)
+
+	assert.Equal(t, http.StatusUnauthorized, serveApiKeyTestRequest(t, rotatedKey.Key, http.MethodGet, "/api/v2/categories", "").StatusCode)
+
+	assert.Equal(t, http.StatusConflict, serveApiKeyTestRequest(t, "test_key", http.MethodPost, "/api/v2/api-keys/"+apiKey.Id+"/rotate", "").StatusCode)
+}
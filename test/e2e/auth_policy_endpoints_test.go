@@ -0,0 +1,108 @@
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	internal_helper "github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+func setupPublicTenantTestConfig() *config.AppConfig {
+	serverConfig := *appTestConfig.Server
+	serverConfig.PublicTenant = "default"
+
+	publicConfig := *appTestConfig
+	publicConfig.Server = &serverConfig
+
+	return &publicConfig
+}
+
+func TestPublicRoutesWithoutCredentials(t *testing.T) {
+	for _, path := range []string{"/healthz", "/api/v2/openapi.json", "/api/v2/docs"} {
+		t.Run(path, func(t *testing.T) {
+			// Arrange
+			testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", baseUrl, path), nil)
+
+			recorder := httptest.NewRecorder()
+
+			middlewareTesting := setupMiddleware(t, appTestConfig)
+
+			// Action
+			middlewareTesting.ServeHTTP(recorder, testRequest)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+		})
+	}
+}
+
+func TestPublicCategoryReads(t *testing.T) {
+	defer categoriesDbTableHelper.DeleteAll()
+
+	categoriesDbTableHelper.Add(&entity.Category{Id: "CAT-1", Name: "Category 1"})
+
+	publicConfig := setupPublicTenantTestConfig()
+
+	t.Run("200 - Read Without Credentials", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v2/categories/CAT-1", baseUrl), nil)
+
+		recorder := httptest.NewRecorder()
+
+		middlewareTesting := setupMiddleware(t, publicConfig)
+
+		// Action
+		middlewareTesting.ServeHTTP(recorder, testRequest)
+
+		// Assert
+		recorderResponse := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+
+		responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+		internal_helper.LogStdPanicIfError(err)
+
+		webResponse := new(model.WebResponse[*model.CategoryResponse])
+
+		err = json.Unmarshal(responseBodyBytes, webResponse)
+		internal_helper.LogStdPanicIfError(err)
+
+		assert.Equal(t, "Category 1", webResponse.Data.Name)
+	})
+
+	t.Run("401 - Write Without Credentials", func(t *testing.T) {
+		// Arrange
+		testRequest := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v2/categories", baseUrl), strings.NewReader(`{"name":"Category 2"}`))
+
+		recorder := httptest.NewRecorder()
+
+		middlewareTesting := setupMiddleware(t, publicConfig)
+
+		// Action
+		middlewareTesting.ServeHTTP(recorder, testRequest)
+
+		// Assert
+		recorderResponse := recorder.Result()
+
+		assert.Equal(t, http.StatusUnauthorized, recorderResponse.StatusCode)
+
+		responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+		internal_helper.LogStdPanicIfError(err)
+
+		webResponse := new(model.WebResponseMessage)
+
+		err = json.Unmarshal(responseBodyBytes, webResponse)
+		internal_helper.LogStdPanicIfError(err)
+
+		assert.Equal(t, "unauthorized", webResponse.Message)
+	})
+}
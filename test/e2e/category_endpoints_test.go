@@ -32,6 +32,10 @@ var categoriesDbTableHelper = helper.NewCategoriesDbTable(
 	config.NewAppConfig(configPath),
 )
 
+var idempotencyKeysDbTableHelper = helper.NewIdempotencyKeysDbTable(
+	config.NewAppConfig(configPath),
+)
+
 func TestUnauthorized(t *testing.T) {
 	// Arrange
 
@@ -40,7 +44,7 @@ func TestUnauthorized(t *testing.T) {
 
 	recorder := httptest.NewRecorder()
 
-	middlewareTesting := setupMiddleware(appTestConfig)
+	middlewareTesting := setupMiddleware(t, appTestConfig)
 
 	// Action
 	middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -77,7 +81,7 @@ func TestCreateFailed(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		middlewareTesting := setupMiddleware(appTestConfig)
+		middlewareTesting := setupMiddleware(t, appTestConfig)
 
 		// Action
 		middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -100,6 +104,80 @@ func TestCreateFailed(t *testing.T) {
 		assert.Equal(t, "BAD REQUEST", webResponse.Status)
 	})
 
+	t.Run("413 - Request Body Too Large", func(t *testing.T) {
+		// Arrange
+		defer categoriesDbTableHelper.DeleteAll()
+
+		requestBody := strings.NewReader(`{"name":"` + strings.Repeat("a", int(appTestConfig.Request.MaxBodySize)) + `"}`)
+
+		testRequest := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v2/categories", baseUrl), requestBody)
+
+		testRequest.Header.Set("X-API-Key", "test_key")
+		testRequest.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+
+		middlewareTesting := setupMiddleware(t, appTestConfig)
+
+		// Action
+		middlewareTesting.ServeHTTP(recorder, testRequest)
+
+		// Assert
+		recorderResponse := recorder.Result()
+
+		assert.Equal(t, "application/json", recorderResponse.Header.Get("content-type"))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorderResponse.StatusCode)
+
+		responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+		internal_helper.LogStdPanicIfError(err)
+
+		webResponse := new(model.WebResponseMessage)
+
+		err = json.Unmarshal(responseBodyBytes, webResponse)
+		internal_helper.LogStdPanicIfError(err)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, webResponse.Code)
+		assert.Equal(t, "REQUEST ENTITY TOO LARGE", webResponse.Status)
+		assert.Equal(t, fmt.Sprintf("request body must not be larger than %d bytes", appTestConfig.Request.MaxBodySize), webResponse.Message)
+	})
+
+	t.Run("415 - Unsupported Content Type", func(t *testing.T) {
+		// Arrange
+		defer categoriesDbTableHelper.DeleteAll()
+
+		requestBody := strings.NewReader("name=Gadget")
+
+		testRequest := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v2/categories", baseUrl), requestBody)
+
+		testRequest.Header.Set("X-API-Key", "test_key")
+		testRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		recorder := httptest.NewRecorder()
+
+		middlewareTesting := setupMiddleware(t, appTestConfig)
+
+		// Action
+		middlewareTesting.ServeHTTP(recorder, testRequest)
+
+		// Assert
+		recorderResponse := recorder.Result()
+
+		assert.Equal(t, "application/json", recorderResponse.Header.Get("content-type"))
+		assert.Equal(t, http.StatusUnsupportedMediaType, recorderResponse.StatusCode)
+
+		responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+		internal_helper.LogStdPanicIfError(err)
+
+		webResponse := new(model.WebResponseMessage)
+
+		err = json.Unmarshal(responseBodyBytes, webResponse)
+		internal_helper.LogStdPanicIfError(err)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, webResponse.Code)
+		assert.Equal(t, "UNSUPPORTED MEDIA TYPE", webResponse.Status)
+		assert.Equal(t, "content type must be application/json", webResponse.Message)
+	})
+
 	t.Run("400 - Field Name - Chars Min", func(t *testing.T) {
 		// Arrange
 		defer categoriesDbTableHelper.DeleteAll()
@@ -112,7 +190,7 @@ func TestCreateFailed(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		middlewareTesting := setupMiddleware(appTestConfig)
+		middlewareTesting := setupMiddleware(t, appTestConfig)
 
 		// Action
 		middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -148,7 +226,7 @@ func TestCreateSuccess(t *testing.T) {
 
 	recorder := httptest.NewRecorder()
 
-	middlewareTesting := setupMiddleware(appTestConfig)
+	middlewareTesting := setupMiddleware(t, appTestConfig)
 
 	// Action
 	middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -173,6 +251,53 @@ func TestCreateSuccess(t *testing.T) {
 	assert.Equal(t, "Fashions", webResponse.Data.Name)
 }
 
+func TestCreateIdempotent(t *testing.T) {
+	// Arrange
+	defer categoriesDbTableHelper.DeleteAll()
+	defer idempotencyKeysDbTableHelper.DeleteAll()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	create := func(name string) *http.Response {
+		testRequest := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v2/categories", baseUrl), strings.NewReader(fmt.Sprintf(`{"name":"%s"}`, name)))
+
+		testRequest.Header.Set("X-API-Key", "test_key")
+		testRequest.Header.Set("Idempotency-Key", "create-fashions-1")
+
+		recorder := httptest.NewRecorder()
+
+		middlewareTesting.ServeHTTP(recorder, testRequest)
+
+		return recorder.Result()
+	}
+
+	readCategory := func(response *http.Response) *model.CategoryResponse {
+		webResponse := new(model.WebResponse[*model.CategoryResponse])
+
+		err := json.NewDecoder(response.Body).Decode(webResponse)
+		internal_helper.LogStdPanicIfError(err)
+
+		return webResponse.Data
+	}
+
+	// Action
+	firstResponse := create("Fashions")
+	retryResponse := create("Fashions")
+	conflictResponse := create("Foods")
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, firstResponse.StatusCode)
+	assert.Empty(t, firstResponse.Header.Get("Idempotent-Replayed"))
+
+	assert.Equal(t, http.StatusCreated, retryResponse.StatusCode)
+	assert.Equal(t, "true", retryResponse.Header.Get("Idempotent-Replayed"))
+	assert.Equal(t, readCategory(firstResponse), readCategory(retryResponse))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, conflictResponse.StatusCode)
+
+	assert.Len(t, categoriesDbTableHelper.FindAll(), 1)
+}
+
 func TestUpdateFailed(t *testing.T) {
 	t.Run("400 - Malformed Request Body", func(t *testing.T) {
 		// Arrange
@@ -186,7 +311,7 @@ func TestUpdateFailed(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		middlewareTesting := setupMiddleware(appTestConfig)
+		middlewareTesting := setupMiddleware(t, appTestConfig)
 
 		// Action
 		middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -221,7 +346,7 @@ func TestUpdateFailed(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		middlewareTesting := setupMiddleware(appTestConfig)
+		middlewareTesting := setupMiddleware(t, appTestConfig)
 
 		// Action
 		middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -257,7 +382,7 @@ func TestUpdateFailed(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		middlewareTesting := setupMiddleware(appTestConfig)
+		middlewareTesting := setupMiddleware(t, appTestConfig)
 
 		// Action
 		middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -300,7 +425,7 @@ func TestUpdateSuccess(t *testing.T) {
 
 	recorder := httptest.NewRecorder()
 
-	middlewareTesting := setupMiddleware(appTestConfig)
+	middlewareTesting := setupMiddleware(t, appTestConfig)
 
 	// Action
 	middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -336,7 +461,7 @@ func TestDeleteFailed(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		middlewareTesting := setupMiddleware(appTestConfig)
+		middlewareTesting := setupMiddleware(t, appTestConfig)
 
 		// Action
 		middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -378,7 +503,7 @@ func TestDeleteSuccess(t *testing.T) {
 
 	recorder := httptest.NewRecorder()
 
-	middlewareTesting := setupMiddleware(appTestConfig)
+	middlewareTesting := setupMiddleware(t, appTestConfig)
 
 	// Action
 	middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -415,7 +540,7 @@ func TestFindByIdFailed(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		middlewareTesting := setupMiddleware(appTestConfig)
+		middlewareTesting := setupMiddleware(t, appTestConfig)
 
 		// Action
 		middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -457,7 +582,7 @@ func TestFindByIdSuccess(t *testing.T) {
 
 	recorder := httptest.NewRecorder()
 
-	middlewareTesting := setupMiddleware(appTestConfig)
+	middlewareTesting := setupMiddleware(t, appTestConfig)
 
 	// Action
 	middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -502,7 +627,7 @@ func TestFindAllSuccess(t *testing.T) {
 
 	recorder := httptest.NewRecorder()
 
-	middlewareTesting := setupMiddleware(appTestConfig)
+	middlewareTesting := setupMiddleware(t, appTestConfig)
 
 	// Action
 	middlewareTesting.ServeHTTP(recorder, testRequest)
@@ -529,3 +654,51 @@ func TestFindAllSuccess(t *testing.T) {
 		{Id: "CAT-3", Name: "Drinks"},
 	}, webResponse.Data)
 }
+
+func TestFindAllNotModified(t *testing.T) {
+	// Arrange
+	defer categoriesDbTableHelper.DeleteAll()
+
+	// Insert dummy data to DB
+	categoriesDbTableHelper.AddMany([]entity.Category{
+		{Id: "CAT-1", Name: "Tools"},
+	})
+	// ------------------------
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	findAll := func(etag string) *http.Response {
+		testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v2/categories", baseUrl), nil)
+
+		testRequest.Header.Set("X-API-Key", "test_key")
+		testRequest.Header.Set("If-None-Match", etag)
+
+		recorder := httptest.NewRecorder()
+
+		middlewareTesting.ServeHTTP(recorder, testRequest)
+
+		return recorder.Result()
+	}
+
+	firstResponse := findAll(`"none"`)
+
+	assert.Equal(t, http.StatusOK, firstResponse.StatusCode)
+
+	etag := firstResponse.Header.Get("etag")
+
+	// Action & Assert
+	notModifiedResponse := findAll(etag)
+
+	assert.Equal(t, http.StatusNotModified, notModifiedResponse.StatusCode)
+	assert.Equal(t, etag, notModifiedResponse.Header.Get("etag"))
+
+	// Any write bumps the version of the categories table
+	categoriesDbTableHelper.AddMany([]entity.Category{
+		{Id: "CAT-2", Name: "Foods"},
+	})
+
+	modifiedResponse := findAll(etag)
+
+	assert.Equal(t, http.StatusOK, modifiedResponse.StatusCode)
+	assert.NotEqual(t, etag, modifiedResponse.Header.Get("etag"))
+}
@@ -0,0 +1,206 @@
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	internal_helper "github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+)
+
+// startStreamServer serves the app over a real connection, so the streams
+// are read while they are written
+func startStreamServer(t *testing.T, broker stream.Broker) *httptest.Server {
+	server := httptest.NewUnstartedServer(setupMiddlewareWithBroker(t, appTestConfig, broker))
+
+	server.Config.RegisterOnShutdown(broker.Close)
+
+	server.Start()
+
+	return server
+}
+
+func openCategoryEvents(t *testing.T, server *httptest.Server, lastEventId string) *http.Response {
+	testRequest, err := http.NewRequestWithContext(t.Context(), http.MethodGet, fmt.Sprintf("%s/api/v2/categories/events", server.URL), nil)
+	internal_helper.LogStdPanicIfError(err)
+
+	testRequest.Header.Set("X-API-Key", "test_key")
+	testRequest.Header.Set("accept-encoding", "gzip")
+
+	if lastEventId != "" {
+		testRequest.Header.Set("last-event-id", lastEventId)
+	}
+
+	response, err := server.Client().Do(testRequest)
+	internal_helper.LogStdPanicIfError(err)
+
+	return response
+}
+
+// readCategoryEvent reads the lines of the next event, heartbeats skipped
+func readCategoryEvent(t *testing.T, reader *bufio.Reader) []string {
+	lines := []string{}
+
+	readChan := make(chan error, 1)
+
+	go func() {
+		for {
+			line, err := reader.ReadString('\n')
+
+			if err != nil {
+				readChan <- err
+				return
+			}
+
+			line = strings.TrimSuffix(line, "\n")
+
+			switch {
+			case strings.HasPrefix(line, ":"):
+			case line == "" && len(lines) > 0:
+				readChan <- nil
+				return
+			case line != "":
+				lines = append(lines, line)
+			}
+		}
+	}()
+
+	select {
+	case err := <-readChan:
+		internal_helper.LogStdPanicIfError(err)
+	case <-time.After(appTestConfig.Test.Timeout * time.Second):
+		t.Fatal("no category event is received")
+	}
+
+	return lines
+}
+
+func TestCategoryEventsUnauthorized(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v2/categories/events", baseUrl), nil)
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, "application/json", recorderResponse.Header.Get("content-type"))
+	assert.Equal(t, http.StatusUnauthorized, recorderResponse.StatusCode)
+}
+
+func TestCategoryEventsStream(t *testing.T) {
+	// Arrange
+	spanExporter.Reset()
+
+	broker := stream.NewBrokerImpl(appTestConfig)
+
+	server := startStreamServer(t, broker)
+	defer server.Close()
+
+	for id, eventType := range []string{"category.created", "category.updated"} {
+		broker.Publish(&model.CategoryEventResponse{
+			Id:        int64(id + 1),
+			Type:      eventType,
+			CreatedAt: time.Date(2026, time.October, 19, 14, 0, 0, 0, time.UTC),
+			Data:      json.RawMessage(`{"id":"CAT-1","name":"Gadget"}`),
+		})
+	}
+
+	// Action
+	response := openCategoryEvents(t, server, "1")
+	defer response.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("content-type"))
+	assert.Empty(t, response.Header.Get("content-encoding"), "the stream is never compressed")
+
+	reader := bufio.NewReader(response.Body)
+
+	assert.Equal(t, []string{
+		"id: 2",
+		"event: category.updated",
+		`data: {"id":2,"type":"category.updated","created_at":"2026-10-19T14:00:00Z","data":{"id":"CAT-1","name":"Gadget"}}`,
+	}, readCategoryEvent(t, reader), "the events after Last-Event-ID are replayed")
+
+	broker.Publish(&model.CategoryEventResponse{
+		Id:   3,
+		Type: "category.deleted",
+		Data: json.RawMessage(`{"id":"CAT-1","name":"Gadget"}`),
+	})
+
+	assert.Equal(t, "id: 3", readCategoryEvent(t, reader)[0])
+
+	ctx, cancel := context.WithTimeout(context.Background(), appTestConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	assert.NoError(t, server.Config.Shutdown(ctx), "the stream ends when the server shuts down")
+
+	_, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+
+	assert.NotNil(t, findSpan(spanExporter.GetSpans(), "GET /api/v2/categories/events"))
+}
+
+func TestCategoryEventsFromNotifications(t *testing.T) {
+	// Arrange
+	webhooksDbTableHelper.DeleteAll()
+	defer webhooksDbTableHelper.DeleteAll()
+	defer categoriesDbTableHelper.DeleteAll()
+
+	broker := stream.NewBrokerImpl(appTestConfig)
+
+	server := startStreamServer(t, broker)
+	defer server.Close()
+
+	pool := config.NewPgxPool(appTestConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	listener := InitializeCategoryEventListenerForTesting(appTestConfig, pool, config.NewLogrus(appTestConfig), broker)
+
+	go listener.Run(ctx)
+
+	response := openCategoryEvents(t, server, "")
+	defer response.Body.Close()
+
+	// Action
+	createResponse := serveWebhookTestRequest(t, http.MethodPost, "/api/v2/categories", `{"name":"Gadget"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, createResponse.StatusCode)
+
+	category := decodeWebhookTestResponse[*model.CategoryResponse](createResponse).Data
+
+	lines := readCategoryEvent(t, bufio.NewReader(response.Body))
+
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, "event: category.created", lines[1])
+
+		event := new(model.CategoryEventResponse)
+
+		err := json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), event)
+		internal_helper.LogStdPanicIfError(err)
+
+		assert.Equal(t, fmt.Sprintf("id: %d", event.Id), lines[0])
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%q,"name":"Gadget"}`, category.Id), string(event.Data))
+	}
+}
@@ -0,0 +1,109 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/stretchr/testify/assert"
+	internal_helper "github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+)
+
+func TestCategorySubscriptionEndpoint(t *testing.T) {
+	for _, withHeader := range []bool{true, false} {
+		name := "API Key Header"
+
+		if !withHeader {
+			name = "Auth Message"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			broker := stream.NewBrokerImpl(appTestConfig)
+
+			server := startStreamServer(t, broker)
+			defer server.Close()
+
+			header := http.Header{"Accept-Encoding": []string{"gzip"}}
+
+			if withHeader {
+				header.Set("X-API-Key", "test_key")
+			}
+
+			ctx, cancel := context.WithTimeout(t.Context(), appTestConfig.Test.Timeout*time.Second)
+			defer cancel()
+
+			// Action
+			conn, _, err := websocket.Dial(ctx, strings.Replace(server.URL, "http", "ws", 1)+"/api/v2/ws", &websocket.DialOptions{
+				HTTPHeader: header,
+			})
+			internal_helper.LogStdPanicIfError(err)
+
+			defer conn.CloseNow()
+
+			// Assert
+			if !withHeader {
+				err = wsjson.Write(ctx, conn, &model.CategorySubscriptionMessage{Type: "auth", Id: "0", ApiKey: "test_key"})
+				internal_helper.LogStdPanicIfError(err)
+
+				reply := new(model.CategorySubscriptionReply)
+
+				err = wsjson.Read(ctx, conn, reply)
+				internal_helper.LogStdPanicIfError(err)
+
+				assert.Equal(t, "ack", reply.Type)
+			}
+
+			err = wsjson.Write(ctx, conn, &model.CategorySubscriptionMessage{Type: "subscribe", Id: "1", CategoryIds: []string{"CAT-1"}})
+			internal_helper.LogStdPanicIfError(err)
+
+			reply := new(model.CategorySubscriptionReply)
+
+			err = wsjson.Read(ctx, conn, reply)
+			internal_helper.LogStdPanicIfError(err)
+
+			assert.Equal(t, &model.CategorySubscriptionReply{Type: "ack", Id: "1", Subscription: "sub-1"}, reply)
+
+			broker.Publish(&model.CategoryEventResponse{Id: 1, Type: "category.created", Data: []byte(`{"id":"CAT-1","name":"Gadget"}`)})
+
+			reply = new(model.CategorySubscriptionReply)
+
+			err = wsjson.Read(ctx, conn, reply)
+			internal_helper.LogStdPanicIfError(err)
+
+			assert.Equal(t, "event", reply.Type)
+			assert.JSONEq(t, `{"id":"CAT-1","name":"Gadget"}`, string(reply.Event.Data))
+
+			// Hijacked connections are not tracked by Shutdown, the broker
+			// ends them
+			err = server.Config.Shutdown(ctx)
+			internal_helper.LogStdPanicIfError(err)
+
+			_, _, err = conn.Read(ctx)
+
+			assert.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(err))
+		})
+	}
+}
+
+func TestCategorySubscriptionEndpointUnauthorized(t *testing.T) {
+	// Arrange
+	server := startStreamServer(t, stream.NewBrokerImpl(appTestConfig))
+	defer server.Close()
+
+	// Action
+	_, response, err := websocket.Dial(t.Context(), strings.Replace(server.URL, "http", "ws", 1)+"/api/v2/ws", &websocket.DialOptions{
+		HTTPHeader: http.Header{"X-API-Key": []string{"wrong_key"}},
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	assert.Equal(t, "application/json", response.Header.Get("content-type"))
+}
@@ -0,0 +1,125 @@
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	internal_helper "github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+type graphqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func TestGraphqlUnauthorized(t *testing.T) {
+	// Arrange
+	requestBody := strings.NewReader(`{"query":"{ categories { totalCount } }"}`)
+
+	testRequest := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/graphql", baseUrl), requestBody)
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, recorder.Result().StatusCode)
+}
+
+func TestGraphqlCategoriesSuccess(t *testing.T) {
+	// Arrange
+	defer categoriesDbTableHelper.DeleteAll()
+
+	// Insert dummy data to DB
+	categoriesDbTableHelper.AddMany([]entity.Category{
+		{Id: "CAT-1", Name: "Tools"},
+		{Id: "CAT-2", Name: "Foods"},
+		{Id: "CAT-3", Name: "Drinks"},
+	})
+	// ------------------------
+
+	requestBody := strings.NewReader(`{"query":"{ categories(first: 2) { totalCount edges { node { id name } } pageInfo { hasNextPage } } one: category(id: \"CAT-3\") { name } }"}`)
+
+	testRequest := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/graphql", baseUrl), requestBody)
+
+	testRequest.Header.Set("X-API-Key", "test_key")
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+
+	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+	internal_helper.LogStdPanicIfError(err)
+
+	result := new(graphqlResponse)
+
+	err = json.Unmarshal(responseBodyBytes, result)
+	internal_helper.LogStdPanicIfError(err)
+
+	assert.Empty(t, result.Errors)
+
+	categories := result.Data["categories"].(map[string]any)
+
+	assert.Equal(t, float64(3), categories["totalCount"])
+	assert.Equal(t, []any{
+		map[string]any{"node": map[string]any{"id": "CAT-1", "name": "Tools"}},
+		map[string]any{"node": map[string]any{"id": "CAT-2", "name": "Foods"}},
+	}, categories["edges"])
+	assert.Equal(t, map[string]any{"hasNextPage": true}, categories["pageInfo"])
+	assert.Equal(t, map[string]any{"name": "Drinks"}, result.Data["one"])
+}
+
+func TestGraphqlCreateCategorySuccess(t *testing.T) {
+	// Arrange
+	defer categoriesDbTableHelper.DeleteAll()
+
+	requestBody := strings.NewReader(`{"query":"mutation { createCategory(input: {name: \"Fashions\"}) { id name } }"}`)
+
+	testRequest := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/graphql", baseUrl), requestBody)
+
+	testRequest.Header.Set("X-API-Key", "test_key")
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+
+	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+	internal_helper.LogStdPanicIfError(err)
+
+	result := new(graphqlResponse)
+
+	err = json.Unmarshal(responseBodyBytes, result)
+	internal_helper.LogStdPanicIfError(err)
+
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "Fashions", result.Data["createCategory"].(map[string]any)["name"])
+	assert.Equal(t, 1, len(categoriesDbTableHelper.FindAll()))
+}
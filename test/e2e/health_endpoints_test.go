@@ -0,0 +1,73 @@
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	internal_helper "github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+func TestHealthzWithoutApiKey(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/healthz", baseUrl), nil)
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, "application/json", recorderResponse.Header.Get("content-type"))
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+
+	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+	internal_helper.LogStdPanicIfError(err)
+
+	webResponse := new(model.WebResponseMessage)
+
+	err = json.Unmarshal(responseBodyBytes, webResponse)
+	internal_helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, http.StatusOK, webResponse.Code)
+	assert.Equal(t, "OK", webResponse.Status)
+}
+
+func TestReadyzWithoutApiKey(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/readyz", baseUrl), nil)
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, "application/json", recorderResponse.Header.Get("content-type"))
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+
+	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+	internal_helper.LogStdPanicIfError(err)
+
+	webResponse := new(model.WebResponse[*model.HealthResponse])
+
+	err = json.Unmarshal(responseBodyBytes, webResponse)
+	internal_helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, "pass", webResponse.Data.Status)
+	assert.Len(t, webResponse.Data.Checks, 3)
+	assert.NotContains(t, string(responseBodyBytes), "pool", "the public probe keeps the pool stats to the metrics")
+}
@@ -8,27 +8,51 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/sirupsen/logrus"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/graphql"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/route"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/webhook"
 )
 
 var repositorySet = wire.NewSet(
 	repository.NewCategoryRepositoryImpl,
+	repository.NewIdempotencyKeyRepositoryImpl,
+	repository.NewSchemaMigrationRepositoryImpl,
+	repository.NewOutboxEventRepositoryImpl,
+	repository.NewWebhookSubscriptionRepositoryImpl,
+	repository.NewWebhookDeliveryRepositoryImpl,
+	repository.NewApiKeyRepositoryImpl,
+	repository.NewRequestNonceRepositoryImpl,
+	repository.NewTenantRepositoryImpl,
 )
 
 var useCaseSet = wire.NewSet(
-	usecase.NewCategoryUseCaseImpl,
+	usecase.NewCategoryUseCase,
+	usecase.NewHealthUseCaseImpl,
+	usecase.NewWebhookUseCaseImpl,
+	usecase.NewApiKeyUseCaseImpl,
+	usecase.NewTenantUseCaseImpl,
 )
 
 var controllerSet = wire.NewSet(
 	http.NewCategoryControllerImpl,
+	http.NewCategoryEventControllerImpl,
+	http.NewCategorySubscriptionControllerImpl,
+	http.NewOpenApiControllerImpl,
+	graphql.NewGraphqlControllerImpl,
+	http.NewHealthControllerImpl,
+	http.NewWebhookControllerImpl,
+	http.NewApiKeyControllerImpl,
+	http.NewTenantControllerImpl,
 )
 
-func InitializeControllerForTesting(appConfig *config.AppConfig, database db.PgxPool, logger *logrus.Logger, router *httprouter.Router) route.RouteConfig {
+func InitializeControllerForTesting(appConfig *config.AppConfig, database db.PgxPool, logger *logrus.Logger, router *httprouter.Router, lifecycle *helper.Lifecycle, broker stream.Broker, categoryCache *usecase.CategoryCache) route.RouteConfig {
 	wire.Build(
 		security.NewIdGenImpl,
 		security.NewValidationImpl,
@@ -40,3 +64,36 @@ func InitializeControllerForTesting(appConfig *config.AppConfig, database db.Pgx
 
 	return nil
 }
+
+func InitializeApiKeyUseCaseForTesting(appConfig *config.AppConfig, database db.PgxPool) usecase.ApiKeyUseCase {
+	wire.Build(
+		security.NewIdGenImpl,
+		security.NewValidationImpl,
+		repository.NewApiKeyRepositoryImpl,
+		repository.NewRequestNonceRepositoryImpl,
+		repository.NewTenantRepositoryImpl,
+		usecase.NewApiKeyUseCaseImpl,
+	)
+
+	return nil
+}
+
+func InitializeWebhookDispatcherForTesting(appConfig *config.AppConfig, database db.PgxPool, logger *logrus.Logger) webhook.Dispatcher {
+	wire.Build(
+		repository.NewOutboxEventRepositoryImpl,
+		repository.NewWebhookDeliveryRepositoryImpl,
+		webhook.NewDispatcherImpl,
+	)
+
+	return nil
+}
+
+func InitializeCategoryEventListenerForTesting(appConfig *config.AppConfig, database db.PgxPool, logger *logrus.Logger, broker stream.Broker) stream.Listener {
+	wire.Build(
+		config.NewPgxConnector,
+		repository.NewOutboxEventRepositoryImpl,
+		stream.NewListenerImpl,
+	)
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package e2e
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	internal_helper "github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+var jwtTestSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// setupJwtTestConfig accepts the bearer tokens only, signed with HS256 by the
+// key of a local JWKS
+func setupJwtTestConfig(t *testing.T) *config.AppConfig {
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]any{
+			{"kid": "e2e", "kty": "oct", "alg": "HS256", "k": base64.RawURLEncoding.EncodeToString(jwtTestSecret)},
+		},
+	})
+	internal_helper.LogStdPanicIfError(err)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+
+	err = os.WriteFile(jwksFile, jwks, 0644)
+	internal_helper.LogStdPanicIfError(err)
+
+	serverConfig := *appTestConfig.Server
+	serverConfig.AuthModes = []string{middleware.AuthModeJwt}
+	serverConfig.Jwt = config.Jwt{
+		JwksFile:    jwksFile,
+		JwksRefresh: 300,
+		Issuer:      "https://sso.example.com",
+		Audience:    "pzn-api",
+		ClockSkew:   30,
+		ScopeClaim:  "scope",
+	}
+
+	jwtConfig := *appTestConfig
+	jwtConfig.Server = &serverConfig
+
+	return &jwtConfig
+}
+
+func signJwtTestToken(scope string) string {
+	header, err := json.Marshal(map[string]any{"alg": "HS256", "kid": "e2e", "typ": "JWT"})
+	internal_helper.LogStdPanicIfError(err)
+
+	claims, err := json.Marshal(map[string]any{
+		"sub":   "user-1",
+		"iss":   "https://sso.example.com",
+		"aud":   "pzn-api",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": scope,
+	})
+	internal_helper.LogStdPanicIfError(err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	mac := hmac.New(sha256.New, jwtTestSecret)
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func serveJwtTestRequest(t *testing.T, appConfig *config.AppConfig, headers map[string]string, method string, path string, requestBody string) *http.Response {
+	testRequest := httptest.NewRequest(method, fmt.Sprintf("%s%s", baseUrl, path), strings.NewReader(requestBody))
+
+	for name, value := range headers {
+		testRequest.Header.Set(name, value)
+	}
+
+	if requestBody != "" {
+		testRequest.Header.Set("content-type", "application/json")
+	}
+
+	recorder := httptest.NewRecorder()
+
+	setupMiddleware(t, appConfig).ServeHTTP(recorder, testRequest)
+
+	return recorder.Result()
+}
+
+func TestJwtScopes(t *testing.T) {
+	// Arrange
+	defer categoriesDbTableHelper.DeleteAll()
+
+	jwtConfig := setupJwtTestConfig(t)
+	bearer := map[string]string{"Authorization": "Bearer " + signJwtTestToken("openid categories:read")}
+
+	// Action & Assert
+	assert.Equal(t, http.StatusOK, serveJwtTestRequest(t, jwtConfig, bearer, http.MethodGet, "/api/v2/categories", "").StatusCode)
+	assert.Equal(t, http.StatusForbidden, serveJwtTestRequest(t, jwtConfig, bearer, http.MethodPost, "/api/v2/categories", `{"name":"Fashions"}`).StatusCode)
+}
+
+func TestJwtFailed(t *testing.T) {
+	// Arrange
+	jwtConfig := setupJwtTestConfig(t)
+
+	token := signJwtTestToken("categories:read")
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{name: "Tampered Token", headers: map[string]string{"Authorization": "Bearer " + token[:len(token)-2] + "AA"}},
+		{name: "API Key With The Mode Disabled", headers: map[string]string{"X-API-Key": "test_key"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Action
+			response := serveJwtTestRequest(t, jwtConfig, test.headers, http.MethodGet, "/api/v2/categories", "")
+
+			// Assert
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		})
+	}
+}
@@ -1,24 +1,83 @@
 package e2e
 
 import (
+	"testing"
+
 	"github.com/julienschmidt/httprouter"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/middleware"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
-func setupMiddleware(appConfig *config.AppConfig) middleware.HttpMiddleware {
+// The spans are kept in memory, so the tests can assert on them
+var spanExporter = setupTracing()
+
+func setupTracing() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return exporter
+}
+
+func setupMiddleware(t *testing.T, appConfig *config.AppConfig) middleware.HttpMiddleware {
+	return setupMiddlewareWithBroker(t, appConfig, stream.NewBrokerImpl(appConfig))
+}
+
+// setupMiddlewareWithBroker lets the event stream tests publish to the broker
+// the controllers subscribe to. The category cache follows the broker until
+// the test ends
+func setupMiddlewareWithBroker(t *testing.T, appConfig *config.AppConfig, broker stream.Broker) middleware.HttpMiddleware {
 	pool := config.NewPgxPool(appConfig)
 	logger := config.NewLogrus(appConfig)
 	router := httprouter.New()
 
-	routeConfig := InitializeControllerForTesting(appConfig, pool, logger, router)
+	categoryCache := usecase.NewCategoryCache(appConfig, broker)
+
+	if appConfig.CategoryCache.Enabled {
+		categoryCache.Start(t.Context())
+		t.Cleanup(categoryCache.Close)
+	}
+
+	routeConfig := InitializeControllerForTesting(appConfig, pool, logger, router, helper.NewLifecycle(), broker, categoryCache)
 	routeConfig.Setup()
 
-	return middleware.NewHttpPanicMiddleware(
-		logger,
-		middleware.NewHttpAuthMiddleware(
-			appConfig,
-			router,
+	// Every response is validated against the API spec, so the tests fail
+	// whenever the implementation drifts from it
+	return middleware.NewHttpRequestIdMiddleware(
+		security.NewIdGenImpl(),
+		middleware.NewHttpTracingMiddleware(
+			middleware.NewHttpCompressionMiddleware(
+				appConfig,
+				middleware.NewHttpOpenApiResponseMiddleware(
+					logger,
+					middleware.NewHttpPanicMiddleware(
+						logger,
+						middleware.NewHttpCorsMiddleware(
+							appConfig,
+							middleware.NewHttpAuthMiddleware(
+								appConfig,
+								InitializeApiKeyUseCaseForTesting(appConfig, pool),
+								security.NewJwtVerifierImpl(appConfig, security.NewJwkSetImpl(appConfig)),
+								middleware.NewHttpRequestBodyMiddleware(
+									appConfig,
+									middleware.NewHttpOpenApiRequestMiddleware(
+										router,
+									),
+								),
+							),
+						),
+					),
+				),
+			),
 		),
 	)
 }
@@ -0,0 +1,136 @@
+package e2e
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	internal_helper "github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+)
+
+func TestOpenApiSpecSuccess(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v2/openapi.json", baseUrl), nil)
+
+	testRequest.Header.Set("X-API-Key", "test_key")
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, "application/json", recorderResponse.Header.Get("content-type"))
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+	assert.NotEmpty(t, recorderResponse.Header.Get("x-request-id"))
+
+	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+	internal_helper.LogStdPanicIfError(err)
+
+	spec := map[string]any{}
+
+	err = json.Unmarshal(responseBodyBytes, &spec)
+	internal_helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, "3.1.1", spec["openapi"])
+}
+
+func TestOpenApiSpecCompressed(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v2/openapi.json", baseUrl), nil)
+
+	testRequest.Header.Set("X-API-Key", "test_key")
+	testRequest.Header.Set("Accept-Encoding", "gzip")
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+	assert.Equal(t, "gzip", recorderResponse.Header.Get("content-encoding"))
+	assert.Equal(t, "Accept-Encoding", recorderResponse.Header.Get("vary"))
+
+	gzipReader, err := gzip.NewReader(recorderResponse.Body)
+	internal_helper.LogStdPanicIfError(err)
+
+	spec := map[string]any{}
+
+	err = json.NewDecoder(gzipReader).Decode(&spec)
+	internal_helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, "3.1.1", spec["openapi"])
+}
+
+func TestOpenApiDocsSuccess(t *testing.T) {
+	// Arrange
+	testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v2/docs", baseUrl), nil)
+
+	testRequest.Header.Set("X-API-Key", "test_key")
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, "text/html; charset=utf-8", recorderResponse.Header.Get("content-type"))
+	assert.Equal(t, http.StatusOK, recorderResponse.StatusCode)
+}
+
+func TestOpenApiRequestValidationFailed(t *testing.T) {
+	// Arrange
+	requestBody := strings.NewReader(`{"name":10}`)
+
+	testRequest := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v2/categories", baseUrl), requestBody)
+
+	testRequest.Header.Set("X-API-Key", "test_key")
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	recorderResponse := recorder.Result()
+
+	assert.Equal(t, "application/json", recorderResponse.Header.Get("content-type"))
+	assert.Equal(t, http.StatusBadRequest, recorderResponse.StatusCode)
+
+	responseBodyBytes, err := io.ReadAll(recorderResponse.Body)
+	internal_helper.LogStdPanicIfError(err)
+
+	webResponse := new(model.WebResponseErrors)
+
+	err = json.Unmarshal(responseBodyBytes, webResponse)
+	internal_helper.LogStdPanicIfError(err)
+
+	assert.Equal(t, http.StatusBadRequest, webResponse.Code)
+	assert.Equal(t, "BAD REQUEST", webResponse.Status)
+	assert.Equal(t, "request does not match the API spec", webResponse.Message)
+	assert.Equal(t, []model.ErrorDetail{
+		{Location: "body", Pointer: "/name", Message: "value must be a string"},
+	}, webResponse.Errors)
+}
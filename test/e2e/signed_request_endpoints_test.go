@@ -0,0 +1,80 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	internal_helper "github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/pkg/signing"
+)
+
+func newSignedTestRequest(apiKey *model.ApiKeyResponse, method string, path string, requestBody string) *http.Request {
+	testRequest := httptest.NewRequest(method, fmt.Sprintf("%s%s", baseUrl, path), strings.NewReader(requestBody))
+
+	testRequest.Header.Set("X-API-Key", apiKey.Key)
+	testRequest.Header.Set("content-type", "application/json")
+
+	err := signing.SignRequest(testRequest, apiKey.SigningSecret, "content-type")
+	internal_helper.LogStdPanicIfError(err)
+
+	return testRequest
+}
+
+func TestSignedRequests(t *testing.T) {
+	// Arrange
+	apiKeysDbTableHelper.DeleteAll()
+	defer apiKeysDbTableHelper.DeleteAll()
+	defer categoriesDbTableHelper.DeleteAll()
+
+	createResponse := serveApiKeyTestRequest(t, "test_key", http.MethodPost, "/api/v2/api-keys", `{"name":"partner","scopes":["categories:write"],"signed":true}`)
+
+	assert.Equal(t, http.StatusCreated, createResponse.StatusCode)
+
+	apiKey := decodeWebhookTestResponse[*model.ApiKeyResponse](createResponse).Data
+
+	assert.True(t, apiKey.Signed)
+	assert.NotEmpty(t, apiKey.SigningSecret)
+
+	t.Run("201 - Signed Request", func(t *testing.T) {
+		// Arrange
+		testRequest := newSignedTestRequest(apiKey, http.MethodPost, "/api/v2/categories", `{"name":"Fashions"}`)
+
+		recorder := httptest.NewRecorder()
+
+		// Action
+		setupMiddleware(t, appTestConfig).ServeHTTP(recorder, testRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, recorder.Result().StatusCode)
+	})
+
+	t.Run("401 - Replayed Request", func(t *testing.T) {
+		// Arrange
+		testRequest := newSignedTestRequest(apiKey, http.MethodPost, "/api/v2/categories", `{"name":"Gadgets"}`)
+		replayRequest := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v2/categories", baseUrl), strings.NewReader(`{"name":"Gadgets"}`))
+		replayRequest.Header = testRequest.Header.Clone()
+
+		setupMiddleware(t, appTestConfig).ServeHTTP(httptest.NewRecorder(), testRequest)
+
+		recorder := httptest.NewRecorder()
+
+		// Action
+		setupMiddleware(t, appTestConfig).ServeHTTP(recorder, replayRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, recorder.Result().StatusCode)
+	})
+
+	t.Run("401 - Unsigned Request", func(t *testing.T) {
+		// Action
+		response := serveApiKeyTestRequest(t, apiKey.Key, http.MethodPost, "/api/v2/categories", `{"name":"Books"}`)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	})
+}
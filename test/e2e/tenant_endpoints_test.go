@@ -0,0 +1,94 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/test/helper"
+)
+
+var tenantsDbTableHelper = helper.NewTenantsDbTable(
+	config.NewAppConfig(configPath),
+)
+
+// createTenantAdminKey provisions a tenant with the bootstrap key, and answers
+// an admin key of it
+func createTenantAdminKey(t *testing.T, name string) *model.ApiKeyResponse {
+	tenantResponse := serveApiKeyTestRequest(t, "test_key", http.MethodPost, "/api/v2/tenants", `{"name":"`+name+`"}`)
+	assert.Equal(t, http.StatusCreated, tenantResponse.StatusCode)
+
+	tenant := decodeWebhookTestResponse[*model.TenantResponse](tenantResponse).Data
+
+	apiKeyResponse := serveApiKeyTestRequest(t, "test_key", http.MethodPost, "/api/v2/api-keys", `{"name":"`+name+` admin","scopes":["admin"],"tenant_id":"`+tenant.Id+`"}`)
+	assert.Equal(t, http.StatusCreated, apiKeyResponse.StatusCode)
+
+	apiKey := decodeWebhookTestResponse[*model.ApiKeyResponse](apiKeyResponse).Data
+	assert.Equal(t, tenant.Id, apiKey.TenantId)
+
+	return apiKey
+}
+
+func TestTenantIsolation(t *testing.T) {
+	// Arrange
+	tenantsDbTableHelper.DeleteAll()
+	defer tenantsDbTableHelper.DeleteAll()
+	defer apiKeysDbTableHelper.DeleteAll()
+	defer categoriesDbTableHelper.DeleteAll()
+
+	keyA := createTenantAdminKey(t, "brand-a")
+	keyB := createTenantAdminKey(t, "brand-b")
+
+	createResponse := serveApiKeyTestRequest(t, keyA.Key, http.MethodPost, "/api/v2/categories", `{"name":"Fashions"}`)
+	assert.Equal(t, http.StatusCreated, createResponse.StatusCode)
+
+	category := decodeWebhookTestResponse[*model.CategoryResponse](createResponse).Data
+	categoryPath := "/api/v2/categories/" + category.Id
+
+	// Action & Assert
+	assert.Equal(t, http.StatusOK, serveApiKeyTestRequest(t, keyA.Key, http.MethodGet, categoryPath, "").StatusCode)
+
+	for _, apiKey := range []string{keyB.Key, "test_key"} {
+		listResponse := serveApiKeyTestRequest(t, apiKey, http.MethodGet, "/api/v2/categories", "")
+		assert.Equal(t, http.StatusOK, listResponse.StatusCode)
+		assert.Empty(t, decodeWebhookTestResponse[[]model.CategoryResponse](listResponse).Data)
+
+		assert.Equal(t, http.StatusNotFound, serveApiKeyTestRequest(t, apiKey, http.MethodGet, categoryPath, "").StatusCode)
+		assert.Equal(t, http.StatusNotFound, serveApiKeyTestRequest(t, apiKey, http.MethodPut, categoryPath, `{"name":"Gadgets"}`).StatusCode)
+		assert.Equal(t, http.StatusNotFound, serveApiKeyTestRequest(t, apiKey, http.MethodDelete, categoryPath, "").StatusCode)
+	}
+
+	findResponse := serveApiKeyTestRequest(t, keyA.Key, http.MethodGet, categoryPath, "")
+	assert.Equal(t, "Fashions", decodeWebhookTestResponse[*model.CategoryResponse](findResponse).Data.Name)
+}
+
+func TestTenantAdministration(t *testing.T) {
+	// Arrange
+	tenantsDbTableHelper.DeleteAll()
+	defer tenantsDbTableHelper.DeleteAll()
+	defer apiKeysDbTableHelper.DeleteAll()
+
+	keyA := createTenantAdminKey(t, "brand-a")
+	keyB := createTenantAdminKey(t, "brand-b")
+
+	// Action & Assert
+	assert.Equal(t, http.StatusConflict, serveApiKeyTestRequest(t, "test_key", http.MethodPost, "/api/v2/tenants", `{"name":"brand-a"}`).StatusCode)
+	assert.Equal(t, http.StatusNotFound, serveApiKeyTestRequest(t, "test_key", http.MethodPost, "/api/v2/api-keys", `{"name":"reader","scopes":["categories:read"],"tenant_id":"unknown"}`).StatusCode)
+
+	tenantsResponse := serveApiKeyTestRequest(t, "test_key", http.MethodGet, "/api/v2/tenants", "")
+	assert.Equal(t, http.StatusOK, tenantsResponse.StatusCode)
+	assert.Len(t, decodeWebhookTestResponse[[]model.TenantResponse](tenantsResponse).Data, 3)
+
+	assert.Equal(t, http.StatusForbidden, serveApiKeyTestRequest(t, keyB.Key, http.MethodGet, "/api/v2/tenants", "").StatusCode)
+	assert.Equal(t, http.StatusForbidden, serveApiKeyTestRequest(t, keyB.Key, http.MethodPost, "/api/v2/api-keys", `{"name":"reader","scopes":["categories:read"],"tenant_id":"`+keyA.TenantId+`"}`).StatusCode)
+	assert.Equal(t, http.StatusNotFound, serveApiKeyTestRequest(t, keyB.Key, http.MethodDelete, "/api/v2/api-keys/"+keyA.Id, "").StatusCode)
+
+	apiKeysResponse := serveApiKeyTestRequest(t, keyB.Key, http.MethodGet, "/api/v2/api-keys", "")
+	apiKeys := decodeWebhookTestResponse[[]model.ApiKeyResponse](apiKeysResponse).Data
+
+	if assert.Len(t, apiKeys, 1) {
+		assert.Equal(t, keyB.Id, apiKeys[0].Id)
+	}
+}
@@ -0,0 +1,91 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	inboundTraceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	inboundSpanId  = "00f067aa0ba902b7"
+)
+
+func findSpan(spans tracetest.SpanStubs, name string) *tracetest.SpanStub {
+	for index := range spans {
+		if spans[index].Name == name {
+			return &spans[index]
+		}
+	}
+
+	return nil
+}
+
+func TestTracingContinuesTraceparent(t *testing.T) {
+	// Arrange
+	spanExporter.Reset()
+
+	testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/healthz", baseUrl), nil)
+
+	testRequest.Header.Set("traceparent", fmt.Sprintf("00-%s-%s-01", inboundTraceId, inboundSpanId))
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	serverSpan := findSpan(spanExporter.GetSpans(), "GET /healthz")
+
+	if assert.NotNil(t, serverSpan) {
+		assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind)
+		assert.Equal(t, inboundTraceId, serverSpan.SpanContext.TraceID().String())
+		assert.Equal(t, inboundSpanId, serverSpan.Parent.SpanID().String())
+		assert.True(t, serverSpan.Parent.IsRemote())
+		assert.Contains(t, serverSpan.Attributes, semconv.HTTPRoute("/healthz"))
+		assert.Contains(t, serverSpan.Attributes, semconv.HTTPResponseStatusCode(http.StatusOK))
+	}
+}
+
+func TestTracingUseCaseAndQueries(t *testing.T) {
+	// Arrange
+	spanExporter.Reset()
+
+	testRequest := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v2/categories", baseUrl), nil)
+
+	testRequest.Header.Set("X-API-Key", "test_key")
+	testRequest.Header.Set("traceparent", fmt.Sprintf("00-%s-%s-01", inboundTraceId, inboundSpanId))
+
+	recorder := httptest.NewRecorder()
+
+	middlewareTesting := setupMiddleware(t, appTestConfig)
+
+	// Action
+	middlewareTesting.ServeHTTP(recorder, testRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	spans := spanExporter.GetSpans()
+
+	serverSpan := findSpan(spans, "GET /api/v2/categories")
+	useCaseSpan := findSpan(spans, "CategoryUseCase.FindVersion")
+	querySpan := findSpan(spans, "pgx SELECT")
+
+	if assert.NotNil(t, serverSpan) && assert.NotNil(t, useCaseSpan) && assert.NotNil(t, querySpan) {
+		assert.Equal(t, serverSpan.SpanContext.SpanID(), useCaseSpan.Parent.SpanID())
+		assert.Equal(t, inboundTraceId, querySpan.SpanContext.TraceID().String())
+		assert.Equal(t, trace.SpanKindClient, querySpan.SpanKind)
+		assert.Contains(t, querySpan.Attributes, semconv.DBSystemNamePostgreSQL)
+	}
+
+	assert.NotNil(t, findSpan(spans, "CategoryUseCase.FindAll"))
+}
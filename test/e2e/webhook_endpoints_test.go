@@ -0,0 +1,189 @@
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	internal_helper "github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/model"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/webhook"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/test/helper"
+)
+
+var webhooksDbTableHelper = helper.NewWebhooksDbTable(
+	config.NewAppConfig(configPath),
+)
+
+func serveWebhookTestRequest(t *testing.T, method string, path string, requestBody string) *http.Response {
+	testRequest := httptest.NewRequest(method, fmt.Sprintf("%s%s", baseUrl, path), strings.NewReader(requestBody))
+
+	testRequest.Header.Set("X-API-Key", "test_key")
+
+	if requestBody != "" {
+		testRequest.Header.Set("content-type", "application/json")
+	}
+
+	recorder := httptest.NewRecorder()
+
+	setupMiddleware(t, appTestConfig).ServeHTTP(recorder, testRequest)
+
+	return recorder.Result()
+}
+
+func decodeWebhookTestResponse[T any](response *http.Response) *model.WebResponse[T] {
+	webResponse := new(model.WebResponse[T])
+
+	err := json.NewDecoder(response.Body).Decode(webResponse)
+	internal_helper.LogStdPanicIfError(err)
+
+	return webResponse
+}
+
+// dispatchWebhooks runs one poll of the dispatcher with the given max attempts
+func dispatchWebhooks(t *testing.T, maxAttempts int) int {
+	webhookConfig := *appTestConfig.Webhook
+	webhookConfig.MaxAttempts = maxAttempts
+
+	dispatcherConfig := *appTestConfig
+	dispatcherConfig.Webhook = &webhookConfig
+
+	pool := config.NewPgxPool(appTestConfig)
+	defer pool.Close()
+
+	return InitializeWebhookDispatcherForTesting(&dispatcherConfig, pool, config.NewLogrus(appTestConfig)).DispatchOnce(t.Context())
+}
+
+func TestWebhookDelivered(t *testing.T) {
+	// Arrange
+	webhooksDbTableHelper.DeleteAll()
+	defer webhooksDbTableHelper.DeleteAll()
+	defer categoriesDbTableHelper.DeleteAll()
+
+	receivedChan := make(chan error, 1)
+	receivedEvent := new(model.WebhookEvent)
+
+	var secret string
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		internal_helper.LogStdPanicIfError(err)
+
+		err = json.Unmarshal(body, receivedEvent)
+		internal_helper.LogStdPanicIfError(err)
+
+		receivedChan <- webhook.Verify(secret, r.Header.Get(webhook.SignatureHeader), body, 5*time.Minute, time.Now())
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	createdWebhook := decodeWebhookTestResponse[*model.WebhookResponse](serveWebhookTestRequest(t,
+		http.MethodPost, "/api/v2/webhooks",
+		fmt.Sprintf(`{"url":%q,"event_types":["category.created"]}`, receiver.URL),
+	))
+
+	secret = createdWebhook.Data.Secret
+
+	createdCategory := decodeWebhookTestResponse[*model.CategoryResponse](serveWebhookTestRequest(t,
+		http.MethodPost, "/api/v2/categories", `{"name":"Gadget"}`,
+	))
+
+	// Action
+	sent := dispatchWebhooks(t, 3)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, createdWebhook.Code)
+	assert.True(t, strings.HasPrefix(secret, "whsec_"))
+
+	assert.Equal(t, 1, sent)
+	assert.NoError(t, <-receivedChan)
+	assert.Equal(t, "category.created", receivedEvent.Type)
+	assert.JSONEq(t, fmt.Sprintf(`{"id":%q,"name":"Gadget"}`, createdCategory.Data.Id), string(receivedEvent.Data))
+
+	deliveries := decodeWebhookTestResponse[[]model.WebhookDeliveryResponse](serveWebhookTestRequest(t,
+		http.MethodGet, fmt.Sprintf("/api/v2/webhooks/%s/deliveries", createdWebhook.Data.Id), "",
+	))
+
+	if assert.Len(t, deliveries.Data, 1) {
+		assert.Equal(t, "delivered", deliveries.Data[0].Status)
+		assert.Equal(t, 1, deliveries.Data[0].Attempts)
+		assert.Equal(t, http.StatusOK, *deliveries.Data[0].LastStatusCode)
+	}
+
+	foundWebhook := decodeWebhookTestResponse[*model.WebhookResponse](serveWebhookTestRequest(t,
+		http.MethodGet, fmt.Sprintf("/api/v2/webhooks/%s", createdWebhook.Data.Id), "",
+	))
+
+	assert.Empty(t, foundWebhook.Data.Secret)
+}
+
+func TestWebhookDeadAndRedelivered(t *testing.T) {
+	// Arrange
+	webhooksDbTableHelper.DeleteAll()
+	defer webhooksDbTableHelper.DeleteAll()
+	defer categoriesDbTableHelper.DeleteAll()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	createdWebhook := decodeWebhookTestResponse[*model.WebhookResponse](serveWebhookTestRequest(t,
+		http.MethodPost, "/api/v2/webhooks",
+		fmt.Sprintf(`{"url":%q,"event_types":["category.created","category.deleted"]}`, receiver.URL),
+	))
+
+	serveWebhookTestRequest(t, http.MethodPost, "/api/v2/categories", `{"name":"Gadget"}`)
+
+	// Action
+	dispatchWebhooks(t, 1)
+
+	// Assert
+	deliveries := decodeWebhookTestResponse[[]model.WebhookDeliveryResponse](serveWebhookTestRequest(t,
+		http.MethodGet, fmt.Sprintf("/api/v2/webhooks/%s/deliveries", createdWebhook.Data.Id), "",
+	))
+
+	if !assert.Len(t, deliveries.Data, 1) {
+		return
+	}
+
+	assert.Equal(t, "dead", deliveries.Data[0].Status)
+	assert.Equal(t, "the receiver answered with status 500", *deliveries.Data[0].LastError)
+
+	redeliverPath := fmt.Sprintf("/api/v2/webhooks/%s/deliveries/%d/redeliver", createdWebhook.Data.Id, deliveries.Data[0].Id)
+
+	redeliverResponse := serveWebhookTestRequest(t, http.MethodPost, redeliverPath, "")
+
+	assert.Equal(t, http.StatusAccepted, redeliverResponse.StatusCode)
+
+	redelivered := decodeWebhookTestResponse[*model.WebhookDeliveryResponse](redeliverResponse)
+
+	assert.Equal(t, "pending", redelivered.Data.Status)
+	assert.Equal(t, 0, redelivered.Data.Attempts)
+
+	assert.Equal(t, http.StatusConflict, serveWebhookTestRequest(t, http.MethodPost, redeliverPath, "").StatusCode)
+}
+
+func TestWebhookCreateFailed(t *testing.T) {
+	// Arrange & Action
+	response := serveWebhookTestRequest(t, http.MethodPost, "/api/v2/webhooks", `{"url":"https://example.com/hook","event_types":["category.renamed"]}`)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestWebhookNotFound(t *testing.T) {
+	// Arrange & Action
+	response := serveWebhookTestRequest(t, http.MethodGet, "/api/v2/webhooks/WH-404/deliveries", "")
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
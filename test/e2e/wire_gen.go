@@ -11,30 +11,78 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/sirupsen/logrus"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/graphql"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/controller/http/route"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/db"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/repository"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/security"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/stream"
 	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/usecase"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/webhook"
 )
 
 // Injectors from injector_for_testing.go:
 
-func InitializeControllerForTesting(appConfig *config.AppConfig, database db.PgxPool, logger *logrus.Logger, router *httprouter.Router) route.RouteConfig {
+func InitializeControllerForTesting(appConfig *config.AppConfig, database db.PgxPool, logger *logrus.Logger, router *httprouter.Router, lifecycle *helper.Lifecycle, broker stream.Broker, categoryCache *usecase.CategoryCache) route.RouteConfig {
 	validation := security.NewValidationImpl()
 	idGenerator := security.NewIdGenImpl()
 	categoryRepository := repository.NewCategoryRepositoryImpl(idGenerator)
-	categoryUseCase := usecase.NewCategoryUseCaseImpl(database, validation, categoryRepository)
-	categoryController := http.NewCategoryControllerImpl(categoryUseCase)
-	routeConfig := route.NewRouteConfigHttpRouter(router, categoryController)
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepositoryImpl(appConfig)
+	outboxEventRepository := repository.NewOutboxEventRepositoryImpl()
+	categoryUseCase := usecase.NewCategoryUseCase(appConfig, database, validation, categoryRepository, idempotencyKeyRepository, outboxEventRepository, categoryCache)
+	categoryController := http.NewCategoryControllerImpl(appConfig, categoryUseCase)
+	categoryEventController := http.NewCategoryEventControllerImpl(appConfig, broker)
+	apiKeyRepository := repository.NewApiKeyRepositoryImpl(idGenerator)
+	tenantRepository := repository.NewTenantRepositoryImpl(idGenerator)
+	requestNonceRepository := repository.NewRequestNonceRepositoryImpl(appConfig)
+	apiKeyUseCase := usecase.NewApiKeyUseCaseImpl(appConfig, database, validation, idGenerator, apiKeyRepository, tenantRepository, requestNonceRepository)
+	categorySubscriptionController := http.NewCategorySubscriptionControllerImpl(appConfig, broker, apiKeyUseCase)
+	openApiController := http.NewOpenApiControllerImpl()
+	graphqlController := graphql.NewGraphqlControllerImpl(appConfig, logger, categoryUseCase)
+	schemaMigrationRepository := repository.NewSchemaMigrationRepositoryImpl()
+	healthUseCase := usecase.NewHealthUseCaseImpl(database, logger, lifecycle, schemaMigrationRepository)
+	healthController := http.NewHealthControllerImpl(healthUseCase)
+	webhookSubscriptionRepository := repository.NewWebhookSubscriptionRepositoryImpl(idGenerator)
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepositoryImpl()
+	webhookUseCase := usecase.NewWebhookUseCaseImpl(database, validation, idGenerator, webhookSubscriptionRepository, webhookDeliveryRepository)
+	webhookController := http.NewWebhookControllerImpl(appConfig, webhookUseCase)
+	apiKeyController := http.NewApiKeyControllerImpl(appConfig, apiKeyUseCase)
+	tenantUseCase := usecase.NewTenantUseCaseImpl(database, validation, tenantRepository)
+	tenantController := http.NewTenantControllerImpl(appConfig, tenantUseCase)
+	routeConfig := route.NewRouteConfigHttpRouter(appConfig, router, categoryController, categoryEventController, categorySubscriptionController, openApiController, graphqlController, healthController, webhookController, apiKeyController, tenantController)
 	return routeConfig
 }
 
+func InitializeApiKeyUseCaseForTesting(appConfig *config.AppConfig, database db.PgxPool) usecase.ApiKeyUseCase {
+	validation := security.NewValidationImpl()
+	idGenerator := security.NewIdGenImpl()
+	apiKeyRepository := repository.NewApiKeyRepositoryImpl(idGenerator)
+	tenantRepository := repository.NewTenantRepositoryImpl(idGenerator)
+	requestNonceRepository := repository.NewRequestNonceRepositoryImpl(appConfig)
+	apiKeyUseCase := usecase.NewApiKeyUseCaseImpl(appConfig, database, validation, idGenerator, apiKeyRepository, tenantRepository, requestNonceRepository)
+	return apiKeyUseCase
+}
+
+func InitializeWebhookDispatcherForTesting(appConfig *config.AppConfig, database db.PgxPool, logger *logrus.Logger) webhook.Dispatcher {
+	outboxEventRepository := repository.NewOutboxEventRepositoryImpl()
+	webhookDeliveryRepository := repository.NewWebhookDeliveryRepositoryImpl()
+	dispatcher := webhook.NewDispatcherImpl(appConfig, database, logger, outboxEventRepository, webhookDeliveryRepository)
+	return dispatcher
+}
+
+func InitializeCategoryEventListenerForTesting(appConfig *config.AppConfig, database db.PgxPool, logger *logrus.Logger, broker stream.Broker) stream.Listener {
+	pgxConnector := config.NewPgxConnector(appConfig)
+	outboxEventRepository := repository.NewOutboxEventRepositoryImpl()
+	listener := stream.NewListenerImpl(appConfig, pgxConnector, database, logger, outboxEventRepository, broker)
+	return listener
+}
+
 // injector_for_testing.go:
 
-var repositorySet = wire.NewSet(repository.NewCategoryRepositoryImpl)
+var repositorySet = wire.NewSet(repository.NewCategoryRepositoryImpl, repository.NewIdempotencyKeyRepositoryImpl, repository.NewSchemaMigrationRepositoryImpl, repository.NewOutboxEventRepositoryImpl, repository.NewWebhookSubscriptionRepositoryImpl, repository.NewWebhookDeliveryRepositoryImpl, repository.NewApiKeyRepositoryImpl, repository.NewRequestNonceRepositoryImpl, repository.NewTenantRepositoryImpl)
 
-var useCaseSet = wire.NewSet(usecase.NewCategoryUseCaseImpl)
+var useCaseSet = wire.NewSet(usecase.NewCategoryUseCase, usecase.NewHealthUseCaseImpl, usecase.NewWebhookUseCaseImpl, usecase.NewApiKeyUseCaseImpl, usecase.NewTenantUseCaseImpl)
 
-var controllerSet = wire.NewSet(http.NewCategoryControllerImpl)
+var controllerSet = wire.NewSet(http.NewCategoryControllerImpl, http.NewCategoryEventControllerImpl, http.NewCategorySubscriptionControllerImpl, http.NewOpenApiControllerImpl, graphql.NewGraphqlControllerImpl, http.NewHealthControllerImpl, http.NewWebhookControllerImpl, http.NewApiKeyControllerImpl, http.NewTenantControllerImpl)
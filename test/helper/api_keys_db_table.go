@@ -0,0 +1,38 @@
+package helper
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+type apiKeysDbTable struct {
+	AppConfig *config.AppConfig
+}
+
+func NewApiKeysDbTable(appConfig *config.AppConfig) *apiKeysDbTable {
+	return &apiKeysDbTable{
+		AppConfig: appConfig,
+	}
+}
+
+func (d *apiKeysDbTable) DeleteAll() {
+	pool := config.NewPgxPool(d.AppConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.AppConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.LogStdPanicIfError(err)
+
+	_, err = tx.Exec(ctx, "DELETE FROM request_nonces")
+	helper.TxRollbackIfError(ctx, tx, err)
+
+	_, err = tx.Exec(ctx, "DELETE FROM api_keys")
+	helper.TxRollbackIfError(ctx, tx, err)
+
+	helper.TxCommit(ctx, tx)
+}
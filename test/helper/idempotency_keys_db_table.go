@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+type idempotencyKeysDbTable struct {
+	AppConfig *config.AppConfig
+}
+
+func NewIdempotencyKeysDbTable(appConfig *config.AppConfig) *idempotencyKeysDbTable {
+	return &idempotencyKeysDbTable{
+		AppConfig: appConfig,
+	}
+}
+
+func (d *idempotencyKeysDbTable) Expire(key string) {
+	pool := config.NewPgxPool(d.AppConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.AppConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.LogStdPanicIfError(err)
+
+	_, err = tx.Exec(ctx, "UPDATE idempotency_keys SET expires_at = now() - interval '1 second' WHERE key = $1", key)
+	helper.TxRollbackIfError(ctx, tx, err)
+
+	helper.TxCommit(ctx, tx)
+}
+
+func (d *idempotencyKeysDbTable) DeleteAll() {
+	pool := config.NewPgxPool(d.AppConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.AppConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.LogStdPanicIfError(err)
+
+	_, err = tx.Exec(ctx, "DELETE FROM idempotency_keys")
+	helper.TxRollbackIfError(ctx, tx, err)
+
+	helper.TxCommit(ctx, tx)
+}
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/entity"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+type tenantsDbTable struct {
+	AppConfig *config.AppConfig
+}
+
+func NewTenantsDbTable(appConfig *config.AppConfig) *tenantsDbTable {
+	return &tenantsDbTable{
+		AppConfig: appConfig,
+	}
+}
+
+func (d *tenantsDbTable) Add(data *entity.Tenant) {
+	pool := config.NewPgxPool(d.AppConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.AppConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.LogStdPanicIfError(err)
+
+	_, err = tx.Exec(ctx, "INSERT INTO tenants (id, name) VALUES ($1, $2)", data.Id, data.Name)
+	helper.TxRollbackIfError(ctx, tx, err)
+
+	helper.TxCommit(ctx, tx)
+}
+
+// DeleteAll keeps the default tenant, the rows of the other tenants are
+// deleted with them
+func (d *tenantsDbTable) DeleteAll() {
+	pool := config.NewPgxPool(d.AppConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.AppConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.LogStdPanicIfError(err)
+
+	for _, table := range []string{"categories", "idempotency_keys", "outbox_events", "webhook_subscriptions", "api_keys"} {
+		_, err = tx.Exec(ctx, "DELETE FROM "+table+" WHERE tenant_id <> 'default'")
+		helper.TxRollbackIfError(ctx, tx, err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM tenants WHERE id <> 'default'")
+	helper.TxRollbackIfError(ctx, tx, err)
+
+	helper.TxCommit(ctx, tx)
+}
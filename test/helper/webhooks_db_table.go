@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"context"
+	"time"
+
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/config"
+	"github.com/syahdaromansyah/pzn-golang-restful-api/internal/helper"
+)
+
+type webhooksDbTable struct {
+	AppConfig *config.AppConfig
+}
+
+func NewWebhooksDbTable(appConfig *config.AppConfig) *webhooksDbTable {
+	return &webhooksDbTable{
+		AppConfig: appConfig,
+	}
+}
+
+// DeleteAll deletes the outbox events and the subscriptions, their deliveries
+// are deleted with them
+func (d *webhooksDbTable) DeleteAll() {
+	pool := config.NewPgxPool(d.AppConfig)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.AppConfig.Test.Timeout*time.Second)
+	defer cancel()
+
+	tx, err := pool.Begin(ctx)
+	helper.LogStdPanicIfError(err)
+
+	_, err = tx.Exec(ctx, "DELETE FROM outbox_events")
+	helper.TxRollbackIfError(ctx, tx, err)
+
+	_, err = tx.Exec(ctx, "DELETE FROM webhook_subscriptions")
+	helper.TxRollbackIfError(ctx, tx, err)
+
+	helper.TxCommit(ctx, tx)
+}